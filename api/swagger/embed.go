@@ -0,0 +1,9 @@
+// Package swagger embeds the Swagger document `make docs` generates from the swag annotations.
+package swagger
+
+import _ "embed"
+
+// Spec is the generated swagger.json, regenerate it with `make docs` after changing the annotations
+//
+//go:embed swagger.json
+var Spec []byte
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	"github.com/MayukhSobo/scaffold/pkg/config"
+	"github.com/MayukhSobo/scaffold/pkg/db"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+var (
+	conf   *viper.Viper
+	logger log.Logger
+
+	// Registered before init so config.NewConfig parses it along with the config flags
+	migrationsDir = flag.String("dir", db.DefaultMigrationsDir, "migrations directory, eg: --dir migrations")
+)
+
+func init() {
+	conf = config.NewConfig()
+	logger = log.MustCreateLoggerFromConfig(conf)
+}
+
+// Usage: migrate [--config configs/local.yml] [--dir migrations] [up|down|status]
+func main() {
+	if !flag.Parsed() {
+		flag.Parse()
+	}
+	command := flag.Arg(0)
+	if command == "" {
+		command = "up"
+	}
+
+	database := db.MustConnect(conf, logger)
+	defer database.Close()
+
+	migrator, err := db.NewMigrator(database, *migrationsDir, logger)
+	if err != nil {
+		logger.Fatal("Failed to load migrations", log.Error(err), log.String("dir", *migrationsDir))
+	}
+
+	ctx := context.Background()
+	switch command {
+	case "up":
+		err = migrator.Up(ctx)
+	case "down":
+		err = migrator.Down(ctx)
+	case "status":
+		var statuses []db.MigrationStatus
+		statuses, err = migrator.Status(ctx)
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%-16d %-60s %s\n", status.Version, status.Path, state)
+		}
+	default:
+		logger.Fatal("Unknown migrate command, expected up, down or status", log.String("command", command))
+	}
+	if err != nil {
+		logger.Fatal("Migration failed", log.Error(err), log.String("command", command))
+	}
+}
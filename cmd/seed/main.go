@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/MayukhSobo/scaffold/pkg/config"
+	"github.com/MayukhSobo/scaffold/pkg/db"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+var (
+	conf   *viper.Viper
+	logger log.Logger
+
+	// Registered before init so config.NewConfig parses them along with the config flags
+	seedFile = flag.String("file", "db/seeds/dev.yml", "JSON or YAML seed file, eg: --file db/seeds/dev.yml")
+	truncate = flag.String("truncate", "", "comma separated tables to empty before seeding, eg: --truncate users,products")
+)
+
+func init() {
+	conf = config.NewConfig()
+	logger = log.MustCreateLoggerFromConfig(conf)
+}
+
+// Usage: seed [--config configs/local.yml] [--file db/seeds/dev.yml] [--truncate users]
+func main() {
+	if !flag.Parsed() {
+		flag.Parse()
+	}
+
+	database := db.MustConnect(conf, logger)
+	defer database.Close()
+
+	ctx := context.Background()
+	seeder := db.NewSeeder(database, logger)
+
+	if *truncate != "" {
+		if err := seeder.Truncate(ctx, strings.Split(*truncate, ",")...); err != nil {
+			logger.Fatal("Failed to truncate tables", log.Error(err), log.String("tables", *truncate))
+		}
+	}
+
+	if err := seeder.LoadFromFile(ctx, *seedFile); err != nil {
+		logger.Fatal("Failed to seed database", log.Error(err), log.String("file", *seedFile))
+	}
+	logger.Info("Database seeded", log.String("file", *seedFile))
+}
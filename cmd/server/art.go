@@ -1,14 +0,0 @@
-package main
-
-// DisplayBanner shows the application startup banner
-func DisplayBanner() string {
-	return `
-███████╗ ██████╗ █████╗ ███████╗███████╗ ██████╗ ██╗     ██████╗ 
-██╔════╝██╔════╝██╔══██╗██╔════╝██╔════╝██╔═══██╗██║     ██╔══██╗
-███████╗██║     ███████║█████╗  █████╗  ██║   ██║██║     ██║  ██║
-╚════██║██║     ██╔══██║██╔══╝  ██╔══╝  ██║   ██║██║     ██║  ██║
-███████║╚██████╗██║  ██║██║     ██║     ╚██████╔╝███████╗██████╔╝
-╚══════╝ ╚═════╝╚═╝  ╚═╝╚═╝     ╚═╝      ╚═════╝ ╚══════╝╚═════╝
-🚀 High-Performance Application Scaffold 🚀
-`
-}
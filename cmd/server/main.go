@@ -1,12 +1,17 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"os"
 
 	"github.com/MayukhSobo/scaffold/internal/server"
+	"github.com/MayukhSobo/scaffold/pkg/banner"
 	"github.com/MayukhSobo/scaffold/pkg/config"
 	"github.com/MayukhSobo/scaffold/pkg/container"
 	"github.com/MayukhSobo/scaffold/pkg/db"
+	"github.com/MayukhSobo/scaffold/pkg/lifecycle"
 	"github.com/MayukhSobo/scaffold/pkg/log"
 	"github.com/spf13/viper"
 )
@@ -14,38 +19,87 @@ import (
 var (
 	conf   *viper.Viper
 	logger log.Logger
+
+	// Registered before init so config.NewConfig parses it along with the config flags
+	seedFile = flag.String("seed", "", "seed the database from a JSON or YAML file and exit, eg: --seed db/seeds/dev.yml")
 )
 
 func init() {
-	// Display startup banner
-	fmt.Println(DisplayBanner())
+	// Print config errors as a banner instead of a raw panic
+	defer func() {
+		if r := recover(); r != nil {
+			startupErr, ok := r.(*config.StartupError)
+			if !ok {
+				panic(r)
+			}
+			fmt.Fprint(os.Stderr, startupErr.Error())
+			os.Exit(1)
+		}
+	}()
+
 	conf = config.NewConfig()
-	var err error
-	logger, err = log.CreateLoggerFromConfig(conf)
-	if err != nil {
-		panic(fmt.Sprintf("failed to create logger: %v", err))
+
+	// Display startup banner, unless disabled with banner.enabled
+	if startupBanner := banner.Generate(conf); startupBanner != "" {
+		fmt.Println(startupBanner)
+	}
+	logger = log.MustCreateLoggerFromConfig(conf)
+	lifecycle.SetLogger(logger)
+
+	// Reload the config file on changes, settings read at startup still need a restart
+	if config.WatchEnabled() {
+		config.WatchConfig(conf, nil, logger)
 	}
 }
 
+// General API info for `make docs`
+//
+//	@title		Scaffold API
+//	@version	1.0
+//	@BasePath	/api/v1
 func main() {
 	logger.Info("Starting application with container pattern...")
 
-	// Create dependencies
-	logger.Info("Initializing dependencies...")
+	// Confirm which config keys were loaded on top of the defaults in production
+	if os.Getenv("APP_ENV") == "production" {
+		for _, change := range config.Diff(viper.New(), conf) {
+			logger.Info("Config key loaded", log.String("key", change.Key), log.String("type", change.Type))
+		}
+	}
 
 	// Create database connection using the db package
+	endPhase := lifecycle.Phase("database init")
 	database := db.MustConnect(conf, logger)
+	endPhase()
+
+	// Seed and exit when --seed is given (flags are not parsed by config when APP_CONF is set)
+	if !flag.Parsed() {
+		flag.Parse()
+	}
+	if *seedFile != "" {
+		endPhase = lifecycle.Phase("database seed")
+		if err := db.NewSeeder(database, logger).LoadFromFile(context.Background(), *seedFile); err != nil {
+			lifecycle.OnFail("database seed", fmt.Errorf("seed %s: %w", *seedFile, err))
+		}
+		endPhase()
+		return
+	}
 
 	// Create dependency container - this handles ALL dependencies
 	// When you add new services/repositories, just add them to the container
-	appContainer := container.NewTypedContainer(conf, logger, database)
-	logger.Info("Dependency container initialized with all services and repositories")
+	endPhase = lifecycle.Phase("container init")
+	appContainer, err := container.NewTypedContainer(conf, logger, database)
+	if err != nil {
+		lifecycle.OnFail("container init", err)
+	}
+	endPhase()
 
 	// Start server with container-based setup
-	logger.Info("Starting server with container-based routes...")
-	server.RunWithCustomSetup(conf, logger, func(s *server.FiberServer) {
-		// Setup business routes using container - scales to any number of services
-		s.SetupBusinessRoutesWithContainer(appContainer)
-		logger.Info("All business routes registered successfully via container")
+	server.RunWithContainer(appContainer, func(s *server.FiberServer) {
+		defer lifecycle.Phase("route registration")()
+		// Setup business routes using the container the server was created with
+		if err := s.SetupContainerRoutes(); err != nil {
+			lifecycle.OnFail("route registration", err)
+		}
 	})
 }
@@ -0,0 +1,23 @@
+package main
+
+import "github.com/MayukhSobo/scaffold/pkg/build"
+
+// Set with -ldflags "-X main.version=... -X main.commit=... -X main.buildTime=...", see the Makefile
+var (
+	version   string
+	commit    string
+	buildTime string
+)
+
+// init copies the ldflags values into pkg/build, keeping its defaults for plain go build
+func init() {
+	if version != "" {
+		build.Version = version
+	}
+	if commit != "" {
+		build.Commit = commit
+	}
+	if buildTime != "" {
+		build.BuildTime = buildTime
+	}
+}
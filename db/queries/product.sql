@@ -0,0 +1,10 @@
+-- name: GetProducts :many
+SELECT * FROM products;
+
+-- name: GetProduct :one
+SELECT * FROM products
+WHERE id = ?;
+
+-- name: CreateProduct :execresult
+INSERT INTO products (name, description, price_cents)
+VALUES (?, ?, ?);
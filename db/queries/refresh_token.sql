@@ -0,0 +1,17 @@
+-- name: CreateRefreshToken :exec
+INSERT INTO refresh_tokens (user_id, token_hash, expires_at)
+VALUES (?, ?, ?);
+
+-- name: GetRefreshTokenByHash :one
+SELECT * FROM refresh_tokens
+WHERE token_hash = ?;
+
+-- name: RevokeRefreshToken :execrows
+UPDATE refresh_tokens
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE id = ? AND revoked_at IS NULL;
+
+-- name: RevokeUserRefreshTokens :exec
+UPDATE refresh_tokens
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE user_id = ? AND revoked_at IS NULL;
@@ -13,4 +13,8 @@ SELECT * FROM users;
 
 -- name: GetUser :one
 SELECT * FROM users
-WHERE id = ?; 
\ No newline at end of file
+WHERE id = ?;
+
+-- name: CreateUser :execresult
+INSERT INTO users (username, email, password_hash, first_name, last_name)
+VALUES (?, ?, ?, ?, ?);
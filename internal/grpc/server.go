@@ -0,0 +1,144 @@
+// Package grpc provides the gRPC server run alongside the Fiber HTTP server.
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"syscall"
+	"time"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+const (
+	// DefaultPort is used when grpc.port is not set
+	DefaultPort = "9090"
+	// defaultShutdownTimeout is used when server.shutdown_timeout is not set, same as the Fiber server
+	defaultShutdownTimeout = 30 * time.Second
+)
+
+// NewGRPCServer creates a gRPC server with the health service registered and the logging and recovery
+// unary interceptors applied. grpc.max_recv_msg_size limits the size of received messages in bytes,
+// gRPC's 4MB default applies when it is not set.
+func NewGRPCServer(conf *viper.Viper, logger log.Logger) *grpc.Server {
+	opts := []grpc.ServerOption{
+		// Logging runs first so panics turned into errors by the recovery interceptor are logged too
+		grpc.ChainUnaryInterceptor(UnaryLoggingInterceptor(logger), UnaryRecoveryInterceptor(logger)),
+	}
+	if maxRecvMsgSize := conf.GetInt("grpc.max_recv_msg_size"); maxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(maxRecvMsgSize))
+	}
+
+	srv := grpc.NewServer(opts...)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	return srv
+}
+
+// UnaryLoggingInterceptor logs every unary call with its method, status code and latency
+func UnaryLoggingInterceptor(logger log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		fields := []log.Field{
+			log.String("method", info.FullMethod),
+			log.String("code", status.Code(err).String()),
+			log.Duration("latency", time.Since(start)),
+		}
+		if err != nil {
+			logger.Error("gRPC request failed", append(fields, log.Error(err))...)
+		} else {
+			logger.Info("gRPC request", fields...)
+		}
+		return resp, err
+	}
+}
+
+// UnaryRecoveryInterceptor turns panics in unary handlers into codes.Internal errors, logging the stack trace
+func UnaryRecoveryInterceptor(logger log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("gRPC handler panicked",
+					log.String("method", info.FullMethod),
+					log.String("panic", fmt.Sprint(r)),
+					log.String("stack", string(debug.Stack())),
+				)
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// RunGRPCServer serves srv on grpc.port and stops it gracefully on SIGINT or SIGTERM,
+// forcing the stop after server.shutdown_timeout
+func RunGRPCServer(srv *grpc.Server, conf *viper.Viper, logger log.Logger) {
+	port := conf.GetString("grpc.port")
+	if port == "" {
+		port = DefaultPort
+	}
+
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		logger.Errorf("gRPC server startup failed: %v", err)
+		os.Exit(1)
+	}
+
+	shutdownTimeout := conf.GetDuration("server.shutdown_timeout")
+	if shutdownTimeout == 0 {
+		shutdownTimeout = defaultShutdownTimeout
+	}
+
+	// Create a channel to listen for interrupt signals
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	logger.Infof("gRPC server starting on port %s", port)
+	if err := serve(srv, lis, logger, shutdownTimeout, quit); err != nil {
+		os.Exit(1)
+	}
+}
+
+// serve runs srv on lis until quit receives, then stops it gracefully within shutdownTimeout.
+// It returns the error of Serve, if the server stopped on its own.
+func serve(srv *grpc.Server, lis net.Listener, logger log.Logger, shutdownTimeout time.Duration, quit <-chan os.Signal) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(lis)
+	}()
+
+	select {
+	case err := <-serveErr:
+		logger.Errorf("gRPC server failed: %v", err)
+		return err
+	case <-quit:
+	}
+	logger.Info("Shutting down gRPC server...")
+
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		logger.Info("gRPC server exited")
+	case <-time.After(shutdownTimeout):
+		srv.Stop()
+		logger.Error("gRPC server forced to shutdown", log.Duration("timeout", shutdownTimeout))
+	}
+	return nil
+}
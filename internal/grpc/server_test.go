@@ -0,0 +1,155 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// syncBuffer is a bytes.Buffer safe to write from the server goroutines and read from the test
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// startTestServer serves a server from NewGRPCServer over bufconn and returns a connected client
+func startTestServer(t *testing.T, logger log.Logger) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	srv := NewGRPCServer(viper.New(), logger)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial bufconn: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestGRPCServerHealth(t *testing.T) {
+	var buf syncBuffer
+	conn := startTestServer(t, log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Failed to check health: %v", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("Expected status %s, got %s", healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Expected 1 log entry, got %d: %s", len(lines), buf.String())
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("Failed to parse log entry %q: %v", lines[0], err)
+	}
+	expected := map[string]interface{}{
+		"level":   "info",
+		"message": "gRPC request",
+		"method":  healthpb.Health_Check_FullMethodName,
+		"code":    codes.OK.String(),
+	}
+	for key, value := range expected {
+		if entry[key] != value {
+			t.Errorf("Expected %s %v, got %v", key, value, entry[key])
+		}
+	}
+	if _, ok := entry["latency"]; !ok {
+		t.Error("Expected a latency field")
+	}
+}
+
+func TestGRPCServerHealthUnknownService(t *testing.T) {
+	var buf syncBuffer
+	conn := startTestServer(t, log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected code %s, got %s", codes.NotFound, status.Code(err))
+	}
+	if !strings.Contains(buf.String(), `"message":"gRPC request failed"`) || !strings.Contains(buf.String(), `"code":"NotFound"`) {
+		t.Errorf("Expected the failed call to be logged, got %s", buf.String())
+	}
+}
+
+func TestUnaryRecoveryInterceptor(t *testing.T) {
+	var buf bytes.Buffer
+	interceptor := UnaryRecoveryInterceptor(log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false))
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Panic"}
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	})
+
+	if status.Code(err) != codes.Internal {
+		t.Errorf("Expected code %s, got %s", codes.Internal, status.Code(err))
+	}
+	if !strings.Contains(buf.String(), `"panic":"boom"`) || !strings.Contains(buf.String(), `"stack":`) {
+		t.Errorf("Expected the panic and stack trace to be logged, got %s", buf.String())
+	}
+}
+
+func TestServeGracefulShutdown(t *testing.T) {
+	var buf syncBuffer
+	logger := log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false)
+
+	quit := make(chan os.Signal, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- serve(NewGRPCServer(viper.New(), logger), bufconn.Listen(1024), logger, time.Second, quit)
+	}()
+
+	quit <- syscall.SIGTERM
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the server to stop")
+	}
+	if !strings.Contains(buf.String(), "gRPC server exited") {
+		t.Errorf("Expected a graceful exit to be logged, got %s", buf.String())
+	}
+}
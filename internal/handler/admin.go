@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/spf13/viper"
+
+	"github.com/MayukhSobo/scaffold/internal/service"
+	"github.com/MayukhSobo/scaffold/pkg/config"
+	"github.com/MayukhSobo/scaffold/pkg/http"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+func NewAdminHandler(handler *Handler, userService service.UserService, conf *viper.Viper) *AdminHandler {
+	return &AdminHandler{
+		Handler:     handler,
+		userService: userService,
+		config:      conf,
+	}
+}
+
+type AdminHandler struct {
+	*Handler
+	userService service.UserService
+	config      *viper.Viper
+}
+
+// ListUsers retrieves all users for user management
+func (h *AdminHandler) ListUsers(c *fiber.Ctx) error {
+	logger := h.RequestLogger(c)
+	logger.Info("ListUsers called")
+
+	ctx := h.ServiceContext(c)
+	allUsers, err := h.userService.GetUsers(ctx)
+	if err != nil {
+		logger.Error("Failed to retrieve users", log.Error(err))
+		return http.HandleFiberError(c, fiber.StatusInternalServerError, "Failed to retrieve users")
+	}
+
+	// Convert to response models (excludes password_hash)
+	userResponses := ToUserResponses(allUsers)
+
+	logger.Info("Retrieved users", log.Int("count", len(allUsers)))
+	return http.HandleFiberSuccess(c, fiber.Map{
+		"users": userResponses,
+		"count": len(userResponses),
+	})
+}
+
+// GetSystemConfig returns the loaded configuration with credentials redacted
+func (h *AdminHandler) GetSystemConfig(c *fiber.Ctx) error {
+	logger := h.RequestLogger(c)
+	logger.Info("GetSystemConfig called")
+
+	data, err := config.ExportJSON(h.config, config.DefaultRedactKeys)
+	if err != nil {
+		logger.Error("Failed to export config", log.Error(err))
+		return http.HandleFiberError(c, fiber.StatusInternalServerError, "Failed to export config")
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Send(data)
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/MayukhSobo/scaffold/internal/service"
+	"github.com/MayukhSobo/scaffold/pkg/http"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+func NewAuthHandler(handler *Handler, userService service.UserService) *AuthHandler {
+	return &AuthHandler{
+		Handler:     handler,
+		userService: userService,
+	}
+}
+
+type AuthHandler struct {
+	*Handler
+	userService service.UserService
+}
+
+// RefreshTokenRequest is the request body for the token refresh endpoint
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshToken exchanges a refresh token for a new access token and a rotated refresh token
+func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
+	logger := h.RequestLogger(c)
+	logger.Info("RefreshToken called")
+
+	var req RefreshTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Warn("Invalid refresh request body", log.Error(err))
+		return http.HandleFiberBadRequest(c, "Invalid request body")
+	}
+	if req.RefreshToken == "" {
+		return http.HandleFiberBadRequest(c, "refresh_token is required")
+	}
+
+	ctx := h.ServiceContext(c)
+	accessToken, refreshToken, err := h.userService.RefreshAccessToken(ctx, req.RefreshToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrRefreshTokenReused):
+			logger.Warn("Refresh token reuse detected, revoked user sessions")
+			return http.HandleFiberUnauthorized(c, "Invalid refresh token")
+		case errors.Is(err, service.ErrInvalidRefreshToken), errors.Is(err, service.ErrRefreshTokenExpired):
+			logger.Info("Refresh token rejected", log.Error(err))
+			return http.HandleFiberUnauthorized(c, "Invalid refresh token")
+		default:
+			logger.Error("Failed to refresh access token", log.Error(err))
+			return http.HandleFiberError(c, fiber.StatusInternalServerError, "Failed to refresh access token")
+		}
+	}
+
+	return http.HandleFiberSuccess(c, fiber.Map{
+		"access_token":  accessToken,
+		"refresh_token": refreshToken,
+		"token_type":    "Bearer",
+	})
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+
+	"github.com/MayukhSobo/scaffold/internal/service"
+	"github.com/MayukhSobo/scaffold/pkg/http"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// serviceErrorStatuses maps the service error kinds to HTTP status codes
+var serviceErrorStatuses = []struct {
+	kind   error
+	status int
+}{
+	{service.ErrNotFound, fiber.StatusNotFound},
+	{service.ErrConflict, fiber.StatusConflict},
+	{service.ErrUnauthorized, fiber.StatusUnauthorized},
+	{service.ErrValidation, fiber.StatusBadRequest},
+}
+
+// ServiceErrorStatus returns the HTTP status code for a service error, 500 for errors of no known kind
+func ServiceErrorStatus(err error) int {
+	for _, mapping := range serviceErrorStatuses {
+		if errors.Is(err, mapping.kind) {
+			return mapping.status
+		}
+	}
+	return fiber.StatusInternalServerError
+}
+
+// HandleServiceError sends the response for an error returned by the service layer.
+// Known kinds are answered with their status code, the ServiceError message and its field details;
+// other errors are logged with fields and answered with 500 and message.
+func (h *Handler) HandleServiceError(c *fiber.Ctx, err error, message string, fields ...log.Field) error {
+	status := ServiceErrorStatus(err)
+	if status == fiber.StatusInternalServerError {
+		h.RequestLogger(c).Error(message, append(fields, log.Error(err))...)
+		return http.HandleFiberError(c, status, message)
+	}
+
+	var serr *service.ServiceError
+	if !errors.As(err, &serr) {
+		// A bare kind carries no client-safe message
+		return http.HandleFiberError(c, status, utils.StatusMessage(status))
+	}
+	if len(serr.Details) > 0 {
+		return http.HandleFiberErrorWithData(c, status, serr.Message, fiber.Map{"details": serr.Details})
+	}
+	return http.HandleFiberError(c, status, serr.Message)
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/MayukhSobo/scaffold/internal/repository/users"
+	"github.com/MayukhSobo/scaffold/internal/service"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// failingUserService returns err from GetUserById
+type failingUserService struct {
+	stubUserService
+	err error
+}
+
+func (s *failingUserService) GetUserById(ctx context.Context, id int64) (users.User, error) {
+	return users.User{}, s.err
+}
+
+func TestUserHandlerServiceErrorStatus(t *testing.T) {
+	testCases := []struct {
+		name            string
+		err             error
+		expectedStatus  int
+		expectedMessage string
+		expectedDetails map[string]interface{}
+	}{
+		{"not found", service.NewServiceError(service.ErrNotFound, "user not found", "USER_NOT_FOUND"), fiber.StatusNotFound, "user not found", nil},
+		{"conflict", service.NewServiceError(service.ErrConflict, "email already registered", ""), fiber.StatusConflict, "email already registered", nil},
+		{"unauthorized", service.NewServiceError(service.ErrUnauthorized, "not allowed", ""), fiber.StatusUnauthorized, "not allowed", nil},
+		{
+			"validation with details",
+			service.NewServiceError(service.ErrValidation, "invalid user", "").WithDetail("email", "is taken"),
+			fiber.StatusBadRequest, "invalid user", map[string]interface{}{"email": "is taken"},
+		},
+		{"wrapped bare kind", fmt.Errorf("lookup: %w", service.ErrNotFound), fiber.StatusNotFound, "Not Found", nil},
+		{"unexpected error", errors.New("connection reset"), fiber.StatusInternalServerError, "Failed to retrieve user", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := log.NewConsoleLoggerWithWriter(log.InfoLevel, &bytes.Buffer{}, false)
+			userHandler := NewUserHandler(NewHandler(logger), &failingUserService{err: tc.err})
+
+			app := fiber.New()
+			app.Get("/users/:id", userHandler.GetUserById)
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/users/1", nil))
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			var response struct {
+				Code    int    `json:"code"`
+				Message string `json:"message"`
+				Data    struct {
+					Details map[string]interface{} `json:"details"`
+				} `json:"data"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to parse JSON response: %v", err)
+			}
+			if response.Message != tc.expectedMessage {
+				t.Errorf("Expected message '%s', got '%s'", tc.expectedMessage, response.Message)
+			}
+			if len(response.Data.Details) != len(tc.expectedDetails) {
+				t.Errorf("Expected details %v, got %v", tc.expectedDetails, response.Data.Details)
+			}
+			for field, problem := range tc.expectedDetails {
+				if response.Data.Details[field] != problem {
+					t.Errorf("Expected detail %s '%v', got '%v'", field, problem, response.Data.Details[field])
+				}
+			}
+		})
+	}
+}
+
+func TestHandleServiceErrorLogsUnexpectedErrors(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false)
+	userHandler := NewUserHandler(NewHandler(logger), &failingUserService{err: errors.New("connection reset")})
+
+	app := fiber.New()
+	app.Get("/users/:id", userHandler.GetUserById)
+	if _, err := app.Test(httptest.NewRequest("GET", "/users/7", nil)); err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"Failed to retrieve user", "connection reset", `"id":7`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected log to contain %q, got: %s", want, output)
+		}
+	}
+}
@@ -1,7 +1,23 @@
 package handler
 
 import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+
 	"github.com/MayukhSobo/scaffold/pkg/log"
+	"github.com/MayukhSobo/scaffold/pkg/utils"
+)
+
+// Locals keys populated by the request ID and auth middleware
+const (
+	RequestIDLocalsKey  = "requestid"
+	UserIDLocalsKey     = "user_id"
+	UserClaimsLocalsKey = "user"
 )
 
 type Handler struct {
@@ -18,3 +34,61 @@ func NewHandler(logger log.Logger) *Handler {
 func (h *Handler) GetLogger() log.Logger {
 	return h.logger
 }
+
+// RequestLogger returns the handler logger enriched with request-scoped fields
+func (h *Handler) RequestLogger(c *fiber.Ctx) log.Logger {
+	return RequestLogger(c, h.logger)
+}
+
+// RequestLogger enriches base with the request ID and user ID stored in c.Locals
+func RequestLogger(c *fiber.Ctx, base log.Logger) log.Logger {
+	var fields []log.Field
+
+	if requestID := c.Locals(RequestIDLocalsKey); requestID != nil {
+		fields = append(fields, log.String("request_id", fmt.Sprint(requestID)))
+	}
+	if userID := c.Locals(UserIDLocalsKey); userID != nil {
+		fields = append(fields, log.String("user_id", fmt.Sprint(userID)))
+	}
+
+	if len(fields) == 0 {
+		return base
+	}
+	return base.WithFields(fields...)
+}
+
+// ServiceContext returns the request's context carrying a utils.RequestContext for service calls
+func (h *Handler) ServiceContext(c *fiber.Ctx) context.Context {
+	return utils.WithRequestContext(c.UserContext(), NewRequestContext(c))
+}
+
+// NewRequestContext collects request metadata from the JWT claims, c.Locals and headers
+func NewRequestContext(c *fiber.Ctx) utils.RequestContext {
+	var rc utils.RequestContext
+
+	if requestID := c.Locals(RequestIDLocalsKey); requestID != nil {
+		rc.RequestID = fmt.Sprint(requestID)
+	}
+	if userID := c.Locals(UserIDLocalsKey); userID != nil {
+		rc.UserID, _ = strconv.ParseUint(fmt.Sprint(userID), 10, 64)
+	}
+	if claims, ok := c.Locals(UserClaimsLocalsKey).(jwt.MapClaims); ok {
+		rc.Role, _ = claims["role"].(string)
+	}
+
+	// First language tag of Accept-Language, e.g. "en-GB,en;q=0.9" -> "en-GB"
+	if acceptLanguage := c.Get(fiber.HeaderAcceptLanguage); acceptLanguage != "" {
+		tag, _, _ := strings.Cut(acceptLanguage, ",")
+		tag, _, _ = strings.Cut(tag, ";")
+		rc.Locale = strings.TrimSpace(tag)
+	}
+
+	// W3C traceparent is "version-traceid-spanid-flags"
+	if parts := strings.Split(c.Get("traceparent"), "-"); len(parts) == 4 {
+		rc.TraceID = parts[1]
+	} else {
+		rc.TraceID = c.Get("X-Trace-Id")
+	}
+
+	return rc
+}
@@ -2,9 +2,19 @@ package handler
 
 import (
 	"bytes"
+	"context"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/MayukhSobo/scaffold/internal/repository/users"
+	"github.com/MayukhSobo/scaffold/internal/service"
 	"github.com/MayukhSobo/scaffold/pkg/log"
+	"github.com/MayukhSobo/scaffold/pkg/utils"
 )
 
 func TestNewHandler(t *testing.T) {
@@ -57,3 +67,186 @@ func TestHandlerInterface(t *testing.T) {
 		t.Error("GetLogger() did not return the same logger instance")
 	}
 }
+
+func TestRequestLogger(t *testing.T) {
+	testCases := []struct {
+		name     string
+		locals   map[string]interface{}
+		expected []string
+		absent   []string
+	}{
+		{
+			name:     "request and user ID",
+			locals:   map[string]interface{}{RequestIDLocalsKey: "req-123", UserIDLocalsKey: 42},
+			expected: []string{`"request_id":"req-123"`, `"user_id":"42"`},
+		},
+		{
+			name:     "request ID only",
+			locals:   map[string]interface{}{RequestIDLocalsKey: "req-456"},
+			expected: []string{`"request_id":"req-456"`},
+			absent:   []string{"user_id"},
+		},
+		{
+			name:   "no locals",
+			locals: map[string]interface{}{},
+			absent: []string{"request_id", "user_id"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			base := log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false)
+
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				for k, v := range tc.locals {
+					c.Locals(k, v)
+				}
+				RequestLogger(c, base).Info("request handled")
+				return nil
+			})
+
+			if _, err := app.Test(httptest.NewRequest("GET", "/", nil)); err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+
+			output := buf.String()
+			for _, want := range tc.expected {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected output to contain %s, got: %s", want, output)
+				}
+			}
+			for _, unwanted := range tc.absent {
+				if strings.Contains(output, unwanted) {
+					t.Errorf("Expected output not to contain %s, got: %s", unwanted, output)
+				}
+			}
+		})
+	}
+}
+
+// stubUserService implements service.UserService for handler tests
+type stubUserService struct{}
+
+func (s *stubUserService) GetUserById(ctx context.Context, id int64) (users.User, error) {
+	return users.User{ID: uint64(id)}, nil
+}
+
+func (s *stubUserService) GetUsers(ctx context.Context) ([]users.User, error) {
+	return nil, nil
+}
+
+func (s *stubUserService) GetAdminUsers(ctx context.Context) ([]users.User, error) {
+	return []users.User{{ID: 1, Username: "admin"}}, nil
+}
+
+func (s *stubUserService) GetPendingVerificationUsers(ctx context.Context) ([]users.User, error) {
+	return nil, nil
+}
+
+func (s *stubUserService) CreateUser(ctx context.Context, req service.CreateUserRequest) (users.User, error) {
+	return users.User{ID: 1, Username: req.Username, Email: req.Email}, nil
+}
+
+func (s *stubUserService) RefreshAccessToken(ctx context.Context, refreshToken string) (string, string, error) {
+	return "", "", service.ErrInvalidRefreshToken
+}
+
+func TestUserHandlerLogsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false)
+	userHandler := NewUserHandler(NewHandler(logger), &stubUserService{})
+
+	app := fiber.New()
+	app.Use(requestid.New())
+	app.Get("/users/admin", userHandler.GetAdminUsers)
+
+	req := httptest.NewRequest("GET", "/users/admin", nil)
+	req.Header.Set(fiber.HeaderXRequestID, "known-request-id")
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) < 2 {
+		t.Fatalf("Expected at least 2 log lines, got %d: %s", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, `"request_id":"known-request-id"`) {
+			t.Errorf("Expected log line to contain request ID, got: %s", line)
+		}
+	}
+}
+
+// contextCapturingUserService records the context passed to GetAdminUsers
+type contextCapturingUserService struct {
+	stubUserService
+	ctx context.Context
+}
+
+func (s *contextCapturingUserService) GetAdminUsers(ctx context.Context) ([]users.User, error) {
+	s.ctx = ctx
+	return nil, nil
+}
+
+func TestUserHandlerPropagatesRequestContext(t *testing.T) {
+	logger := log.NewConsoleLoggerWithWriter(log.InfoLevel, &bytes.Buffer{}, false)
+	userService := &contextCapturingUserService{}
+	userHandler := NewUserHandler(NewHandler(logger), userService)
+
+	app := fiber.New()
+	app.Use(requestid.New())
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(UserIDLocalsKey, "42")
+		c.Locals(UserClaimsLocalsKey, jwt.MapClaims{"sub": "42", "role": "admin"})
+		return c.Next()
+	})
+	app.Get("/users/admin", userHandler.GetAdminUsers)
+
+	req := httptest.NewRequest("GET", "/users/admin", nil)
+	req.Header.Set(fiber.HeaderXRequestID, "req-789")
+	req.Header.Set(fiber.HeaderAcceptLanguage, "en-GB,en;q=0.9")
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	rc, ok := utils.GetRequestContext(userService.ctx)
+	if !ok {
+		t.Fatal("Expected request context to be propagated to the service")
+	}
+
+	expected := utils.RequestContext{
+		UserID:    42,
+		Role:      "admin",
+		Locale:    "en-GB",
+		RequestID: "req-789",
+		TraceID:   "4bf92f3577b34da6a3ce929d0e0e4736",
+	}
+	if rc != expected {
+		t.Errorf("Expected request context %+v, got %+v", expected, rc)
+	}
+}
+
+func TestNewRequestContextTraceIDFallback(t *testing.T) {
+	app := fiber.New()
+	var rc utils.RequestContext
+	app.Get("/", func(c *fiber.Ctx) error {
+		rc = NewRequestContext(c)
+		return nil
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Trace-Id", "trace-abc")
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	if rc.TraceID != "trace-abc" {
+		t.Errorf("Expected trace ID trace-abc, got %s", rc.TraceID)
+	}
+	if rc.UserID != 0 || rc.Role != "" || rc.RequestID != "" {
+		t.Errorf("Expected empty identity fields, got %+v", rc)
+	}
+}
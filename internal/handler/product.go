@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/MayukhSobo/scaffold/internal/repository/products"
+	"github.com/MayukhSobo/scaffold/internal/service"
+	"github.com/MayukhSobo/scaffold/pkg/http"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+func NewProductHandler(handler *Handler, productService service.ProductService) *ProductHandler {
+	return &ProductHandler{
+		Handler:        handler,
+		productService: productService,
+	}
+}
+
+type ProductHandler struct {
+	*Handler
+	productService service.ProductService
+}
+
+// GetAllProducts retrieves all products
+func (h *ProductHandler) GetAllProducts(c *fiber.Ctx) error {
+	logger := h.RequestLogger(c)
+	logger.Info("GetAllProducts called")
+
+	ctx := context.Background()
+	allProducts, err := h.productService.GetProducts(ctx)
+	if err != nil {
+		logger.Error("Failed to retrieve products", log.Error(err))
+		return http.HandleFiberError(c, fiber.StatusInternalServerError, "Failed to retrieve products")
+	}
+
+	logger.Info("Retrieved products", log.Int("count", len(allProducts)))
+	return http.HandleFiberSuccess(c, fiber.Map{
+		"products": allProducts,
+		"count":    len(allProducts),
+	})
+}
+
+// GetProductById retrieves a single product by its ID
+func (h *ProductHandler) GetProductById(c *fiber.Ctx) error {
+	logger := h.RequestLogger(c)
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return http.HandleFiberBadRequest(c, "Invalid product ID")
+	}
+
+	ctx := context.Background()
+	product, err := h.productService.GetProduct(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return http.HandleFiberNotFound(c, "Product not found")
+		}
+		logger.Error("Failed to retrieve product", log.Int("id", id), log.Error(err))
+		return http.HandleFiberError(c, fiber.StatusInternalServerError, "Failed to retrieve product")
+	}
+
+	return http.HandleFiberSuccess(c, fiber.Map{
+		"product": product,
+	})
+}
+
+// CreateProduct creates a new product from the request body
+func (h *ProductHandler) CreateProduct(c *fiber.Ctx) error {
+	logger := h.RequestLogger(c)
+
+	var params products.CreateProductParams
+	if err := c.BodyParser(&params); err != nil {
+		return http.HandleFiberBadRequest(c, "Invalid request body")
+	}
+	if params.Name == "" {
+		return http.HandleFiberBadRequest(c, "Product name is required")
+	}
+
+	ctx := context.Background()
+	product, err := h.productService.CreateProduct(ctx, params)
+	if err != nil {
+		logger.Error("Failed to create product", log.Error(err))
+		return http.HandleFiberError(c, fiber.StatusInternalServerError, "Failed to create product")
+	}
+
+	logger.Info("Created product", log.Any("id", product.ID))
+	return http.HandleFiberSuccess(c, fiber.Map{
+		"product": product,
+	})
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/MayukhSobo/scaffold/pkg/container"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// RequestContainerLocalsKey is the c.Locals key holding the *RequestContainer of the request
+const RequestContainerLocalsKey = "container"
+
+// RequestContainer holds request-scoped dependencies on top of the shared TypedContainer.
+// Infrastructure, repositories and services come from the embedded parent.
+type RequestContainer struct {
+	*container.TypedContainer
+
+	requestID string
+	userID    uint64
+	logger    log.Logger
+}
+
+// NewRequestContainer derives a container for the request from parent, with a logger carrying the
+// request_id and user_id fields, and stores it in c.Locals("container").
+// The request ID comes from the requestid middleware and the user ID from the JWT middleware, when they run first.
+func NewRequestContainer(parent *container.TypedContainer, c *fiber.Ctx) *RequestContainer {
+	rc := &RequestContainer{
+		TypedContainer: parent,
+		logger:         RequestLogger(c, parent.GetLogger()),
+	}
+	if requestID := c.Locals(RequestIDLocalsKey); requestID != nil {
+		rc.requestID = fmt.Sprint(requestID)
+	}
+	if userID := c.Locals(UserIDLocalsKey); userID != nil {
+		rc.userID, _ = strconv.ParseUint(fmt.Sprint(userID), 10, 64)
+	}
+
+	c.Locals(RequestContainerLocalsKey, rc)
+	return rc
+}
+
+// RequestContainerMiddleware creates a RequestContainer from parent for every request
+func RequestContainerMiddleware(parent *container.TypedContainer) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		NewRequestContainer(parent, c)
+		return c.Next()
+	}
+}
+
+// GetRequestContainer returns the RequestContainer stored by NewRequestContainer, or nil
+func GetRequestContainer(c *fiber.Ctx) *RequestContainer {
+	rc, _ := c.Locals(RequestContainerLocalsKey).(*RequestContainer)
+	return rc
+}
+
+// GetLogger returns the request-scoped logger
+func (rc *RequestContainer) GetLogger() log.Logger {
+	return rc.logger
+}
+
+// RequestID returns the request ID, empty when the requestid middleware did not run
+func (rc *RequestContainer) RequestID() string {
+	return rc.requestID
+}
+
+// UserID returns the authenticated user's ID, 0 for anonymous requests
+func (rc *RequestContainer) UserID() uint64 {
+	return rc.userID
+}
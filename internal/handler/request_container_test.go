@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"io"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/spf13/viper"
+
+	"github.com/MayukhSobo/scaffold/pkg/container"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// fieldsLogger records the fields added with WithFields
+type fieldsLogger struct {
+	log.Logger
+	fields map[string]any
+}
+
+func (l *fieldsLogger) WithFields(fields ...log.Field) log.Logger {
+	derived := &fieldsLogger{Logger: l.Logger, fields: make(map[string]any, len(l.fields)+len(fields))}
+	for key, value := range l.fields {
+		derived.fields[key] = value
+	}
+	for _, field := range fields {
+		derived.fields[field.Key] = field.Value
+	}
+	return derived
+}
+
+func TestRequestContainerConcurrentRequests(t *testing.T) {
+	parent := container.NewTypedContainerWithoutDB(viper.New(), &fieldsLogger{Logger: log.NewConsoleLoggerWithWriter(log.InfoLevel, io.Discard, false)})
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("requestid", c.Get("X-Request-ID"))
+		c.Locals("user_id", "42")
+		return c.Next()
+	})
+	app.Use(RequestContainerMiddleware(parent))
+
+	var mu sync.Mutex
+	loggers := make(map[string]*fieldsLogger)
+	app.Get("/", func(c *fiber.Ctx) error {
+		rc := GetRequestContainer(c)
+		if rc.UserID() != 42 {
+			t.Errorf("Expected user ID 42, got %d", rc.UserID())
+		}
+		if rc.GetConfig() != parent.GetConfig() {
+			t.Error("Expected the request container to share the parent config")
+		}
+
+		mu.Lock()
+		loggers[rc.RequestID()] = rc.GetLogger().(*fieldsLogger)
+		mu.Unlock()
+		return c.SendString(rc.RequestID())
+	})
+
+	var wg sync.WaitGroup
+	for _, requestID := range []string{"req-1", "req-2"} {
+		wg.Add(1)
+		go func(requestID string) {
+			defer wg.Done()
+			req := httptest.NewRequest("GET", "/", nil)
+			req.Header.Set("X-Request-ID", requestID)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Errorf("Failed to test request: %v", err)
+				return
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != requestID {
+				t.Errorf("Expected request ID %q, got %q", requestID, body)
+			}
+		}(requestID)
+	}
+	wg.Wait()
+
+	first, second := loggers["req-1"], loggers["req-2"]
+	if first == nil || second == nil {
+		t.Fatalf("Expected a logger per request, got %v", loggers)
+	}
+	if first == second {
+		t.Error("Expected distinct loggers for concurrent requests")
+	}
+	if first.fields["request_id"] != "req-1" || second.fields["request_id"] != "req-2" {
+		t.Errorf("Expected distinct request_id fields, got %v and %v", first.fields["request_id"], second.fields["request_id"])
+	}
+	if parent.GetLogger().(*fieldsLogger).fields != nil {
+		t.Error("Expected the parent logger to stay unscoped")
+	}
+}
@@ -1,8 +1,6 @@
 package handler
 
 import (
-	"context"
-
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/MayukhSobo/scaffold/internal/service"
@@ -22,44 +20,98 @@ type UserHandler struct {
 	userService service.UserService
 }
 
+// GetUserById retrieves a single user by its ID
+func (h *UserHandler) GetUserById(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return http.HandleFiberBadRequest(c, "Invalid user ID")
+	}
+
+	ctx := h.ServiceContext(c)
+	user, err := h.userService.GetUserById(ctx, int64(id))
+	if err != nil {
+		return h.HandleServiceError(c, err, "Failed to retrieve user", log.Int("id", id))
+	}
+
+	return http.HandleFiberSuccess(c, UserDetailResponse{
+		User: ToUserResponse(&user),
+	})
+}
+
+// CreateUser creates a new user from the request body
+func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
+	logger := h.RequestLogger(c)
+
+	req, err := ParseAndValidate[service.CreateUserRequest](c)
+	if err != nil {
+		return nil // ParseAndValidate sent the 400 response
+	}
+
+	ctx := h.ServiceContext(c)
+	user, err := h.userService.CreateUser(ctx, req)
+	if err != nil {
+		logger.Error("Failed to create user", log.Error(err))
+		return http.HandleFiberError(c, fiber.StatusInternalServerError, "Failed to create user")
+	}
+
+	logger.Info("Created user", log.Any("id", user.ID))
+	return http.HandleFiberSuccess(c, UserDetailResponse{
+		User: ToUserResponse(&user),
+	})
+}
+
 // GetAdminUsers retrieves all users with admin access
+//
+//	@Summary	List admin users
+//	@Tags		users
+//	@Produce	json
+//	@Success	200	{object}	http.Response{data=UserListResponse}
+//	@Router		/users/admin [get]
 func (h *UserHandler) GetAdminUsers(c *fiber.Ctx) error {
-	h.GetLogger().Info("GetAdminUsers called")
+	logger := h.RequestLogger(c)
+	logger.Info("GetAdminUsers called")
 
-	ctx := context.Background()
+	ctx := h.ServiceContext(c)
 	adminUsers, err := h.userService.GetAdminUsers(ctx)
 	if err != nil {
-		h.GetLogger().Error("Failed to retrieve admin users", log.Error(err))
+		logger.Error("Failed to retrieve admin users", log.Error(err))
 		return http.HandleFiberError(c, fiber.StatusInternalServerError, "Failed to retrieve admin users")
 	}
 
 	// Convert to response models (excludes password_hash)
 	userResponses := ToUserResponses(adminUsers)
 
-	h.GetLogger().Info("Retrieved admin users", log.Int("count", len(adminUsers)))
-	return http.HandleFiberSuccess(c, fiber.Map{
-		"users": userResponses,
-		"count": len(userResponses),
+	logger.Info("Retrieved admin users", log.Int("count", len(adminUsers)))
+	return http.HandleFiberSuccess(c, UserListResponse{
+		Users: userResponses,
+		Count: len(userResponses),
 	})
 }
 
 // GetPendingVerificationUsers retrieves all users with pending verification status
+//
+//	@Summary	List users pending verification
+//	@Tags		users
+//	@Produce	json
+//	@Success	200	{object}	http.Response{data=UserListResponse}
+//	@Router		/users/pending-verification [get]
 func (h *UserHandler) GetPendingVerificationUsers(c *fiber.Ctx) error {
-	h.GetLogger().Info("GetPendingVerificationUsers called")
+	logger := h.RequestLogger(c)
+	logger.Info("GetPendingVerificationUsers called")
 
-	ctx := context.Background()
+	ctx := h.ServiceContext(c)
 	pendingUsers, err := h.userService.GetPendingVerificationUsers(ctx)
 	if err != nil {
-		h.GetLogger().Error("Failed to retrieve pending verification users", log.Error(err))
+		logger.Error("Failed to retrieve pending verification users", log.Error(err))
 		return http.HandleFiberError(c, fiber.StatusInternalServerError, "Failed to retrieve pending verification users")
 	}
 
 	// Convert to response models (excludes password_hash)
 	userResponses := ToUserResponses(pendingUsers)
 
-	h.GetLogger().Info("Retrieved pending verification users", log.Int("count", len(pendingUsers)))
-	return http.HandleFiberSuccess(c, fiber.Map{
-		"users": userResponses,
-		"count": len(userResponses),
+	logger.Info("Retrieved pending verification users", log.Int("count", len(pendingUsers)))
+	return http.HandleFiberSuccess(c, UserListResponse{
+		Users: userResponses,
+		Count: len(userResponses),
 	})
 }
@@ -5,6 +5,17 @@ import (
 	"github.com/MayukhSobo/scaffold/pkg/http"
 )
 
+// UserListResponse is the data returned by user list endpoints
+type UserListResponse struct {
+	Users []*users.User `json:"users"`
+	Count int           `json:"count"`
+}
+
+// UserDetailResponse is the data returned by single user endpoints
+type UserDetailResponse struct {
+	User *users.User `json:"user"`
+}
+
 // ToUserResponse converts a database User model to a response-safe format using redaction
 func ToUserResponse(user *users.User) *users.User {
 	// Create a copy of the user to avoid modifying the original
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/MayukhSobo/scaffold/pkg/http"
+	"github.com/MayukhSobo/scaffold/pkg/utils"
+)
+
+// ParseAndValidate parses the JSON body into a T and validates it with utils.Validate.
+// On failure it has already sent the error response, a 400 listing the failed fields for invalid input,
+// so handlers return nil:
+//
+//	req, err := ParseAndValidate[service.CreateUserRequest](c)
+//	if err != nil {
+//		return nil
+//	}
+func ParseAndValidate[T any](c *fiber.Ctx) (T, error) {
+	var req T
+	if err := c.BodyParser(&req); err != nil {
+		if sendErr := http.HandleFiberBadRequest(c, "Invalid request body"); sendErr != nil {
+			return req, sendErr
+		}
+		return req, err
+	}
+
+	if err := utils.Validate(&req); err != nil {
+		var verr *utils.ValidationError
+		if !errors.As(err, &verr) {
+			// T is not a struct, a programming error rather than bad input
+			if sendErr := http.HandleFiberInternalError(c, "Failed to validate request"); sendErr != nil {
+				return req, sendErr
+			}
+			return req, err
+		}
+		if sendErr := http.HandleFiberBadRequest(c, "Validation failed", verr); sendErr != nil {
+			return req, sendErr
+		}
+		return req, err
+	}
+	return req, nil
+}
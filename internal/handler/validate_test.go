@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type contactRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+func TestParseAndValidate(t *testing.T) {
+	app := fiber.New()
+	app.Post("/contacts", func(c *fiber.Ctx) error {
+		req, err := ParseAndValidate[contactRequest](c)
+		if err != nil {
+			return nil
+		}
+		return c.SendString(req.Email)
+	})
+
+	testCases := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+		expectedFields []string
+	}{
+		{"valid body", `{"name":"Jane","email":"jane@example.com"}`, fiber.StatusOK, "jane@example.com", nil},
+		{"invalid email", `{"name":"Jane","email":"jane"}`, fiber.StatusBadRequest, "", []string{"email"}},
+		{"missing email", `{"name":"Jane"}`, fiber.StatusBadRequest, "", []string{"email"}},
+		{"malformed JSON", `{"name":`, fiber.StatusBadRequest, "Invalid request body", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/contacts", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.expectedFields == nil {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					t.Fatalf("Failed to read response body: %v", err)
+				}
+				if !strings.Contains(string(body), tc.expectedBody) {
+					t.Errorf("Expected body to contain %q, got %q", tc.expectedBody, body)
+				}
+				return
+			}
+
+			var response struct {
+				Message string `json:"message"`
+				Data    struct {
+					Fields []struct {
+						Field string `json:"field"`
+					} `json:"fields"`
+				} `json:"data"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to parse JSON response: %v", err)
+			}
+			if response.Message != "Validation failed" {
+				t.Errorf("Expected message 'Validation failed', got '%s'", response.Message)
+			}
+			if len(response.Data.Fields) != len(tc.expectedFields) {
+				t.Fatalf("Expected fields %v, got %+v", tc.expectedFields, response.Data.Fields)
+			}
+			for i, field := range tc.expectedFields {
+				if response.Data.Fields[i].Field != field {
+					t.Errorf("Expected field '%s', got '%s'", field, response.Data.Fields[i].Field)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/MayukhSobo/scaffold/internal/server"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+func TestBodyLimitIntegration(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false)
+
+	config := createTestConfig()
+	config.Set("server.middleware.content_type", false)
+	config.Set("server.middleware.body_limit", true)
+	config.Set("server.body_limit.max_bytes", 1024)
+
+	srv := server.NewFiberServer(config, logger)
+	srv.AddRoutes(func(app *fiber.App) {
+		app.Post("/upload", func(c *fiber.Ctx) error {
+			return c.SendString("ok")
+		})
+	})
+	app := srv.GetApp()
+
+	// Oversized bodies are rejected while reading the connection, so serve on a real listener
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go func() { _ = app.Listener(ln) }()
+	defer func() { _ = app.Shutdown() }()
+
+	url := "http://" + ln.Addr().String() + "/upload"
+
+	resp, err := http.Post(url, "text/plain", strings.NewReader(strings.Repeat("a", 1024)))
+	if err != nil {
+		t.Fatalf("Failed to send request at the limit: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status %d at the limit, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	resp, err = http.Post(url, "text/plain", strings.NewReader(strings.Repeat("a", 1025)))
+	if err != nil {
+		t.Fatalf("Failed to send request over the limit: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Fatalf("Expected status %d over the limit, got %d", http.StatusRequestEntityTooLarge, resp.StatusCode)
+	}
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	if body["error"] != true || body["code"] != float64(http.StatusRequestEntityTooLarge) {
+		t.Errorf("Expected the standard error body, got %v", body)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "Request body too large") || !strings.Contains(output, "/upload") {
+		t.Errorf("Expected a warning with the path, got: %s", output)
+	}
+}
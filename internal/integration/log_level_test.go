@@ -0,0 +1,56 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/MayukhSobo/scaffold/internal/server"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+func TestLogLevelHotReloadIntegration(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false)
+
+	config := createTestConfig()
+	config.Set("server.log_level_endpoint", true)
+	config.Set("server.jwt.secret", "integration-secret")
+	srv := server.NewFiberServer(config, logger)
+
+	logger.Debug("before reload")
+	if strings.Contains(buf.String(), "before reload") {
+		t.Fatalf("Expected debug message to be filtered at info level, got: %s", buf.String())
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/log-level", strings.NewReader(`{"level":"debug"}`))
+	req.Header.Set("Content-Type", "application/json")
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":  "1",
+		"role": "admin",
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("integration-secret"))
+	if err != nil {
+		t.Fatalf("Failed to sign admin token: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := srv.GetApp().Test(req)
+	if err != nil {
+		t.Fatalf("Failed to change log level: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	logger.Debug("after reload")
+	if !strings.Contains(buf.String(), "after reload") {
+		t.Errorf("Expected debug message after reload, got: %s", buf.String())
+	}
+}
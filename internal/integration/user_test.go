@@ -0,0 +1,228 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/MayukhSobo/scaffold/internal/repository/users"
+	"github.com/MayukhSobo/scaffold/internal/server"
+	"github.com/MayukhSobo/scaffold/pkg/container"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// mockUserRepository is an in-memory users.Querier
+type mockUserRepository struct {
+	mu    sync.Mutex
+	users []users.User
+}
+
+// mockResult implements sql.Result for the mock repository
+type mockResult struct {
+	lastInsertID int64
+}
+
+func (r mockResult) LastInsertId() (int64, error) { return r.lastInsertID, nil }
+func (r mockResult) RowsAffected() (int64, error) { return 1, nil }
+
+func (m *mockUserRepository) filter(keep func(users.User) bool) []users.User {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var matched []users.User
+	for _, user := range m.users {
+		if keep(user) {
+			matched = append(matched, user)
+		}
+	}
+	return matched
+}
+
+func (m *mockUserRepository) CreateUser(ctx context.Context, arg users.CreateUserParams) (sql.Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id := uint64(len(m.users) + 1)
+	m.users = append(m.users, users.User{
+		ID:           id,
+		Username:     arg.Username,
+		Email:        arg.Email,
+		PasswordHash: arg.PasswordHash,
+		FirstName:    arg.FirstName,
+		LastName:     arg.LastName,
+		Status:       users.UsersStatusPendingVerification,
+		Role:         users.UsersRoleUser,
+	})
+	return mockResult{lastInsertID: int64(id)}, nil
+}
+
+func (m *mockUserRepository) GetUser(ctx context.Context, id uint64) (users.User, error) {
+	matched := m.filter(func(u users.User) bool { return u.ID == id })
+	if len(matched) == 0 {
+		return users.User{}, sql.ErrNoRows
+	}
+	return matched[0], nil
+}
+
+func (m *mockUserRepository) GetUsers(ctx context.Context) ([]users.User, error) {
+	return m.filter(func(users.User) bool { return true }), nil
+}
+
+func (m *mockUserRepository) GetAdminUsers(ctx context.Context) ([]users.User, error) {
+	return m.filter(func(u users.User) bool { return u.Role == users.UsersRoleAdmin }), nil
+}
+
+func (m *mockUserRepository) GetPendingVerificationUsers(ctx context.Context) ([]users.User, error) {
+	return m.filter(func(u users.User) bool { return u.Status == users.UsersStatusPendingVerification }), nil
+}
+
+func (m *mockUserRepository) CreateRefreshToken(ctx context.Context, arg users.CreateRefreshTokenParams) error {
+	return nil
+}
+
+func (m *mockUserRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (users.RefreshToken, error) {
+	return users.RefreshToken{}, sql.ErrNoRows
+}
+
+func (m *mockUserRepository) RevokeRefreshToken(ctx context.Context, id uint64) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockUserRepository) RevokeUserRefreshTokens(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+func createTestConfig() *viper.Viper {
+	config := viper.New()
+	config.Set("app.name", "IntegrationTest")
+	config.Set("env", "test")
+	config.Set("security.jwt.key", "integration-test-secret")
+	config.Set("server.middleware.request_id", true)
+	config.Set("server.middleware.content_type", true)
+	config.Set("server.content_type.required", "application/json")
+	return config
+}
+
+// newTestServer wires the mock repository through the container, services, handlers and routes
+func newTestServer(t *testing.T, repo *mockUserRepository) *server.FiberServer {
+	t.Helper()
+
+	logger := log.NewConsoleLoggerWithWriter(log.InfoLevel, &bytes.Buffer{}, false)
+	c := container.NewTypedContainerWithRepositories(createTestConfig(), logger, &container.AllRepositories{User: repo})
+
+	srv := server.NewFiberServerFromContainer(c)
+	if err := srv.SetupContainerRoutes(); err != nil {
+		t.Fatalf("SetupContainerRoutes() returned error: %v", err)
+	}
+	return srv
+}
+
+// userEnvelope is the standard response envelope for user endpoints
+type userEnvelope struct {
+	Code int `json:"code"`
+	Data struct {
+		User  map[string]interface{}   `json:"user"`
+		Users []map[string]interface{} `json:"users"`
+		Count int                      `json:"count"`
+	} `json:"data"`
+}
+
+func doRequest(t *testing.T, srv *server.FiberServer, req *http.Request) (int, userEnvelope) {
+	t.Helper()
+
+	resp, err := srv.GetApp().Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test %s %s: %v", req.Method, req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+
+	var envelope userEnvelope
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return resp.StatusCode, envelope
+}
+
+func TestUserAPIIntegration(t *testing.T) {
+	repo := &mockUserRepository{users: []users.User{
+		{ID: 1, Username: "janedoe", Email: "janedoe@example.com", PasswordHash: "secret-hash", Role: users.UsersRoleAdmin, Status: users.UsersStatusActive},
+		{ID: 2, Username: "superadmin", Email: "superadmin@example.com", PasswordHash: "secret-hash", Role: users.UsersRoleAdmin, Status: users.UsersStatusActive},
+		{ID: 3, Username: "johndoe", Email: "johndoe@example.com", PasswordHash: "secret-hash", Role: users.UsersRoleUser, Status: users.UsersStatusActive},
+	}}
+	srv := newTestServer(t, repo)
+
+	t.Run("list admin users", func(t *testing.T) {
+		status, envelope := doRequest(t, srv, httptest.NewRequest("GET", "/api/v1/users/admin", nil))
+		if status != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", status)
+		}
+		if envelope.Data.Count != 2 {
+			t.Errorf("Expected 2 admin users, got %d", envelope.Data.Count)
+		}
+		for _, user := range envelope.Data.Users {
+			if user["password_hash"] == "secret-hash" {
+				t.Errorf("Expected password hash to be redacted, got %v", user)
+			}
+		}
+	})
+
+	t.Run("create user", func(t *testing.T) {
+		body := `{"username":"newuser","email":"newuser@example.com","password":"s3cret-password","first_name":"New"}`
+		req := httptest.NewRequest("POST", "/api/v1/users", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		status, envelope := doRequest(t, srv, req)
+		if status != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", status)
+		}
+		if envelope.Data.User["username"] != "newuser" {
+			t.Errorf("Expected username 'newuser', got %v", envelope.Data.User["username"])
+		}
+		if envelope.Data.User["id"] != float64(4) {
+			t.Errorf("Expected user ID 4, got %v", envelope.Data.User["id"])
+		}
+
+		// The service stored a hash, not the raw password
+		stored, err := repo.GetUser(context.Background(), 4)
+		if err != nil {
+			t.Fatalf("Expected created user in repository: %v", err)
+		}
+		if stored.PasswordHash == "" || stored.PasswordHash == "s3cret-password" {
+			t.Errorf("Expected password to be stored hashed, got %q", stored.PasswordHash)
+		}
+
+		// The new user is visible through the API
+		status, envelope = doRequest(t, srv, httptest.NewRequest("GET", "/api/v1/users/4", nil))
+		if status != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", status)
+		}
+		if envelope.Data.User["email"] != "newuser@example.com" {
+			t.Errorf("Expected email 'newuser@example.com', got %v", envelope.Data.User["email"])
+		}
+	})
+
+	t.Run("create user missing fields", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/v1/users", strings.NewReader(`{"username":"incomplete"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		status, _ := doRequest(t, srv, req)
+		if status != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", status)
+		}
+	})
+
+	t.Run("missing user", func(t *testing.T) {
+		status, _ := doRequest(t, srv, httptest.NewRequest("GET", "/api/v1/users/999", nil))
+		if status != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", status)
+		}
+	})
+}
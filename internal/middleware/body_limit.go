@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// NewBodyLimitMiddleware rejects requests whose declared or received body is larger than maxBytes with 413.
+// Fiber's BodyLimit should be set to the same value so oversized bodies are rejected before they are read.
+func NewBodyLimitMiddleware(maxBytes int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		size := c.Request().Header.ContentLength()
+		if received := len(c.Body()); received > size {
+			size = received
+		}
+		if size <= maxBytes {
+			return c.Next()
+		}
+
+		return fiber.NewError(fiber.StatusRequestEntityTooLarge,
+			fmt.Sprintf("Request body of %d bytes exceeds the limit of %d bytes", size, maxBytes))
+	}
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestBodyLimitMiddleware(t *testing.T) {
+	// Fiber's own limit is larger so the middleware decides
+	app := fiber.New(fiber.Config{BodyLimit: 1024})
+	app.Use(NewBodyLimitMiddleware(16))
+	app.Post("/upload", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	testCases := []struct {
+		name           string
+		size           int
+		expectedStatus int
+	}{
+		{"empty body", 0, http.StatusOK},
+		{"exactly the limit", 16, http.StatusOK},
+		{"one byte over", 17, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(strings.Repeat("a", tc.size)))
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestBodyLimitMiddlewareErrorBody(t *testing.T) {
+	app := fiber.New(fiber.Config{
+		BodyLimit: 1024,
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			e := err.(*fiber.Error)
+			return c.Status(e.Code).JSON(fiber.Map{"error": true, "message": e.Message, "code": e.Code})
+		},
+	})
+	app.Use(NewBodyLimitMiddleware(4))
+	app.Post("/upload", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("too large")))
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if body["code"] != float64(http.StatusRequestEntityTooLarge) {
+		t.Errorf("Expected code %d, got %v", http.StatusRequestEntityTooLarge, body["code"])
+	}
+	if message, _ := body["message"].(string); !strings.Contains(message, "limit of 4 bytes") {
+		t.Errorf("Expected message to mention the limit, got %q", message)
+	}
+}
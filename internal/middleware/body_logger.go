@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// NewBodyLoggerMiddleware logs the request and response bodies at debug level, meant for debug environments.
+// Bodies are truncated to maxBytes when it is positive, and the values of JSON keys in redactKeys are
+// replaced with log.RedactedValue at any depth. The handler still reads the full request body.
+func NewBodyLoggerMiddleware(logger log.Logger, maxBytes int, redactKeys []string) fiber.Handler {
+	redact := make(map[string]bool, len(redactKeys))
+	for _, key := range redactKeys {
+		redact[strings.ToLower(key)] = true
+	}
+
+	return func(c *fiber.Ctx) error {
+		// fasthttp reuses the request buffer, so keep a copy and hand the handler its own
+		requestBody := append([]byte(nil), c.Body()...)
+		c.Request().SetBody(append([]byte(nil), requestBody...))
+
+		err := c.Next()
+
+		logger.Debug("Request body",
+			log.String("method", c.Method()),
+			log.String("path", c.Path()),
+			log.Int("status", c.Response().StatusCode()),
+			log.String("request_body", formatBody(requestBody, maxBytes, redact)),
+			log.String("response_body", formatBody(c.Response().Body(), maxBytes, redact)),
+		)
+
+		return err
+	}
+}
+
+// formatBody returns body with redacted JSON values, truncated to maxBytes.
+// Bodies that are not JSON are only truncated.
+func formatBody(body []byte, maxBytes int, redact map[string]bool) string {
+	if len(redact) > 0 {
+		var value interface{}
+		if err := json.Unmarshal(body, &value); err == nil {
+			if redacted, err := json.Marshal(redactJSON(value, redact)); err == nil {
+				body = redacted
+			}
+		}
+	}
+
+	if maxBytes > 0 && len(body) > maxBytes {
+		return string(body[:maxBytes]) + "...(truncated)"
+	}
+	return string(body)
+}
+
+// redactJSON replaces the values of redacted keys in decoded JSON objects, including nested ones.
+func redactJSON(value interface{}, redact map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if redact[strings.ToLower(key)] {
+				v[key] = log.RedactedValue
+			} else {
+				v[key] = redactJSON(item, redact)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSON(item, redact)
+		}
+	}
+	return value
+}
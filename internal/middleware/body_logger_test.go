@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+func createBodyLoggerTestApp(buf *bytes.Buffer, maxBytes int) *fiber.App {
+	logger := log.NewConsoleLoggerWithWriter(log.DebugLevel, buf, false)
+
+	app := fiber.New()
+	app.Use(NewBodyLoggerMiddleware(logger, maxBytes, []string{"password", "token"}))
+	app.Post("/echo", func(c *fiber.Ctx) error {
+		// The handler still sees the full body
+		return c.Send(c.Body())
+	})
+	app.Post("/login", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"user": "alice", "token": "secret-token"})
+	})
+
+	return app
+}
+
+func TestBodyLoggerMiddlewareRedactsPassword(t *testing.T) {
+	var buf bytes.Buffer
+	app := createBodyLoggerTestApp(&buf, 0)
+
+	body := `{"username":"alice","password":"hunter2","profile":{"Password":"nested"}}`
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(body)))
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	output := buf.String()
+	for _, secret := range []string{"hunter2", "nested", "secret-token"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("Expected %q to be redacted, got: %s", secret, output)
+		}
+	}
+	if !strings.Contains(output, log.RedactedValue) || !strings.Contains(output, "alice") {
+		t.Errorf("Expected redacted bodies with the other fields kept, got: %s", output)
+	}
+}
+
+func TestBodyLoggerMiddlewareBodyReadable(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     string
+		maxBytes int
+		logged   string
+	}{
+		{"json", `{"name":"x"}`, 0, `{\"name\":\"x\"}`},
+		{"plain text", "hello world", 0, "hello world"},
+		{"truncated", "hello world", 5, "hello...(truncated)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			app := createBodyLoggerTestApp(&buf, tc.maxBytes)
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(tc.body)))
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+
+			echoed, _ := io.ReadAll(resp.Body)
+			if string(echoed) != tc.body {
+				t.Errorf("Expected handler to read %q, got %q", tc.body, echoed)
+			}
+			if !strings.Contains(buf.String(), tc.logged) {
+				t.Errorf("Expected log to contain %q, got: %s", tc.logged, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// NewContentTypeMiddleware rejects POST, PUT and PATCH requests whose Content-Type doesn't match required.
+// Multipart requests and requests without a body are passed through.
+func NewContentTypeMiddleware(required string) fiber.Handler {
+	required = strings.ToLower(strings.TrimSpace(required))
+
+	return func(c *fiber.Ctx) error {
+		switch c.Method() {
+		case fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch:
+		default:
+			return c.Next()
+		}
+
+		contentType := c.Get(fiber.HeaderContentType)
+		if contentType == "" && len(c.Body()) == 0 {
+			return c.Next()
+		}
+
+		mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+		if strings.HasPrefix(mediaType, "multipart/") || mediaType == required {
+			return c.Next()
+		}
+
+		return fiber.NewError(fiber.StatusUnsupportedMediaType,
+			fmt.Sprintf("unsupported Content-Type %q, expected %q", mediaType, required))
+	}
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func createContentTypeTestApp() *fiber.App {
+	app := fiber.New()
+	app.Use(NewContentTypeMiddleware(fiber.MIMEApplicationJSON))
+
+	handler := func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	}
+	app.Get("/items", handler)
+	app.Post("/items", handler)
+	app.Put("/items", handler)
+	app.Patch("/items", handler)
+	app.Options("/items", handler)
+
+	return app
+}
+
+func TestContentTypeMiddleware(t *testing.T) {
+	app := createContentTypeTestApp()
+
+	testCases := []struct {
+		name           string
+		method         string
+		contentType    string
+		body           string
+		expectedStatus int
+	}{
+		{"correct type", "POST", "application/json", `{"name":"x"}`, http.StatusOK},
+		{"correct type with charset", "PUT", "application/json; charset=utf-8", `{"name":"x"}`, http.StatusOK},
+		{"correct type mixed case", "PATCH", "Application/JSON", `{"name":"x"}`, http.StatusOK},
+		{"wrong type", "POST", "text/plain", "hello", http.StatusUnsupportedMediaType},
+		{"missing type with body", "PUT", "", "hello", http.StatusUnsupportedMediaType},
+		{"multipart skipped", "POST", "multipart/form-data; boundary=xyz", "--xyz--", http.StatusOK},
+		{"empty body without type", "POST", "", "", http.StatusOK},
+		{"OPTIONS pass-through", "OPTIONS", "text/plain", "hello", http.StatusOK},
+		{"GET pass-through", "GET", "text/plain", "", http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/items", strings.NewReader(tc.body))
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/MayukhSobo/scaffold/pkg/utils"
+)
+
+// CorrelationIDLocalsKey is the c.Locals key holding the correlation ID
+const CorrelationIDLocalsKey = "correlation_id"
+
+// maxCorrelationIDLength bounds client supplied IDs so they are safe to log and forward
+const maxCorrelationIDLength = 128
+
+// NewCorrelationIDMiddleware reads X-Correlation-ID, or generates one when it is missing, stores it in
+// c.Locals and the request context and echoes it on the response.
+// Outgoing calls made with utils.CorrelationIDTransport and the request context forward it.
+func NewCorrelationIDMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Get(utils.HeaderCorrelationID)
+		if id == "" || len(id) > maxCorrelationIDLength {
+			id = uuid.NewString()
+		}
+
+		c.Locals(CorrelationIDLocalsKey, id)
+		c.SetUserContext(utils.WithCorrelationID(c.UserContext(), id))
+		c.Set(utils.HeaderCorrelationID, id)
+
+		return c.Next()
+	}
+}
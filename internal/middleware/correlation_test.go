@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/MayukhSobo/scaffold/pkg/utils"
+)
+
+func TestCorrelationIDMiddleware(t *testing.T) {
+	testCases := []struct {
+		name     string
+		header   string
+		generate bool
+	}{
+		{name: "propagates incoming ID", header: "corr-123"},
+		{name: "generates missing ID", header: "", generate: true},
+		{name: "replaces oversized ID", header: strings.Repeat("a", maxCorrelationIDLength+1), generate: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var localsID, contextID string
+
+			app := fiber.New()
+			app.Use(NewCorrelationIDMiddleware())
+			app.Get("/", func(c *fiber.Ctx) error {
+				localsID, _ = c.Locals(CorrelationIDLocalsKey).(string)
+				contextID, _ = utils.GetCorrelationID(c.UserContext())
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			req := httptest.NewRequest("GET", "/", nil)
+			if tc.header != "" {
+				req.Header.Set(utils.HeaderCorrelationID, tc.header)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+
+			responseID := resp.Header.Get(utils.HeaderCorrelationID)
+			if tc.generate {
+				if responseID == "" || responseID == tc.header {
+					t.Errorf("Expected a generated correlation ID, got %q", responseID)
+				}
+			} else if responseID != tc.header {
+				t.Errorf("Expected correlation ID %s, got %s", tc.header, responseID)
+			}
+
+			if localsID != responseID {
+				t.Errorf("Expected locals correlation ID %s, got %s", responseID, localsID)
+			}
+			if contextID != responseID {
+				t.Errorf("Expected context correlation ID %s, got %s", responseID, contextID)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// DefaultCSRFHeader is the request header that must echo the CSRF cookie
+	DefaultCSRFHeader = "X-CSRF-Token"
+	// CSRFCookieName is the cookie holding the CSRF token
+	CSRFCookieName = "csrf_token"
+)
+
+// csrfTokenBytes is the number of random bytes in a CSRF token
+const csrfTokenBytes = 32
+
+// csrfConfig holds the optional CSRF middleware settings
+type csrfConfig struct {
+	header string
+	exempt pathSet
+}
+
+// CSRFOption configures CSRFMiddleware
+type CSRFOption func(*csrfConfig)
+
+// CSRFHeader sets the request header compared with the CSRF cookie, DefaultCSRFHeader by default
+func CSRFHeader(header string) CSRFOption {
+	return func(config *csrfConfig) {
+		if header != "" {
+			config.header = header
+		}
+	}
+}
+
+// CSRFExemptPaths skips the token check for routes called without a browser session, e.g. webhooks.
+// A path ending in /* exempts every path below it.
+func CSRFExemptPaths(paths ...string) CSRFOption {
+	return func(config *csrfConfig) {
+		config.exempt.add(paths...)
+	}
+}
+
+// CSRFMiddleware implements the double-submit cookie pattern. Requests without a CSRF cookie receive a new
+// random token in it, and POST, PUT, DELETE and PATCH requests are rejected with 403 unless the header echoes the cookie.
+func CSRFMiddleware(opts ...CSRFOption) fiber.Handler {
+	config := &csrfConfig{header: DefaultCSRFHeader}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return func(c *fiber.Ctx) error {
+		cookie := c.Cookies(CSRFCookieName)
+		if cookie == "" {
+			token, err := newCSRFToken()
+			if err != nil {
+				return err
+			}
+			c.Cookie(&fiber.Cookie{
+				Name:     CSRFCookieName,
+				Value:    token,
+				Path:     "/",
+				Secure:   c.Protocol() == "https",
+				SameSite: fiber.CookieSameSiteStrictMode,
+			})
+		}
+
+		switch c.Method() {
+		case fiber.MethodPost, fiber.MethodPut, fiber.MethodDelete, fiber.MethodPatch:
+		default:
+			return c.Next()
+		}
+		if config.exempt.contains(c.Path()) {
+			return c.Next()
+		}
+
+		header := c.Get(config.header)
+		if cookie == "" || header == "" || subtle.ConstantTimeCompare([]byte(cookie), []byte(header)) != 1 {
+			return fiber.NewError(fiber.StatusForbidden, "missing or invalid CSRF token")
+		}
+		return c.Next()
+	}
+}
+
+// newCSRFToken returns a base64-url encoded random token
+func newCSRFToken() (string, error) {
+	buf := make([]byte, csrfTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
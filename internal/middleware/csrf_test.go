@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newCSRFTestApp(opts ...CSRFOption) *fiber.App {
+	app := fiber.New()
+	app.Use(CSRFMiddleware(opts...))
+	handler := func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	}
+	app.Get("/form", handler)
+	app.Post("/form", handler)
+	app.Post("/webhooks/github", handler)
+	return app
+}
+
+func TestCSRFMiddlewareSetsCookie(t *testing.T) {
+	app := newCSRFTestApp()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/form", nil))
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	var token string
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == CSRFCookieName {
+			token = cookie.Value
+		}
+	}
+	// 32 random bytes, base64-url encoded without padding
+	if len(token) != 43 {
+		t.Errorf("Expected a 43 character token cookie, got %q", token)
+	}
+}
+
+func TestCSRFMiddleware(t *testing.T) {
+	app := newCSRFTestApp(CSRFHeader("X-XSRF-Token"), CSRFExemptPaths("/webhooks/*"))
+
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		cookie         string
+		header         string
+		expectedStatus int
+	}{
+		{"safe method skips check", http.MethodGet, "/form", "", "", http.StatusOK},
+		{"matching token", http.MethodPost, "/form", "token-a", "token-a", http.StatusOK},
+		{"mismatched token", http.MethodPost, "/form", "token-a", "token-b", http.StatusForbidden},
+		{"missing header", http.MethodPost, "/form", "token-a", "", http.StatusForbidden},
+		{"missing cookie", http.MethodPost, "/form", "", "token-a", http.StatusForbidden},
+		{"exempt path", http.MethodPost, "/webhooks/github", "", "", http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: CSRFCookieName, Value: tc.cookie})
+			}
+			if tc.header != "" {
+				req.Header.Set("X-XSRF-Token", tc.header)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
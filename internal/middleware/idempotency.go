@@ -0,0 +1,214 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// HeaderIdempotencyKey is the request header identifying retries of the same operation
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed is set on responses replayed from the store
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
+// idempotencySweepInterval is how often MemoryIdempotencyStore removes expired entries while it is written to
+const idempotencySweepInterval = time.Minute
+
+// IdempotencyStore keeps encoded responses by idempotency key
+type IdempotencyStore interface {
+	Get(key string) ([]byte, bool)
+	// Reserve stores body for key unless key already has an entry, reporting whether it was stored
+	Reserve(key string, body []byte, ttl time.Duration) bool
+	Set(key string, body []byte, ttl time.Duration)
+	Delete(key string)
+}
+
+// idempotentResponse is the response stored for an idempotency key. Pending entries mark a request
+// that is still being handled.
+type idempotentResponse struct {
+	Pending     bool   `json:"pending,omitempty"`
+	Fingerprint string `json:"fingerprint"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// IdempotencyMiddleware replays the stored response for POST and PUT requests carrying an Idempotency-Key
+// header seen within ttl, without calling the handler again. Keys are scoped to the method, the path and the
+// authenticated user (c.Locals("user_id"), the client IP for anonymous requests), so callers cannot replay
+// each other's responses. The key is reserved before the handler runs: a second request with the same key
+// gets 409 while the first is in flight, and 422 when its body differs from the first request's.
+// Server errors are not stored so the client can retry them.
+func IdempotencyMiddleware(store IdempotencyStore, ttl time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		method := c.Method()
+		key := c.Get(HeaderIdempotencyKey)
+		if key == "" || (method != fiber.MethodPost && method != fiber.MethodPut) {
+			return c.Next()
+		}
+		key = method + " " + c.Path() + " " + idempotencyScope(c) + " " + key
+		sum := sha256.Sum256(c.Body())
+		fingerprint := hex.EncodeToString(sum[:])
+
+		pending, err := json.Marshal(idempotentResponse{Pending: true, Fingerprint: fingerprint})
+		if err != nil {
+			return err
+		}
+		if !store.Reserve(key, pending, ttl) {
+			return replayIdempotent(c, store, key, fingerprint)
+		}
+
+		// Release the key unless a response was stored, including when the handler panics
+		stored := false
+		defer func() {
+			if !stored {
+				store.Delete(key)
+			}
+		}()
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError {
+			return nil
+		}
+		data, err := json.Marshal(idempotentResponse{
+			Fingerprint: fingerprint,
+			Status:      status,
+			ContentType: string(c.Response().Header.ContentType()),
+			Body:        c.Response().Body(),
+		})
+		if err != nil {
+			return err
+		}
+		store.Set(key, data, ttl)
+		stored = true
+		return nil
+	}
+}
+
+// idempotencyScope returns the authenticated user set by the JWT middleware, or the client IP
+func idempotencyScope(c *fiber.Ctx) string {
+	if userID, ok := c.Locals(UserIDLocalsKey).(string); ok && userID != "" {
+		return "user:" + userID
+	}
+	return "ip:" + c.IP()
+}
+
+// replayIdempotent answers a request whose key is already reserved from the stored entry
+func replayIdempotent(c *fiber.Ctx, store IdempotencyStore, key, fingerprint string) error {
+	data, ok := store.Get(key)
+	if !ok {
+		// The first request has just released the key
+		return fiber.NewError(fiber.StatusConflict, "a request with this Idempotency-Key is in progress")
+	}
+	var cached idempotentResponse
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return err
+	}
+	if cached.Fingerprint != fingerprint {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, "Idempotency-Key was used with a different request body")
+	}
+	if cached.Pending {
+		return fiber.NewError(fiber.StatusConflict, "a request with this Idempotency-Key is in progress")
+	}
+
+	c.Set(fiber.HeaderContentType, cached.ContentType)
+	c.Set(HeaderIdempotentReplayed, "true")
+	return c.Status(cached.Status).Send(cached.Body)
+}
+
+// MemoryIdempotencyStore is an in-process IdempotencyStore. Expired entries are removed when read, and
+// swept from the whole store at most once per minute while entries are written.
+type MemoryIdempotencyStore struct {
+	entries   sync.Map // key -> *memoryIdempotencyEntry
+	lastSweep atomic.Int64
+}
+
+type memoryIdempotencyEntry struct {
+	body      []byte
+	expiresAt time.Time
+}
+
+// NewMemoryIdempotencyStore creates an empty in-memory idempotency store
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	store := &MemoryIdempotencyStore{}
+	store.lastSweep.Store(time.Now().UnixNano())
+	return store
+}
+
+// Get returns the body stored for key unless it has expired
+func (s *MemoryIdempotencyStore) Get(key string) ([]byte, bool) {
+	value, ok := s.entries.Load(key)
+	if !ok {
+		return nil, false
+	}
+	entry := value.(*memoryIdempotencyEntry)
+	if time.Now().After(entry.expiresAt) {
+		s.entries.CompareAndDelete(key, value)
+		return nil, false
+	}
+	return entry.body, true
+}
+
+// Reserve stores body for key until ttl has elapsed, unless key has an entry that has not expired
+func (s *MemoryIdempotencyStore) Reserve(key string, body []byte, ttl time.Duration) bool {
+	s.sweep()
+	entry := newMemoryIdempotencyEntry(body, ttl)
+	for {
+		value, loaded := s.entries.LoadOrStore(key, entry)
+		if !loaded {
+			return true
+		}
+		if !time.Now().After(value.(*memoryIdempotencyEntry).expiresAt) {
+			return false
+		}
+		// Replace the expired entry, unless another request did so first
+		if s.entries.CompareAndSwap(key, value, entry) {
+			return true
+		}
+	}
+}
+
+// Set stores body for key until ttl has elapsed
+func (s *MemoryIdempotencyStore) Set(key string, body []byte, ttl time.Duration) {
+	s.sweep()
+	s.entries.Store(key, newMemoryIdempotencyEntry(body, ttl))
+}
+
+// Delete removes the entry stored for key
+func (s *MemoryIdempotencyStore) Delete(key string) {
+	s.entries.Delete(key)
+}
+
+// sweep removes every expired entry when the last sweep is older than idempotencySweepInterval,
+// so keys that are never read again do not stay in memory
+func (s *MemoryIdempotencyStore) sweep() {
+	now := time.Now()
+	last := s.lastSweep.Load()
+	if now.Sub(time.Unix(0, last)) < idempotencySweepInterval || !s.lastSweep.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+	s.entries.Range(func(key, value interface{}) bool {
+		if now.After(value.(*memoryIdempotencyEntry).expiresAt) {
+			s.entries.CompareAndDelete(key, value)
+		}
+		return true
+	})
+}
+
+func newMemoryIdempotencyEntry(body []byte, ttl time.Duration) *memoryIdempotencyEntry {
+	return &memoryIdempotencyEntry{
+		body:      append([]byte(nil), body...),
+		expiresAt: time.Now().Add(ttl),
+	}
+}
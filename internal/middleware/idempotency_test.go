@@ -0,0 +1,232 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	calls := 0
+	app := fiber.New()
+	app.Use(IdempotencyMiddleware(NewMemoryIdempotencyStore(), time.Minute))
+	app.Post("/orders", func(c *fiber.Ctx) error {
+		calls++
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"order": calls})
+	})
+
+	send := func(key string) (*http.Response, string) {
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"item":"book"}`))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set(HeaderIdempotencyKey, key)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to test request: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return resp, string(body)
+	}
+
+	first, firstBody := send("key-1")
+	second, secondBody := send("key-1")
+
+	if calls != 1 {
+		t.Fatalf("Expected the handler to be called once, got %d", calls)
+	}
+	if second.StatusCode != http.StatusCreated || secondBody != firstBody {
+		t.Errorf("Expected replayed %d %s, got %d %s", first.StatusCode, firstBody, second.StatusCode, secondBody)
+	}
+	if second.Header.Get(HeaderIdempotentReplayed) != "true" || first.Header.Get(HeaderIdempotentReplayed) != "" {
+		t.Error("Expected only the replayed response to be marked")
+	}
+	if got := second.Header.Get("Content-Type"); got != fiber.MIMEApplicationJSON {
+		t.Errorf("Expected replayed content type %s, got %s", fiber.MIMEApplicationJSON, got)
+	}
+
+	// Other keys and requests without a key reach the handler
+	send("key-2")
+	send("")
+	send("")
+	if calls != 4 {
+		t.Errorf("Expected 4 handler calls, got %d", calls)
+	}
+}
+
+func TestMemoryIdempotencyStoreExpiry(t *testing.T) {
+	store := NewMemoryIdempotencyStore()
+	store.Set("live", []byte("a"), time.Minute)
+	store.Set("expired", []byte("b"), -time.Second)
+
+	if body, ok := store.Get("live"); !ok || string(body) != "a" {
+		t.Errorf("Expected live entry, got %q %v", body, ok)
+	}
+	if _, ok := store.Get("expired"); ok {
+		t.Error("Expected expired entry to be missing")
+	}
+	if _, ok := store.Get("unknown"); ok {
+		t.Error("Expected unknown key to be missing")
+	}
+}
+
+func TestIdempotencyMiddlewareInFlight(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	calls := 0
+	app := fiber.New()
+	app.Use(IdempotencyMiddleware(NewMemoryIdempotencyStore(), time.Minute))
+	app.Post("/orders", func(c *fiber.Ctx) error {
+		calls++
+		close(started)
+		<-release
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"item":"book"}`))
+		req.Header.Set(HeaderIdempotencyKey, "key-1")
+		return req
+	}
+
+	done := make(chan *http.Response)
+	go func() {
+		resp, err := app.Test(newRequest(), -1)
+		if err != nil {
+			t.Errorf("Failed to test first request: %v", err)
+		}
+		done <- resp
+	}()
+	<-started
+
+	resp, err := app.Test(newRequest())
+	if err != nil {
+		t.Fatalf("Failed to test second request: %v", err)
+	}
+	if resp.StatusCode != http.StatusConflict {
+		t.Errorf("Expected status %d while the first request is in flight, got %d", http.StatusConflict, resp.StatusCode)
+	}
+
+	close(release)
+	if first := <-done; first == nil || first.StatusCode != http.StatusCreated {
+		t.Fatalf("Expected the first request to complete with %d, got %v", http.StatusCreated, first)
+	}
+	if calls != 1 {
+		t.Errorf("Expected the handler to be called once, got %d", calls)
+	}
+}
+
+func TestIdempotencyMiddlewareScope(t *testing.T) {
+	calls := 0
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		if user := c.Get("X-User"); user != "" {
+			c.Locals(UserIDLocalsKey, user)
+		}
+		return c.Next()
+	})
+	app.Use(IdempotencyMiddleware(NewMemoryIdempotencyStore(), time.Minute))
+	app.Post("/orders", func(c *fiber.Ctx) error {
+		calls++
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	tests := []struct {
+		name     string
+		user     string
+		body     string
+		expected int
+		calls    int
+	}{
+		{name: "first request", user: "1", body: `{"item":"book"}`, expected: http.StatusCreated, calls: 1},
+		{name: "retry is replayed", user: "1", body: `{"item":"book"}`, expected: http.StatusCreated, calls: 1},
+		{name: "different body is rejected", user: "1", body: `{"item":"pen"}`, expected: http.StatusUnprocessableEntity, calls: 1},
+		{name: "other user is not replayed", user: "2", body: `{"item":"book"}`, expected: http.StatusCreated, calls: 2},
+		{name: "anonymous request is not replayed", body: `{"item":"book"}`, expected: http.StatusCreated, calls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(tt.body))
+			req.Header.Set(HeaderIdempotencyKey, "key-1")
+			if tt.user != "" {
+				req.Header.Set("X-User", tt.user)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tt.expected {
+				t.Errorf("Expected status %d, got %d", tt.expected, resp.StatusCode)
+			}
+			if calls != tt.calls {
+				t.Errorf("Expected %d handler calls, got %d", tt.calls, calls)
+			}
+		})
+	}
+}
+
+func TestIdempotencyMiddlewareReleasesFailedKeys(t *testing.T) {
+	calls := 0
+	app := fiber.New()
+	app.Use(IdempotencyMiddleware(NewMemoryIdempotencyStore(), time.Minute))
+	app.Post("/orders", func(c *fiber.Ctx) error {
+		calls++
+		return fiber.NewError(fiber.StatusServiceUnavailable, "try again")
+	})
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{}`))
+		req.Header.Set(HeaderIdempotencyKey, "key-1")
+		if _, err := app.Test(req); err != nil {
+			t.Fatalf("Failed to test request: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Expected failed requests to be retried, got %d handler calls", calls)
+	}
+}
+
+func TestMemoryIdempotencyStoreReserve(t *testing.T) {
+	store := NewMemoryIdempotencyStore()
+
+	if !store.Reserve("key", []byte("a"), time.Minute) {
+		t.Fatal("Expected the first reservation to succeed")
+	}
+	if store.Reserve("key", []byte("b"), time.Minute) {
+		t.Error("Expected a reserved key not to be reserved again")
+	}
+	store.Delete("key")
+	if !store.Reserve("key", []byte("c"), time.Minute) {
+		t.Error("Expected a deleted key to be reserved again")
+	}
+
+	store.Set("expired", []byte("d"), -time.Second)
+	if !store.Reserve("expired", []byte("e"), time.Minute) {
+		t.Error("Expected an expired key to be reserved again")
+	}
+	if body, _ := store.Get("expired"); string(body) != "e" {
+		t.Errorf("Expected body e, got %q", body)
+	}
+}
+
+func TestMemoryIdempotencyStoreSweep(t *testing.T) {
+	store := NewMemoryIdempotencyStore()
+	store.Set("expired", []byte("a"), -time.Second)
+
+	// Force the next write to sweep
+	store.lastSweep.Store(time.Now().Add(-2 * idempotencySweepInterval).UnixNano())
+	store.Set("live", []byte("b"), time.Minute)
+
+	if _, ok := store.entries.Load("expired"); ok {
+		t.Error("Expected the expired entry to be swept without being read")
+	}
+	if _, ok := store.entries.Load("live"); !ok {
+		t.Error("Expected the live entry to be kept")
+	}
+}
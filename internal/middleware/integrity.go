@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Headers set by the integrity middleware
+const (
+	HeaderRequestBodyHash  = "X-Request-Body-Hash"
+	HeaderResponseBodyHash = "X-Response-Body-Hash"
+)
+
+// ErrUnsupportedIntegrityAlgorithm is returned by NewIntegrityMiddleware for algorithms other than sha256 and sha512
+var ErrUnsupportedIntegrityAlgorithm = errors.New("unsupported integrity algorithm")
+
+// RenderedError wraps a handler error whose response the ErrorHandler has already written. Middleware earlier
+// in the chain still sees the error, and the server's ErrorHandler does not render it a second time.
+type RenderedError struct {
+	Err error
+}
+
+func (e *RenderedError) Error() string { return e.Err.Error() }
+
+func (e *RenderedError) Unwrap() error { return e.Err }
+
+// NewIntegrityMiddleware adds hex encoded hashes of the request and response bodies as response headers
+// so clients can verify the exact payloads. Supported algorithms are "sha256" and "sha512".
+func NewIntegrityMiddleware(algorithm string) (fiber.Handler, error) {
+	var newHash func() hash.Hash
+	switch strings.ToLower(strings.TrimSpace(algorithm)) {
+	case "", "sha256":
+		newHash = sha256.New
+	case "sha512":
+		newHash = sha512.New
+	default:
+		return nil, fmt.Errorf("%w %q, expected sha256 or sha512", ErrUnsupportedIntegrityAlgorithm, algorithm)
+	}
+
+	sum := func(body []byte) string {
+		h := newHash()
+		h.Write(body)
+		return hex.EncodeToString(h.Sum(nil))
+	}
+
+	return func(c *fiber.Ctx) error {
+		requestHash := sum(c.Body())
+
+		// Let the error handler write its response now so the hash covers the body that is sent
+		err := c.Next()
+		if err != nil {
+			if renderErr := c.App().ErrorHandler(c, err); renderErr != nil {
+				return renderErr
+			}
+			err = &RenderedError{Err: err}
+		}
+
+		c.Set(HeaderRequestBodyHash, requestHash)
+		c.Set(HeaderResponseBodyHash, sum(c.Response().Body()))
+		return err
+	}, nil
+}
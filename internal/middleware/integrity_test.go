@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func createIntegrityTestApp(t *testing.T, algorithm string) *fiber.App {
+	app := fiber.New()
+	integrity, err := NewIntegrityMiddleware(algorithm)
+	if err != nil {
+		t.Fatalf("Failed to create integrity middleware: %v", err)
+	}
+	app.Use(integrity)
+
+	app.Post("/echo", func(c *fiber.Ctx) error {
+		return c.Send(append([]byte("received: "), c.Body()...))
+	})
+	app.Get("/missing", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusNotFound, "not here")
+	})
+
+	return app
+}
+
+func TestIntegrityMiddlewareSHA256(t *testing.T) {
+	app := createIntegrityTestApp(t, "sha256")
+	requestBody := `{"amount":1000,"currency":"EUR"}`
+
+	req := httptest.NewRequest("POST", "/echo", strings.NewReader(requestBody))
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test integrity middleware: %v", err)
+	}
+
+	responseBody, _ := io.ReadAll(resp.Body)
+
+	requestSum := sha256.Sum256([]byte(requestBody))
+	if got := resp.Header.Get(HeaderRequestBodyHash); got != hex.EncodeToString(requestSum[:]) {
+		t.Errorf("Expected request hash %x, got %s", requestSum, got)
+	}
+
+	responseSum := sha256.Sum256(responseBody)
+	if got := resp.Header.Get(HeaderResponseBodyHash); got != hex.EncodeToString(responseSum[:]) {
+		t.Errorf("Expected response hash %x, got %s", responseSum, got)
+	}
+}
+
+func TestIntegrityMiddlewareSHA512(t *testing.T) {
+	app := createIntegrityTestApp(t, "sha512")
+
+	req := httptest.NewRequest("POST", "/echo", strings.NewReader("payload"))
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test integrity middleware: %v", err)
+	}
+
+	requestSum := sha512.Sum512([]byte("payload"))
+	if got := resp.Header.Get(HeaderRequestBodyHash); got != hex.EncodeToString(requestSum[:]) {
+		t.Errorf("Expected request hash %x, got %s", requestSum, got)
+	}
+}
+
+func TestIntegrityMiddlewareErrorResponse(t *testing.T) {
+	app := createIntegrityTestApp(t, "sha256")
+
+	req := httptest.NewRequest("GET", "/missing", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test integrity middleware: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", resp.StatusCode)
+	}
+
+	responseBody, _ := io.ReadAll(resp.Body)
+	responseSum := sha256.Sum256(responseBody)
+	if got := resp.Header.Get(HeaderResponseBodyHash); got != hex.EncodeToString(responseSum[:]) {
+		t.Errorf("Expected error response hash %x, got %s", responseSum, got)
+	}
+}
+
+func TestIntegrityMiddlewarePropagatesError(t *testing.T) {
+	var chainErr error
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		chainErr = c.Next()
+		return chainErr
+	})
+	integrity, err := NewIntegrityMiddleware("sha256")
+	if err != nil {
+		t.Fatalf("Failed to create integrity middleware: %v", err)
+	}
+	app.Use(integrity)
+	app.Get("/missing", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusNotFound, "not here")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/missing", nil))
+	if err != nil {
+		t.Fatalf("Failed to test integrity middleware: %v", err)
+	}
+
+	var fiberErr *fiber.Error
+	if !errors.As(chainErr, &fiberErr) || fiberErr.Code != fiber.StatusNotFound {
+		t.Errorf("Expected the handler error to reach earlier middleware, got %v", chainErr)
+	}
+	var rendered *RenderedError
+	if !errors.As(chainErr, &rendered) {
+		t.Errorf("Expected a RenderedError, got %T", chainErr)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	responseSum := sha256.Sum256(body)
+	if resp.StatusCode != http.StatusNotFound || resp.Header.Get(HeaderResponseBodyHash) != hex.EncodeToString(responseSum[:]) {
+		t.Errorf("Expected hashed 404 response, got %d %q", resp.StatusCode, body)
+	}
+}
+
+func TestIntegrityMiddlewareUnsupportedAlgorithm(t *testing.T) {
+	handler, err := NewIntegrityMiddleware("md5")
+	if !errors.Is(err, ErrUnsupportedIntegrityAlgorithm) {
+		t.Errorf("Expected ErrUnsupportedIntegrityAlgorithm, got %v", err)
+	}
+	if handler != nil {
+		t.Error("Expected no handler for unsupported algorithm")
+	}
+}
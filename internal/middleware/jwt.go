@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// Locals keys populated by the JWT middleware
+const (
+	ClaimsLocalsKey = "claims"
+	UserLocalsKey   = "user"
+	UserIDLocalsKey = "user_id"
+)
+
+// errMissingJWTSecret rejects every token when no secret is configured, instead of accepting tokens signed with an empty key
+var errMissingJWTSecret = errors.New("jwt secret is not configured")
+
+// jwtConfig holds the optional JWT middleware settings
+type jwtConfig struct {
+	excluded pathSet
+}
+
+// JWTOption configures JWTMiddleware
+type JWTOption func(*jwtConfig)
+
+// ExcludePaths skips token validation for public routes such as /health.
+// A path ending in /* excludes every path below it, e.g. /api/v1/auth/*.
+func ExcludePaths(paths ...string) JWTOption {
+	return func(config *jwtConfig) {
+		config.excluded.add(paths...)
+	}
+}
+
+// NewJWTMiddleware validates the HS256 bearer token in the Authorization header, see JWTMiddleware.
+func NewJWTMiddleware(secret []byte) fiber.Handler {
+	return JWTMiddleware(secret, nil)
+}
+
+// JWTMiddleware validates the HS256 bearer token in the Authorization header and logs rejected requests.
+// The token claims are stored in c.Locals("claims") and c.Locals("user"), the subject in c.Locals("user_id").
+func JWTMiddleware(secret []byte, logger log.Logger, opts ...JWTOption) fiber.Handler {
+	config := &jwtConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		if len(secret) == 0 {
+			return nil, errMissingJWTSecret
+		}
+		return secret, nil
+	}
+
+	reject := func(c *fiber.Ctx, message string, err error) error {
+		if logger != nil {
+			fields := []log.Field{log.String("path", c.Path())}
+			if err != nil {
+				fields = append(fields, log.Err(err))
+			}
+			logger.Warn("Rejected unauthenticated request", fields...)
+		}
+		return fiber.NewError(fiber.StatusUnauthorized, message)
+	}
+
+	return func(c *fiber.Ctx) error {
+		if config.excluded.contains(c.Path()) {
+			return c.Next()
+		}
+
+		header := c.Get(fiber.HeaderAuthorization)
+		tokenString, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || tokenString == "" {
+			return reject(c, "missing or malformed bearer token", nil)
+		}
+
+		claims := jwt.MapClaims{}
+		if _, err := parser.ParseWithClaims(tokenString, claims, keyFunc); err != nil {
+			return reject(c, "invalid or expired token", err)
+		}
+
+		c.Locals(ClaimsLocalsKey, claims)
+		c.Locals(UserLocalsKey, claims)
+		if subject, err := claims.GetSubject(); err == nil && subject != "" {
+			c.Locals(UserIDLocalsKey, subject)
+		}
+
+		return c.Next()
+	}
+}
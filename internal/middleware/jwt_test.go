@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+var testJWTSecret = []byte("test-secret")
+
+func signTestToken(t *testing.T, method jwt.SigningMethod, key interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	return token
+}
+
+func TestJWTMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Use(NewJWTMiddleware(testJWTSecret))
+	app.Get("/profile", func(c *fiber.Ctx) error {
+		claims, ok := c.Locals(UserLocalsKey).(jwt.MapClaims)
+		if !ok {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+		return c.SendString(claims["role"].(string) + ":" + c.Locals(UserIDLocalsKey).(string))
+	})
+
+	validClaims := jwt.MapClaims{"sub": "42", "role": "admin", "exp": time.Now().Add(time.Hour).Unix()}
+
+	testCases := []struct {
+		name           string
+		header         string
+		expectedStatus int
+	}{
+		{"valid token", "Bearer " + signTestToken(t, jwt.SigningMethodHS256, testJWTSecret, validClaims), http.StatusOK},
+		{"missing header", "", http.StatusUnauthorized},
+		{"missing bearer prefix", signTestToken(t, jwt.SigningMethodHS256, testJWTSecret, validClaims), http.StatusUnauthorized},
+		{"wrong secret", "Bearer " + signTestToken(t, jwt.SigningMethodHS256, []byte("other"), validClaims), http.StatusUnauthorized},
+		{"wrong algorithm", "Bearer " + signTestToken(t, jwt.SigningMethodHS512, testJWTSecret, validClaims), http.StatusUnauthorized},
+		{"expired token", "Bearer " + signTestToken(t, jwt.SigningMethodHS256, testJWTSecret, jwt.MapClaims{"sub": "42", "exp": time.Now().Add(-time.Hour).Unix()}), http.StatusUnauthorized},
+		{"malformed token", "Bearer not.a.token", http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/profile", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestJWTMiddlewareClaimsAndExcludedPaths(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false)
+
+	app := fiber.New()
+	app.Use(JWTMiddleware(testJWTSecret, logger, ExcludePaths("/health", "/ping", "/api/v1/auth/*")))
+	handler := func(c *fiber.Ctx) error {
+		if claims, ok := c.Locals(ClaimsLocalsKey).(jwt.MapClaims); ok {
+			return c.SendString(claims["sub"].(string))
+		}
+		return c.SendString("anonymous")
+	}
+	for _, path := range []string{"/health", "/ping", "/api/v1/auth/login", "/api/v1/users", "/health/details"} {
+		app.Get(path, handler)
+	}
+
+	validToken := signTestToken(t, jwt.SigningMethodHS256, testJWTSecret, jwt.MapClaims{"sub": "42", "exp": time.Now().Add(time.Hour).Unix()})
+	expiredToken := signTestToken(t, jwt.SigningMethodHS256, testJWTSecret, jwt.MapClaims{"sub": "42", "exp": time.Now().Add(-time.Hour).Unix()})
+	wrongAlgorithmToken := signTestToken(t, jwt.SigningMethodHS384, testJWTSecret, jwt.MapClaims{"sub": "42"})
+	noneToken := signTestToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, jwt.MapClaims{"sub": "42"})
+
+	testCases := []struct {
+		name           string
+		path           string
+		header         string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"valid token", "/api/v1/users", "Bearer " + validToken, http.StatusOK, "42"},
+		{"expired token", "/api/v1/users", "Bearer " + expiredToken, http.StatusUnauthorized, "invalid or expired token"},
+		{"wrong algorithm", "/api/v1/users", "Bearer " + wrongAlgorithmToken, http.StatusUnauthorized, "invalid or expired token"},
+		{"none algorithm", "/api/v1/users", "Bearer " + noneToken, http.StatusUnauthorized, "invalid or expired token"},
+		{"missing token", "/api/v1/users", "", http.StatusUnauthorized, "missing or malformed bearer token"},
+		{"malformed header", "/api/v1/users", "Token " + validToken, http.StatusUnauthorized, ""},
+		{"excluded path", "/health", "", http.StatusOK, "anonymous"},
+		{"excluded prefix", "/api/v1/auth/login", "", http.StatusOK, "anonymous"},
+		{"exact exclusion does not match sub-paths", "/health/details", "", http.StatusUnauthorized, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tc.path, nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+			if tc.expectedBody != "" {
+				body, _ := io.ReadAll(resp.Body)
+				if string(body) != tc.expectedBody {
+					t.Errorf("Expected body '%s', got '%s'", tc.expectedBody, string(body))
+				}
+			}
+		})
+	}
+
+	// The parser errors are only logged, not returned to the client
+	if !strings.Contains(buf.String(), "Rejected unauthenticated request") || !strings.Contains(buf.String(), "token is expired") {
+		t.Errorf("Expected rejected requests to be logged with the parser error, got: %s", buf.String())
+	}
+}
+
+func TestJWTMiddlewareEmptySecret(t *testing.T) {
+	app := fiber.New()
+	app.Use(JWTMiddleware(nil, nil))
+	app.Get("/profile", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	// A token signed with an empty key must not be accepted
+	token := signTestToken(t, jwt.SigningMethodHS256, []byte{}, jwt.MapClaims{"sub": "42"})
+	req := httptest.NewRequest("GET", "/profile", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, resp.StatusCode)
+	}
+}
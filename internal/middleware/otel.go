@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceContextLocalsKey is the c.Locals key holding the request context.Context with the request span
+const TraceContextLocalsKey = "ctx"
+
+// traceContextPropagator reads and writes the W3C traceparent and tracestate headers
+var traceContextPropagator = propagation.TraceContext{}
+
+// headerCarrier adapts the fasthttp request and response headers to propagation.TextMapCarrier
+type headerCarrier struct {
+	c *fiber.Ctx
+}
+
+func (h headerCarrier) Get(key string) string {
+	return h.c.Get(key)
+}
+
+func (h headerCarrier) Set(key, value string) {
+	h.c.Set(key, value)
+}
+
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0)
+	h.c.Request().Header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
+
+// OTelMiddleware starts a server span for each request, continuing the trace from the incoming traceparent header.
+// The span is named after the matched route template, e.g. /api/v1/users/:id, and the span context is echoed
+// on the response. The request context with the span is stored in c.Locals and the user context.
+func OTelMiddleware(tracer trace.Tracer) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		carrier := headerCarrier{c: c}
+		ctx := traceContextPropagator.Extract(c.UserContext(), carrier)
+
+		// The route is only known once the router matched, the span is renamed afterwards
+		ctx, span := tracer.Start(ctx, c.Method()+" "+c.Path(), trace.WithSpanKind(trace.SpanKindServer))
+		defer span.End()
+
+		c.Locals(TraceContextLocalsKey, ctx)
+		c.SetUserContext(ctx)
+		traceContextPropagator.Inject(ctx, carrier)
+
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			// The ErrorHandler writes the response after the middleware chain returns
+			status = fiber.StatusInternalServerError
+			var e *fiber.Error
+			if errors.As(err, &e) {
+				status = e.Code
+			}
+			span.RecordError(err)
+		}
+
+		route := c.Route().Path
+		span.SetName(route)
+		span.SetAttributes(
+			attribute.String("http.method", c.Method()),
+			attribute.String("http.route", route),
+			attribute.Int("http.status_code", status),
+			attribute.String("http.url", c.OriginalURL()),
+		)
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		return err
+	}
+}
+
+// TraceContext returns the request context stored by OTelMiddleware, or the user context without it.
+func TraceContext(c *fiber.Ctx) context.Context {
+	if ctx, ok := c.Locals(TraceContextLocalsKey).(context.Context); ok {
+		return ctx
+	}
+	return c.UserContext()
+}
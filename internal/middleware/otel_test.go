@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func createOTelTestApp(t *testing.T) (*fiber.App, *tracetest.InMemoryExporter) {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	app := fiber.New()
+	app.Use(OTelMiddleware(provider.Tracer("test")))
+	app.Get("/api/v1/users/:id", func(c *fiber.Ctx) error {
+		// Child span from the stored request context
+		_, child := provider.Tracer("test").Start(TraceContext(c), "load user")
+		child.End()
+		return c.SendString("ok")
+	})
+	app.Get("/fail", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusServiceUnavailable, "unavailable")
+	})
+
+	return app, exporter
+}
+
+// spanAttributes returns the attributes of span by key
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value, len(span.Attributes))
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestOTelMiddlewareSpan(t *testing.T) {
+	app, exporter := createOTelTestApp(t)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/users/42?fields=name", nil))
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("Expected a request span and a child span, got %d spans", len(spans))
+	}
+	child, server := spans[0], spans[1]
+
+	if server.Name != "/api/v1/users/:id" {
+		t.Errorf("Expected span name to be the route template, got %q", server.Name)
+	}
+	if server.SpanKind != trace.SpanKindServer {
+		t.Errorf("Expected server span kind, got %s", server.SpanKind)
+	}
+	if child.Parent.SpanID() != server.SpanContext.SpanID() {
+		t.Error("Expected the handler span to be a child of the request span")
+	}
+
+	attrs := spanAttributes(server)
+	expected := map[attribute.Key]attribute.Value{
+		"http.method":      attribute.StringValue("GET"),
+		"http.route":       attribute.StringValue("/api/v1/users/:id"),
+		"http.status_code": attribute.IntValue(http.StatusOK),
+		"http.url":         attribute.StringValue("/api/v1/users/42?fields=name"),
+	}
+	for k, v := range expected {
+		if attrs[k] != v {
+			t.Errorf("Expected attribute %s=%v, got %v", k, v.Emit(), attrs[k].Emit())
+		}
+	}
+}
+
+func TestOTelMiddlewarePropagation(t *testing.T) {
+	app, exporter := createOTelTestApp(t)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/1", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	server := spans[len(spans)-1]
+	if got := server.SpanContext.TraceID().String(); got != traceID {
+		t.Errorf("Expected trace ID %s from traceparent, got %s", traceID, got)
+	}
+	if got := server.Parent.SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("Expected remote parent span 00f067aa0ba902b7, got %s", got)
+	}
+
+	expectedHeader := "00-" + traceID + "-" + server.SpanContext.SpanID().String() + "-01"
+	if got := resp.Header.Get("traceparent"); got != expectedHeader {
+		t.Errorf("Expected response traceparent %s, got %s", expectedHeader, got)
+	}
+}
+
+func TestOTelMiddlewareErrorStatus(t *testing.T) {
+	app, exporter := createOTelTestApp(t)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/fail", nil))
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Expected status %d, got %d", http.StatusServiceUnavailable, resp.StatusCode)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	if got := spanAttributes(spans[0])["http.status_code"]; got != attribute.IntValue(http.StatusServiceUnavailable) {
+		t.Errorf("Expected status code attribute %d, got %v", http.StatusServiceUnavailable, got.Emit())
+	}
+	if spans[0].Status.Code != codes.Error {
+		t.Errorf("Expected error span status, got %s", spans[0].Status.Code)
+	}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import "strings"
+
+// pathSet matches request paths exactly, or by prefix for entries ending in *, e.g. /api/v1/auth/*
+type pathSet struct {
+	exact    map[string]struct{}
+	prefixes []string
+}
+
+// add adds paths to the set
+func (p *pathSet) add(paths ...string) {
+	if p.exact == nil {
+		p.exact = make(map[string]struct{})
+	}
+	for _, path := range paths {
+		if prefix, ok := strings.CutSuffix(path, "*"); ok {
+			p.prefixes = append(p.prefixes, prefix)
+			continue
+		}
+		p.exact[path] = struct{}{}
+	}
+}
+
+// contains reports whether path matches an entry of the set
+func (p *pathSet) contains(path string) bool {
+	if _, ok := p.exact[path]; ok {
+		return true
+	}
+	for _, prefix := range p.prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// httpMetrics holds the request metrics recorded by PrometheusMiddleware
+type httpMetrics struct {
+	requests     *prometheus.CounterVec
+	duration     *prometheus.HistogramVec
+	responseSize *prometheus.HistogramVec
+}
+
+// PrometheusMiddleware registers the HTTP request metrics with registerer and records them for each request.
+// The path label is the matched route template, e.g. /api/v1/users/:id, to keep its cardinality bounded.
+func PrometheusMiddleware(registerer prometheus.Registerer) fiber.Handler {
+	labels := []string{"method", "path", "status"}
+	metrics := &httpMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests.",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Duration of HTTP requests in seconds.",
+			Buckets: prometheus.DefBuckets,
+		}, labels),
+		responseSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_response_size_bytes",
+			Help:    "Size of HTTP responses in bytes.",
+			Buckets: prometheus.ExponentialBuckets(100, 10, 6),
+		}, labels),
+	}
+	registerer.MustRegister(metrics.requests, metrics.duration, metrics.responseSize)
+
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			// The ErrorHandler writes the response after the middleware chain returns
+			status = fiber.StatusInternalServerError
+			var e *fiber.Error
+			if errors.As(err, &e) {
+				status = e.Code
+			}
+		}
+
+		values := []string{c.Method(), c.Route().Path, strconv.Itoa(status)}
+		metrics.requests.WithLabelValues(values...).Inc()
+		metrics.duration.WithLabelValues(values...).Observe(time.Since(start).Seconds())
+		metrics.responseSize.WithLabelValues(values...).Observe(float64(len(c.Response().Body())))
+
+		return err
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+func TestPrometheusMiddleware(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	app := fiber.New()
+	app.Use(PrometheusMiddleware(registry))
+	app.Get("/users/:id", func(c *fiber.Ctx) error {
+		return c.SendString("user")
+	})
+	app.Get("/fail", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusBadRequest, "bad request")
+	})
+
+	for _, path := range []string{"/users/1", "/users/2", "/fail"} {
+		if _, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil)); err != nil {
+			t.Fatalf("Failed to test request: %v", err)
+		}
+	}
+
+	// Scrape the registry the way Prometheus would
+	scrape := fiber.New()
+	scrape.Get("/metrics", adaptor.HTTPHandler(promhttp.HandlerFor(registry, promhttp.HandlerOpts{})))
+	resp, err := scrape.Test(httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if err != nil {
+		t.Fatalf("Failed to scrape metrics: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	output := string(body)
+
+	testCases := []struct {
+		name     string
+		line     string
+		expected bool
+	}{
+		{"route template", `http_requests_total{method="GET",path="/users/:id",status="200"} 2`, true},
+		{"error status", `http_requests_total{method="GET",path="/fail",status="400"} 1`, true},
+		{"actual path not used", `path="/users/1"`, false},
+		{"duration histogram", `http_request_duration_seconds_count{method="GET",path="/users/:id",status="200"} 2`, true},
+		{"response size histogram", `http_response_size_bytes_sum{method="GET",path="/users/:id",status="200"} 8`, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := strings.Contains(output, tc.line); got != tc.expected {
+				t.Errorf("Expected %q present=%v, got present=%v in:\n%s", tc.line, tc.expected, got, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// NewResponseSizeLimitMiddleware creates a middleware that rejects responses larger than maxBytes.
+// When a handler writes more than the limit, the body is discarded and a 500 error is returned instead.
+func NewResponseSizeLimitMiddleware(maxBytes int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		// Process request
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		// Check the size of the written body
+		size := len(c.Response().Body())
+		if maxBytes > 0 && size > maxBytes {
+			c.Response().ResetBody()
+			return fiber.NewError(
+				fiber.StatusInternalServerError,
+				fmt.Sprintf("response body of %d bytes exceeds the limit of %d bytes", size, maxBytes),
+			)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	oneMB = 1024 * 1024
+	tenMB = 10 * oneMB
+)
+
+func createResponseSizeTestApp(limit int) *fiber.App {
+	app := fiber.New()
+	app.Use(NewResponseSizeLimitMiddleware(limit))
+
+	app.Get("/large", func(c *fiber.Ctx) error {
+		return c.Send(bytes.Repeat([]byte("a"), tenMB))
+	})
+
+	app.Get("/small", func(c *fiber.Ctx) error {
+		return c.SendString("small response")
+	})
+
+	return app
+}
+
+func TestResponseSizeLimitExceeded(t *testing.T) {
+	app := createResponseSizeTestApp(oneMB)
+
+	req := httptest.NewRequest("GET", "/large", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("Failed to test large response: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read response body: %v", err)
+	}
+
+	if len(body) >= oneMB {
+		t.Errorf("Expected body to be truncated below %d bytes, got %d bytes", oneMB, len(body))
+	}
+
+	if !strings.Contains(string(body), "exceeds the limit") {
+		t.Errorf("Expected descriptive error message, got '%s'", string(body))
+	}
+}
+
+func TestResponseSizeLimitWithinLimit(t *testing.T) {
+	app := createResponseSizeTestApp(oneMB)
+
+	req := httptest.NewRequest("GET", "/small", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test small response: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read response body: %v", err)
+	}
+
+	if string(body) != "small response" {
+		t.Errorf("Expected body 'small response', got '%s'", string(body))
+	}
+}
+
+func TestResponseSizeLimitDisabled(t *testing.T) {
+	app := createResponseSizeTestApp(0)
+
+	req := httptest.NewRequest("GET", "/large", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("Failed to test large response: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200 when limit is disabled, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// NewRoleMiddleware only allows requests whose JWT "role" claim matches required.
+// It must run after NewJWTMiddleware, which stores the claims in c.Locals("user").
+func NewRoleMiddleware(required string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := c.Locals(UserLocalsKey).(jwt.MapClaims)
+		if !ok {
+			return fiber.NewError(fiber.StatusUnauthorized, "authentication required")
+		}
+
+		if role, _ := claims["role"].(string); role != required {
+			return fiber.NewError(fiber.StatusForbidden, "insufficient role")
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestRoleMiddleware(t *testing.T) {
+	testCases := []struct {
+		name           string
+		claims         jwt.MapClaims
+		expectedStatus int
+	}{
+		{"matching role", jwt.MapClaims{"role": "admin"}, http.StatusOK},
+		{"different role", jwt.MapClaims{"role": "user"}, http.StatusForbidden},
+		{"missing role claim", jwt.MapClaims{"sub": "42"}, http.StatusForbidden},
+		{"unauthenticated", nil, http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(func(c *fiber.Ctx) error {
+				if tc.claims != nil {
+					c.Locals(UserLocalsKey, tc.claims)
+				}
+				return c.Next()
+			})
+			app.Use(NewRoleMiddleware("admin"))
+			app.Get("/admin", func(c *fiber.Ctx) error {
+				return c.SendString("ok")
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/admin", nil))
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
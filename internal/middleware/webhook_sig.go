@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// WebhookSignatureConfig holds the settings for verifying HMAC signed webhook payloads
+type WebhookSignatureConfig struct {
+	Header    string // Header carrying the signature, e.g. "X-Hub-Signature-256"
+	Secret    string // Shared webhook secret
+	Algorithm string // "sha256" or "sha1"
+	Prefix    string // Prefix before the hex digest, e.g. "sha256="
+}
+
+// ErrUnsupportedWebhookAlgorithm is returned by NewWebhookSignatureMiddleware for algorithms other than sha256 and sha1
+var ErrUnsupportedWebhookAlgorithm = errors.New("unsupported webhook signature algorithm")
+
+// NewWebhookSignatureMiddleware rejects requests whose body doesn't match the HMAC signature header with 401.
+// The body is left in place for the handler.
+func NewWebhookSignatureMiddleware(config WebhookSignatureConfig) (fiber.Handler, error) {
+	var newHash func() hash.Hash
+	switch strings.ToLower(strings.TrimSpace(config.Algorithm)) {
+	case "sha256":
+		newHash = sha256.New
+	case "sha1":
+		newHash = sha1.New
+	default:
+		return nil, fmt.Errorf("%w %q, expected sha256 or sha1", ErrUnsupportedWebhookAlgorithm, config.Algorithm)
+	}
+	secret := []byte(config.Secret)
+
+	return func(c *fiber.Ctx) error {
+		signature := c.Get(config.Header)
+		if signature == "" {
+			return fiber.NewError(fiber.StatusUnauthorized, "missing webhook signature")
+		}
+		if !strings.HasPrefix(signature, config.Prefix) {
+			return fiber.NewError(fiber.StatusUnauthorized, "invalid webhook signature")
+		}
+
+		expected, err := hex.DecodeString(strings.TrimPrefix(signature, config.Prefix))
+		if err != nil {
+			return fiber.NewError(fiber.StatusUnauthorized, "invalid webhook signature")
+		}
+
+		mac := hmac.New(newHash, secret)
+		mac.Write(c.Body())
+		if !hmac.Equal(mac.Sum(nil), expected) {
+			return fiber.NewError(fiber.StatusUnauthorized, "invalid webhook signature")
+		}
+
+		return c.Next()
+	}, nil
+}
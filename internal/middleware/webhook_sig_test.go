@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const webhookTestSecret = "webhook-secret"
+
+func signWebhookBody(newHash func() hash.Hash, body string) string {
+	mac := hmac.New(newHash, []byte(webhookTestSecret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func createWebhookTestApp(t *testing.T) *fiber.App {
+	app := fiber.New()
+	verify, err := NewWebhookSignatureMiddleware(WebhookSignatureConfig{
+		Header:    "X-Hub-Signature-256",
+		Secret:    webhookTestSecret,
+		Algorithm: "sha256",
+		Prefix:    "sha256=",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create webhook signature middleware: %v", err)
+	}
+	app.Use(verify)
+
+	// Echo the body to prove the middleware left it readable
+	app.Post("/webhooks/github", func(c *fiber.Ctx) error {
+		return c.Send(c.Body())
+	})
+
+	return app
+}
+
+func TestWebhookSignatureMiddleware(t *testing.T) {
+	app := createWebhookTestApp(t)
+	payload := `{"action":"opened","number":42}`
+
+	testCases := []struct {
+		name           string
+		body           string
+		signature      string
+		expectedStatus int
+	}{
+		{"valid signature", payload, "sha256=" + signWebhookBody(sha256.New, payload), http.StatusOK},
+		{"tampered body", `{"action":"closed","number":42}`, "sha256=" + signWebhookBody(sha256.New, payload), http.StatusUnauthorized},
+		{"missing header", payload, "", http.StatusUnauthorized},
+		{"wrong algorithm", payload, "sha256=" + signWebhookBody(sha1.New, payload), http.StatusUnauthorized},
+		{"missing prefix", payload, signWebhookBody(sha256.New, payload), http.StatusUnauthorized},
+		{"not hex", payload, "sha256=not-hex", http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/webhooks/github", strings.NewReader(tc.body))
+			if tc.signature != "" {
+				req.Header.Set("X-Hub-Signature-256", tc.signature)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test webhook signature middleware: %v", err)
+			}
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.expectedStatus == http.StatusOK {
+				body, _ := io.ReadAll(resp.Body)
+				if string(body) != tc.body {
+					t.Errorf("Expected handler to receive the raw body, got %q", string(body))
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookSignatureMiddlewareSHA1(t *testing.T) {
+	app := fiber.New()
+	verify, err := NewWebhookSignatureMiddleware(WebhookSignatureConfig{
+		Header:    "X-Hub-Signature",
+		Secret:    webhookTestSecret,
+		Algorithm: "sha1",
+		Prefix:    "sha1=",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create webhook signature middleware: %v", err)
+	}
+	app.Use(verify)
+	app.Post("/webhooks", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	req := httptest.NewRequest("POST", "/webhooks", strings.NewReader("payload"))
+	req.Header.Set("X-Hub-Signature", "sha1="+signWebhookBody(sha1.New, "payload"))
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test webhook signature middleware: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestWebhookSignatureMiddlewareUnsupportedAlgorithm(t *testing.T) {
+	handler, err := NewWebhookSignatureMiddleware(WebhookSignatureConfig{Header: "X-Signature", Secret: "s", Algorithm: "md5"})
+	if !errors.Is(err, ErrUnsupportedWebhookAlgorithm) {
+		t.Errorf("Expected ErrUnsupportedWebhookAlgorithm, got %v", err)
+	}
+	if handler != nil {
+		t.Error("Expected no handler for unsupported algorithm")
+	}
+}
@@ -0,0 +1,7 @@
+package repository
+
+// Regenerates the users and products packages from db/queries
+//go:generate sqlc generate -f ../../sqlc.yaml
+
+// Regenerates the users.Querier mock used by the service tests
+//go:generate mockery --name=Querier --dir=users --output=users/mocks
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Hook events accepted by HookedRepository.AddHook
+const (
+	BeforeCreate = "before_create"
+	AfterCreate  = "after_create"
+	BeforeUpdate = "before_update"
+	AfterUpdate  = "after_update"
+	BeforeDelete = "before_delete"
+	AfterDelete  = "after_delete"
+)
+
+// CRUDRepository is the basic set of operations for a single entity type
+type CRUDRepository[T any, ID comparable] interface {
+	Create(ctx context.Context, entity *T) error
+	Get(ctx context.Context, id ID) (T, error)
+	Update(ctx context.Context, entity *T) error
+	Delete(ctx context.Context, id ID) error
+}
+
+// HookedRepository wraps a CRUDRepository and runs registered callbacks around writes.
+// Before hooks can abort the operation by returning an error; after hooks only run on success.
+type HookedRepository[T any, ID comparable] struct {
+	CRUDRepository[T, ID]
+
+	mu           sync.RWMutex
+	beforeCreate []func(context.Context, *T) error
+	afterCreate  []func(context.Context, T)
+	beforeUpdate []func(context.Context, *T) error
+	afterUpdate  []func(context.Context, T)
+	beforeDelete []func(context.Context, ID) error
+	afterDelete  []func(context.Context, ID)
+}
+
+// NewHookedRepository wraps repo with hook support
+func NewHookedRepository[T any, ID comparable](repo CRUDRepository[T, ID]) *HookedRepository[T, ID] {
+	return &HookedRepository[T, ID]{CRUDRepository: repo}
+}
+
+// AddHook registers fn for event. Create and update hooks take the entity
+// (func(ctx, *T) error before, func(ctx, T) after); delete hooks take the ID
+// (func(ctx, ID) error before, func(ctx, ID) after).
+func (r *HookedRepository[T, ID]) AddHook(event string, fn interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch event {
+	case BeforeCreate, BeforeUpdate:
+		hook, ok := fn.(func(context.Context, *T) error)
+		if !ok {
+			return fmt.Errorf("invalid %s hook type %T", event, fn)
+		}
+		if event == BeforeCreate {
+			r.beforeCreate = append(r.beforeCreate, hook)
+		} else {
+			r.beforeUpdate = append(r.beforeUpdate, hook)
+		}
+	case AfterCreate, AfterUpdate:
+		hook, ok := fn.(func(context.Context, T))
+		if !ok {
+			return fmt.Errorf("invalid %s hook type %T", event, fn)
+		}
+		if event == AfterCreate {
+			r.afterCreate = append(r.afterCreate, hook)
+		} else {
+			r.afterUpdate = append(r.afterUpdate, hook)
+		}
+	case BeforeDelete:
+		hook, ok := fn.(func(context.Context, ID) error)
+		if !ok {
+			return fmt.Errorf("invalid %s hook type %T", event, fn)
+		}
+		r.beforeDelete = append(r.beforeDelete, hook)
+	case AfterDelete:
+		hook, ok := fn.(func(context.Context, ID))
+		if !ok {
+			return fmt.Errorf("invalid %s hook type %T", event, fn)
+		}
+		r.afterDelete = append(r.afterDelete, hook)
+	default:
+		return fmt.Errorf("unknown hook event %q", event)
+	}
+
+	return nil
+}
+
+// Create runs the before_create hooks, creates the entity and runs the after_create hooks
+func (r *HookedRepository[T, ID]) Create(ctx context.Context, entity *T) error {
+	r.mu.RLock()
+	before, after := r.beforeCreate, r.afterCreate
+	r.mu.RUnlock()
+
+	for _, hook := range before {
+		if err := hook(ctx, entity); err != nil {
+			return fmt.Errorf("%s hook: %w", BeforeCreate, err)
+		}
+	}
+
+	if err := r.CRUDRepository.Create(ctx, entity); err != nil {
+		return err
+	}
+
+	for _, hook := range after {
+		hook(ctx, *entity)
+	}
+	return nil
+}
+
+// Update runs the before_update hooks, updates the entity and runs the after_update hooks
+func (r *HookedRepository[T, ID]) Update(ctx context.Context, entity *T) error {
+	r.mu.RLock()
+	before, after := r.beforeUpdate, r.afterUpdate
+	r.mu.RUnlock()
+
+	for _, hook := range before {
+		if err := hook(ctx, entity); err != nil {
+			return fmt.Errorf("%s hook: %w", BeforeUpdate, err)
+		}
+	}
+
+	if err := r.CRUDRepository.Update(ctx, entity); err != nil {
+		return err
+	}
+
+	for _, hook := range after {
+		hook(ctx, *entity)
+	}
+	return nil
+}
+
+// Delete runs the before_delete hooks, deletes the entity and runs the after_delete hooks
+func (r *HookedRepository[T, ID]) Delete(ctx context.Context, id ID) error {
+	r.mu.RLock()
+	before, after := r.beforeDelete, r.afterDelete
+	r.mu.RUnlock()
+
+	for _, hook := range before {
+		if err := hook(ctx, id); err != nil {
+			return fmt.Errorf("%s hook: %w", BeforeDelete, err)
+		}
+	}
+
+	if err := r.CRUDRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	for _, hook := range after {
+		hook(ctx, id)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type widget struct {
+	ID   int
+	Name string
+}
+
+// memoryRepository is an in-memory CRUDRepository used to exercise the hooks
+type memoryRepository struct {
+	items     map[int]widget
+	nextID    int
+	createErr error
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{items: make(map[int]widget), nextID: 1}
+}
+
+func (m *memoryRepository) Create(ctx context.Context, w *widget) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	w.ID = m.nextID
+	m.nextID++
+	m.items[w.ID] = *w
+	return nil
+}
+
+func (m *memoryRepository) Get(ctx context.Context, id int) (widget, error) {
+	w, ok := m.items[id]
+	if !ok {
+		return widget{}, errors.New("not found")
+	}
+	return w, nil
+}
+
+func (m *memoryRepository) Update(ctx context.Context, w *widget) error {
+	m.items[w.ID] = *w
+	return nil
+}
+
+func (m *memoryRepository) Delete(ctx context.Context, id int) error {
+	delete(m.items, id)
+	return nil
+}
+
+func TestHookedRepositoryBeforeCreateAborts(t *testing.T) {
+	inner := newMemoryRepository()
+	repo := NewHookedRepository[widget, int](inner)
+
+	hookErr := errors.New("name required")
+	afterCalled := false
+	if err := repo.AddHook(BeforeCreate, func(ctx context.Context, w *widget) error {
+		if w.Name == "" {
+			return hookErr
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("Failed to add hook: %v", err)
+	}
+	if err := repo.AddHook(AfterCreate, func(ctx context.Context, w widget) {
+		afterCalled = true
+	}); err != nil {
+		t.Fatalf("Failed to add hook: %v", err)
+	}
+
+	err := repo.Create(context.Background(), &widget{})
+	if !errors.Is(err, hookErr) {
+		t.Errorf("Expected hook error, got %v", err)
+	}
+	if len(inner.items) != 0 {
+		t.Errorf("Expected create to be aborted, got %d items", len(inner.items))
+	}
+	if afterCalled {
+		t.Error("Expected after_create hook not to be called")
+	}
+}
+
+func TestHookedRepositoryAfterCreateOnlyOnSuccess(t *testing.T) {
+	inner := newMemoryRepository()
+	repo := NewHookedRepository[widget, int](inner)
+
+	var created []widget
+	if err := repo.AddHook(AfterCreate, func(ctx context.Context, w widget) {
+		created = append(created, w)
+	}); err != nil {
+		t.Fatalf("Failed to add hook: %v", err)
+	}
+
+	if err := repo.Create(context.Background(), &widget{Name: "first"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(created) != 1 || created[0].ID != 1 {
+		t.Errorf("Expected after_create to receive the stored widget, got %v", created)
+	}
+
+	inner.createErr = errors.New("insert failed")
+	if err := repo.Create(context.Background(), &widget{Name: "second"}); err == nil {
+		t.Error("Expected create error, got nil")
+	}
+	if len(created) != 1 {
+		t.Errorf("Expected after_create not to run on failure, got %d calls", len(created))
+	}
+}
+
+func TestHookedRepositoryUpdateAndDelete(t *testing.T) {
+	inner := newMemoryRepository()
+	repo := NewHookedRepository[widget, int](inner)
+
+	var events []string
+	hooks := map[string]interface{}{
+		BeforeUpdate: func(ctx context.Context, w *widget) error { events = append(events, BeforeUpdate); return nil },
+		AfterUpdate:  func(ctx context.Context, w widget) { events = append(events, AfterUpdate) },
+		BeforeDelete: func(ctx context.Context, id int) error { events = append(events, BeforeDelete); return nil },
+		AfterDelete:  func(ctx context.Context, id int) { events = append(events, AfterDelete) },
+	}
+	for event, fn := range hooks {
+		if err := repo.AddHook(event, fn); err != nil {
+			t.Fatalf("Failed to add %s hook: %v", event, err)
+		}
+	}
+
+	w := &widget{Name: "thing"}
+	_ = repo.Create(context.Background(), w)
+	_ = repo.Update(context.Background(), w)
+	_ = repo.Delete(context.Background(), w.ID)
+
+	expected := []string{BeforeUpdate, AfterUpdate, BeforeDelete, AfterDelete}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected events %v, got %v", expected, events)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Errorf("Expected event %s at %d, got %s", expected[i], i, events[i])
+		}
+	}
+}
+
+func TestHookedRepositoryAddHookValidation(t *testing.T) {
+	repo := NewHookedRepository[widget, int](newMemoryRepository())
+
+	if err := repo.AddHook("before_save", func() {}); err == nil {
+		t.Error("Expected error for unknown event, got nil")
+	}
+	if err := repo.AddHook(BeforeCreate, func(ctx context.Context, w widget) {}); err == nil {
+		t.Error("Expected error for mismatched hook signature, got nil")
+	}
+}
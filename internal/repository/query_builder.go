@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrEmptyWhitelist is returned by Build when no columns have been whitelisted.
+var ErrEmptyWhitelist = errors.New("query builder: column whitelist is empty")
+
+// allowedOperators lists the comparison operators accepted by Where.
+var allowedOperators = map[string]bool{
+	"=": true, "!=": true, "<>": true, "<": true, "<=": true, ">": true, ">=": true,
+	"LIKE": true, "NOT LIKE": true, "IN": true, "NOT IN": true,
+}
+
+type condition struct {
+	column   string
+	operator string
+	value    interface{}
+}
+
+type ordering struct {
+	column    string
+	direction string
+}
+
+// QueryBuilder builds parameterized SELECT statements with whitelisted column names.
+type QueryBuilder struct {
+	table      string
+	columns    []string
+	allowed    map[string]bool
+	conditions []condition
+	orderings  []ordering
+	limit      int
+	offset     int
+	err        error
+}
+
+// NewQuery starts a SELECT query against table.
+func NewQuery(table string) *QueryBuilder {
+	return &QueryBuilder{
+		table:   table,
+		allowed: make(map[string]bool),
+	}
+}
+
+// AllowColumns adds columns to the whitelist checked by Select, Where and OrderBy.
+func (q *QueryBuilder) AllowColumns(columns ...string) *QueryBuilder {
+	for _, column := range columns {
+		q.allowed[column] = true
+	}
+	return q
+}
+
+// Select sets the selected columns. All columns are selected when unset.
+func (q *QueryBuilder) Select(columns ...string) *QueryBuilder {
+	q.columns = append(q.columns, columns...)
+	return q
+}
+
+// Where adds a condition joined to the previous ones with AND.
+// IN and NOT IN expect a slice value, which is expanded into one placeholder per element.
+func (q *QueryBuilder) Where(column, operator string, value interface{}) *QueryBuilder {
+	operator = strings.ToUpper(strings.TrimSpace(operator))
+	if !allowedOperators[operator] {
+		q.setErr(fmt.Errorf("query builder: unsupported operator %q", operator))
+		return q
+	}
+	q.conditions = append(q.conditions, condition{column: column, operator: operator, value: value})
+	return q
+}
+
+// OrderBy adds a sort column with direction ASC or DESC.
+func (q *QueryBuilder) OrderBy(column, direction string) *QueryBuilder {
+	direction = strings.ToUpper(strings.TrimSpace(direction))
+	if direction != "ASC" && direction != "DESC" {
+		q.setErr(fmt.Errorf("query builder: invalid sort direction %q", direction))
+		return q
+	}
+	q.orderings = append(q.orderings, ordering{column: column, direction: direction})
+	return q
+}
+
+// Limit caps the number of returned rows. Zero means no limit.
+func (q *QueryBuilder) Limit(limit int) *QueryBuilder {
+	if limit < 0 {
+		q.setErr(fmt.Errorf("query builder: negative limit %d", limit))
+	}
+	q.limit = limit
+	return q
+}
+
+// Offset skips the given number of rows. It only applies together with Limit.
+func (q *QueryBuilder) Offset(offset int) *QueryBuilder {
+	if offset < 0 {
+		q.setErr(fmt.Errorf("query builder: negative offset %d", offset))
+	}
+	q.offset = offset
+	return q
+}
+
+// Build returns the SQL statement with ? placeholders and its arguments.
+func (q *QueryBuilder) Build() (string, []interface{}, error) {
+	if q.err != nil {
+		return "", nil, q.err
+	}
+	if len(q.allowed) == 0 {
+		return "", nil, ErrEmptyWhitelist
+	}
+
+	selected := "*"
+	if len(q.columns) > 0 {
+		for _, column := range q.columns {
+			if err := q.checkColumn(column); err != nil {
+				return "", nil, err
+			}
+		}
+		selected = strings.Join(q.columns, ", ")
+	}
+
+	var sb strings.Builder
+	var args []interface{}
+	fmt.Fprintf(&sb, "SELECT %s FROM %s", selected, q.table)
+
+	for i, cond := range q.conditions {
+		if err := q.checkColumn(cond.column); err != nil {
+			return "", nil, err
+		}
+
+		if i == 0 {
+			sb.WriteString(" WHERE ")
+		} else {
+			sb.WriteString(" AND ")
+		}
+
+		if cond.operator == "IN" || cond.operator == "NOT IN" {
+			values, err := expandSlice(cond.value)
+			if err != nil {
+				return "", nil, fmt.Errorf("query builder: %s %s: %w", cond.column, cond.operator, err)
+			}
+			placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ")
+			fmt.Fprintf(&sb, "%s %s (%s)", cond.column, cond.operator, placeholders)
+			args = append(args, values...)
+			continue
+		}
+
+		fmt.Fprintf(&sb, "%s %s ?", cond.column, cond.operator)
+		args = append(args, cond.value)
+	}
+
+	for i, order := range q.orderings {
+		if err := q.checkColumn(order.column); err != nil {
+			return "", nil, err
+		}
+		if i == 0 {
+			sb.WriteString(" ORDER BY ")
+		} else {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "%s %s", order.column, order.direction)
+	}
+
+	if q.limit > 0 {
+		fmt.Fprintf(&sb, " LIMIT %d", q.limit)
+		if q.offset > 0 {
+			fmt.Fprintf(&sb, " OFFSET %d", q.offset)
+		}
+	}
+
+	return sb.String(), args, nil
+}
+
+// checkColumn rejects columns that are not whitelisted.
+func (q *QueryBuilder) checkColumn(column string) error {
+	if !q.allowed[column] {
+		return fmt.Errorf("query builder: column %q is not allowed", column)
+	}
+	return nil
+}
+
+// setErr records the first error raised while building.
+func (q *QueryBuilder) setErr(err error) {
+	if q.err == nil {
+		q.err = err
+	}
+}
+
+// expandSlice converts a slice value into a list of query arguments.
+func expandSlice(value interface{}) ([]interface{}, error) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a slice, got %T", value)
+	}
+	if v.Len() == 0 {
+		return nil, errors.New("empty value list")
+	}
+
+	values := make([]interface{}, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Interface()
+	}
+	return values, nil
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestQueryBuilderBuild(t *testing.T) {
+	columns := []string{"id", "role", "status", "created_at"}
+
+	testCases := []struct {
+		name         string
+		build        func() *QueryBuilder
+		expectedSQL  string
+		expectedArgs []interface{}
+	}{
+		{
+			name: "single where",
+			build: func() *QueryBuilder {
+				return NewQuery("users").AllowColumns(columns...).Where("role", "=", "admin")
+			},
+			expectedSQL:  "SELECT * FROM users WHERE role = ?",
+			expectedArgs: []interface{}{"admin"},
+		},
+		{
+			name: "multiple where",
+			build: func() *QueryBuilder {
+				return NewQuery("users").AllowColumns(columns...).
+					Where("role", "=", "admin").
+					Where("id", ">", 10)
+			},
+			expectedSQL:  "SELECT * FROM users WHERE role = ? AND id > ?",
+			expectedArgs: []interface{}{"admin", 10},
+		},
+		{
+			name: "in clause",
+			build: func() *QueryBuilder {
+				return NewQuery("users").AllowColumns(columns...).
+					Where("status", "IN", []string{"active", "pending"})
+			},
+			expectedSQL:  "SELECT * FROM users WHERE status IN (?, ?)",
+			expectedArgs: []interface{}{"active", "pending"},
+		},
+		{
+			name: "order limit and offset",
+			build: func() *QueryBuilder {
+				return NewQuery("users").AllowColumns(columns...).
+					Select("id", "role").
+					Where("role", "=", "admin").
+					Where("status", "in", []string{"active", "pending"}).
+					OrderBy("created_at", "desc").
+					Limit(20).
+					Offset(40)
+			},
+			expectedSQL:  "SELECT id, role FROM users WHERE role = ? AND status IN (?, ?) ORDER BY created_at DESC LIMIT 20 OFFSET 40",
+			expectedArgs: []interface{}{"admin", "active", "pending"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, args, err := tc.build().Build()
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if query != tc.expectedSQL {
+				t.Errorf("Expected query %q, got %q", tc.expectedSQL, query)
+			}
+			if !reflect.DeepEqual(args, tc.expectedArgs) {
+				t.Errorf("Expected args %v, got %v", tc.expectedArgs, args)
+			}
+		})
+	}
+}
+
+func TestQueryBuilderErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		builder *QueryBuilder
+	}{
+		{"empty whitelist", NewQuery("users").Where("role", "=", "admin")},
+		{"column not whitelisted", NewQuery("users").AllowColumns("id").Where("role", "=", "admin")},
+		{"injected column", NewQuery("users").AllowColumns("id").OrderBy("id; DROP TABLE users", "ASC")},
+		{"unsupported operator", NewQuery("users").AllowColumns("id").Where("id", "OR 1=1 --", 1)},
+		{"invalid direction", NewQuery("users").AllowColumns("id").OrderBy("id", "SIDEWAYS")},
+		{"in without slice", NewQuery("users").AllowColumns("id").Where("id", "IN", 1)},
+		{"in with empty slice", NewQuery("users").AllowColumns("id").Where("id", "IN", []int{})},
+		{"negative limit", NewQuery("users").AllowColumns("id").Limit(-1)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := tc.builder.Build(); err == nil {
+				t.Error("Expected an error, got nil")
+			}
+		})
+	}
+
+	if _, _, err := NewQuery("users").Build(); !errors.Is(err, ErrEmptyWhitelist) {
+		t.Errorf("Expected ErrEmptyWhitelist, got %v", err)
+	}
+}
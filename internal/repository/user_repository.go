@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/MayukhSobo/scaffold/internal/repository/users"
+)
+
+// userColumns lists the users table columns in the order they are scanned into users.User
+var userColumns = []string{
+	"id", "username", "email", "password_hash", "first_name", "last_name", "avatar_url", "bio",
+	"phone_number", "address_street", "address_city", "address_state", "address_postal_code",
+	"address_country", "status", "role", "email_verified_at", "last_login_at", "created_at",
+	"updated_at", "deleted_at",
+}
+
+// Filter is a single column condition used by SearchUsers
+type Filter struct {
+	Column   string
+	Operator string
+	Value    interface{}
+}
+
+// UserSearch describes a dynamic users query
+type UserSearch struct {
+	Filters   []Filter
+	OrderBy   string
+	Direction string
+	Limit     int
+	Offset    int
+}
+
+// UserRepository extends the sqlc generated user queries with dynamic search
+type UserRepository struct {
+	*users.Queries
+	db users.DBTX
+}
+
+// NewUserRepository creates a new user repository
+func NewUserRepository(db users.DBTX) *UserRepository {
+	return &UserRepository{
+		Queries: users.New(db),
+		db:      db,
+	}
+}
+
+// SearchUsers returns the users matching all filters
+func (r *UserRepository) SearchUsers(ctx context.Context, search UserSearch) ([]users.User, error) {
+	query := NewQuery("users").AllowColumns(userColumns...).Select(userColumns...)
+	for _, filter := range search.Filters {
+		query.Where(filter.Column, filter.Operator, filter.Value)
+	}
+	if search.OrderBy != "" {
+		direction := search.Direction
+		if direction == "" {
+			direction = "ASC"
+		}
+		query.OrderBy(search.OrderBy, direction)
+	}
+	query.Limit(search.Limit).Offset(search.Offset)
+
+	sqlQuery, args, err := query.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []users.User
+	for rows.Next() {
+		var i users.User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Email,
+			&i.PasswordHash,
+			&i.FirstName,
+			&i.LastName,
+			&i.AvatarUrl,
+			&i.Bio,
+			&i.PhoneNumber,
+			&i.AddressStreet,
+			&i.AddressCity,
+			&i.AddressState,
+			&i.AddressPostalCode,
+			&i.AddressCountry,
+			&i.Status,
+			&i.Role,
+			&i.EmailVerifiedAt,
+			&i.LastLoginAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+const usersSchema = `
+CREATE TABLE users (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    username VARCHAR(255) NOT NULL UNIQUE,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    password_hash VARCHAR(255) NOT NULL,
+    first_name VARCHAR(255) NOT NULL DEFAULT '',
+    last_name VARCHAR(255) NOT NULL DEFAULT '',
+    avatar_url VARCHAR(255) NOT NULL DEFAULT '',
+    bio TEXT NOT NULL DEFAULT '',
+    phone_number VARCHAR(50) NOT NULL DEFAULT '',
+    address_street VARCHAR(255) NOT NULL DEFAULT '',
+    address_city VARCHAR(100) NOT NULL DEFAULT '',
+    address_state VARCHAR(100) NOT NULL DEFAULT '',
+    address_postal_code VARCHAR(50) NOT NULL DEFAULT '',
+    address_country VARCHAR(100) NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending_verification',
+    role TEXT NOT NULL DEFAULT 'user',
+    email_verified_at TIMESTAMP NULL,
+    last_login_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL
+);
+INSERT INTO users (username, email, password_hash, role, status) VALUES
+    ('alice', 'alice@example.com', 'hash', 'admin', 'active'),
+    ('bob', 'bob@example.com', 'hash', 'admin', 'pending_verification'),
+    ('carol', 'carol@example.com', 'hash', 'admin', 'suspended'),
+    ('dave', 'dave@example.com', 'hash', 'user', 'active');`
+
+func TestUserRepositorySearchUsers(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(usersSchema); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+
+	repo := NewUserRepository(db)
+	result, err := repo.SearchUsers(context.Background(), UserSearch{
+		Filters: []Filter{
+			{Column: "role", Operator: "=", Value: "admin"},
+			{Column: "status", Operator: "IN", Value: []string{"active", "pending_verification"}},
+		},
+		OrderBy:   "username",
+		Direction: "DESC",
+		Limit:     20,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 users, got %d", len(result))
+	}
+	if result[0].Username != "bob" || result[1].Username != "alice" {
+		t.Errorf("Expected [bob alice], got [%s %s]", result[0].Username, result[1].Username)
+	}
+
+	if _, err := repo.SearchUsers(context.Background(), UserSearch{
+		Filters: []Filter{{Column: "nickname", Operator: "=", Value: "x"}},
+	}); err == nil {
+		t.Error("Expected error for unknown column, got nil")
+	}
+}
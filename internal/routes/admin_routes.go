@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/MayukhSobo/scaffold/internal/handler"
+	"github.com/MayukhSobo/scaffold/internal/middleware"
+	"github.com/MayukhSobo/scaffold/internal/service"
+	"github.com/MayukhSobo/scaffold/pkg/container"
+)
+
+// RegisterAdminRoutes sets up admin-only routes behind JWT authentication and the admin role
+func RegisterAdminRoutes(v1 fiber.Router, baseHandler *handler.Handler, container *container.TypedContainer) {
+	// Create admin handler
+	adminHandler := handler.NewAdminHandler(baseHandler, container.GetUserService(), container.GetConfig())
+
+	// Admin routes group - authenticate first, then authorize the role
+	secret := []byte(service.JWTSecret(container.GetConfig()))
+	admin := v1.Group("/admin", middleware.NewJWTMiddleware(secret), middleware.NewRoleMiddleware("admin"))
+
+	// User management routes
+	admin.Get("/users", adminHandler.ListUsers) // GET /api/v1/admin/users
+
+	// System config routes
+	admin.Get("/config", adminHandler.GetSystemConfig) // GET /api/v1/admin/config
+}
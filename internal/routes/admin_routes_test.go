@@ -0,0 +1,156 @@
+package routes
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/viper"
+
+	"github.com/MayukhSobo/scaffold/internal/handler"
+)
+
+const adminTestSecret = "admin-test-secret"
+
+// usersSchema mirrors the users migration using SQLite syntax
+const usersSchema = `
+CREATE TABLE users (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    username VARCHAR(255) NOT NULL UNIQUE,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    password_hash VARCHAR(255) NOT NULL,
+    first_name VARCHAR(255) NOT NULL DEFAULT '',
+    last_name VARCHAR(255) NOT NULL DEFAULT '',
+    avatar_url VARCHAR(255) NOT NULL DEFAULT '',
+    bio TEXT NOT NULL DEFAULT '',
+    phone_number VARCHAR(50) NOT NULL DEFAULT '',
+    address_street VARCHAR(255) NOT NULL DEFAULT '',
+    address_city VARCHAR(100) NOT NULL DEFAULT '',
+    address_state VARCHAR(100) NOT NULL DEFAULT '',
+    address_postal_code VARCHAR(50) NOT NULL DEFAULT '',
+    address_country VARCHAR(100) NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending_verification',
+    role TEXT NOT NULL DEFAULT 'user',
+    email_verified_at TIMESTAMP NULL,
+    last_login_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL
+);
+INSERT INTO users (username, email, password_hash, role, status) VALUES
+    ('janedoe', 'janedoe@example.com', 'secret-hash', 'admin', 'active'),
+    ('johndoe', 'johndoe@example.com', 'secret-hash', 'user', 'active');`
+
+func createAdminTestToken(t *testing.T, role string) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":  "1",
+		"role": role,
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(adminTestSecret))
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	return token
+}
+
+func TestAdminRoutes(t *testing.T) {
+	conf := viper.New()
+	conf.Set("security.jwt.key", adminTestSecret)
+	conf.Set("db.mysql.password", "db-password")
+	c := newSQLiteContainer(t, conf, usersSchema)
+
+	app := createTestApp()
+	v1 := app.Group("/api").Group("/v1")
+	RegisterAdminRoutes(v1, handler.NewHandler(c.GetLogger()), c)
+
+	testCases := []struct {
+		name           string
+		path           string
+		token          string
+		expectedStatus int
+		contains       []string
+		excludes       []string
+	}{
+		{
+			name:           "admin lists users",
+			path:           "/api/v1/admin/users",
+			token:          createAdminTestToken(t, "admin"),
+			expectedStatus: http.StatusOK,
+			contains:       []string{"janedoe", "johndoe", `"count":2`},
+			excludes:       []string{"secret-hash"},
+		},
+		{
+			name:           "admin reads system config",
+			path:           "/api/v1/admin/config",
+			token:          createAdminTestToken(t, "admin"),
+			expectedStatus: http.StatusOK,
+			excludes:       []string{"db-password", adminTestSecret},
+		},
+		{
+			name:           "non-admin is forbidden",
+			path:           "/api/v1/admin/users",
+			token:          createAdminTestToken(t, "user"),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "unauthenticated is rejected",
+			path:           "/api/v1/admin/users",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tc.path, nil)
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test %s: %v", tc.path, err)
+			}
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			body, _ := io.ReadAll(resp.Body)
+			for _, want := range tc.contains {
+				if !strings.Contains(string(body), want) {
+					t.Errorf("Expected body to contain %s, got: %s", want, string(body))
+				}
+			}
+			for _, unwanted := range tc.excludes {
+				if strings.Contains(string(body), unwanted) {
+					t.Errorf("Expected body not to contain %s", unwanted)
+				}
+			}
+		})
+	}
+}
+
+func TestAdminRoutesUseServerJWTSecret(t *testing.T) {
+	conf := viper.New()
+	conf.Set("security.jwt.key", "fallback-secret")
+	conf.Set("server.jwt.secret", adminTestSecret)
+	c := newSQLiteContainer(t, conf, usersSchema)
+
+	app := createTestApp()
+	RegisterAdminRoutes(app.Group("/api").Group("/v1"), handler.NewHandler(c.GetLogger()), c)
+
+	req := httptest.NewRequest("GET", "/api/v1/admin/users", nil)
+	req.Header.Set("Authorization", "Bearer "+createAdminTestToken(t, "admin"))
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test admin users: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status %d for a token signed with server.jwt.secret, got %d", http.StatusOK, resp.StatusCode)
+	}
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/MayukhSobo/scaffold/internal/handler"
+	"github.com/MayukhSobo/scaffold/pkg/container"
+)
+
+// RegisterAuthRoutesWithContainer sets up authentication routes using container
+func RegisterAuthRoutesWithContainer(router fiber.Router, baseHandler *handler.Handler, container *container.TypedContainer) {
+	// Create auth handler
+	authHandler := handler.NewAuthHandler(baseHandler, container.GetUserService())
+
+	// Auth routes group
+	auth := router.Group("/auth")
+
+	// Token routes
+	auth.Post("/refresh", authHandler.RefreshToken) // POST /api/v1/auth/refresh
+}
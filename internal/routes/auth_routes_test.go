@@ -0,0 +1,120 @@
+package routes
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/MayukhSobo/scaffold/internal/handler"
+)
+
+// refreshTokensSchema mirrors the refresh_tokens migration using SQLite syntax
+const refreshTokensSchema = `
+CREATE TABLE refresh_tokens (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);`
+
+const authTestSecret = "auth-test-secret"
+
+func hashTestRefreshToken(token string) string {
+	mac := hmac.New(sha256.New, []byte(authTestSecret))
+	mac.Write([]byte(token))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestAuthRefreshRoute(t *testing.T) {
+	conf := viper.New()
+	conf.Set("security.jwt.key", authTestSecret)
+	c := newSQLiteContainer(t, conf, usersSchema+refreshTokensSchema)
+
+	// Seed one valid and one expired refresh token for janedoe
+	_, err := c.GetDatabase().Exec(
+		"INSERT INTO refresh_tokens (user_id, token_hash, expires_at) VALUES (?, ?, ?), (?, ?, ?)",
+		1, hashTestRefreshToken("valid-token"), time.Now().Add(time.Hour),
+		1, hashTestRefreshToken("expired-token"), time.Now().Add(-time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("Failed to seed refresh tokens: %v", err)
+	}
+
+	app := createTestApp()
+	v1 := app.Group("/api").Group("/v1")
+	RegisterAuthRoutesWithContainer(v1, handler.NewHandler(c.GetLogger()), c)
+
+	refresh := func(token string) (int, map[string]interface{}) {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/api/v1/auth/refresh", strings.NewReader(`{"refresh_token":"`+token+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to test refresh route: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		var parsed struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		_ = json.Unmarshal(body, &parsed)
+		return resp.StatusCode, parsed.Data
+	}
+
+	// Valid token is rotated
+	status, data := refresh("valid-token")
+	if status != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", status)
+	}
+	newToken, _ := data["refresh_token"].(string)
+	if data["access_token"] == "" || newToken == "" || newToken == "valid-token" {
+		t.Errorf("Expected new access and refresh tokens, got %v", data)
+	}
+
+	testCases := []struct {
+		name  string
+		token string
+	}{
+		{"replayed token", "valid-token"},
+		{"token issued before replay", newToken},
+		{"expired token", "expired-token"},
+		{"unknown token", "unknown-token"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if status, _ := refresh(tc.token); status != http.StatusUnauthorized {
+				t.Errorf("Expected status 401, got %d", status)
+			}
+		})
+	}
+}
+
+func TestAuthRefreshRouteMissingToken(t *testing.T) {
+	c := newSQLiteContainer(t, viper.New(), usersSchema+refreshTokensSchema)
+
+	app := createTestApp()
+	v1 := app.Group("/api").Group("/v1")
+	RegisterAuthRoutesWithContainer(v1, handler.NewHandler(c.GetLogger()), c)
+
+	req := httptest.NewRequest("POST", "/api/v1/auth/refresh", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test refresh route: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/swagger"
+
+	apidocs "github.com/MayukhSobo/scaffold/api/swagger"
+)
+
+// SwaggerSpecPath is the path of the Swagger document rendered by the Swagger UI
+const SwaggerSpecPath = "/swagger/swagger.json"
+
+// RegisterDocsRoutes serves the Swagger UI on /swagger/* and, on SwaggerSpecPath, the document
+// `make docs` generates from the swag annotations into api/swagger.
+func RegisterDocsRoutes(router fiber.Router) {
+	router.Get(SwaggerSpecPath, func(c *fiber.Ctx) error {
+		c.Type("json")
+		return c.Send(apidocs.Spec)
+	})
+	router.Get("/swagger/*", swagger.New(swagger.Config{URL: SwaggerSpecPath}))
+}
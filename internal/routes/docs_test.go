@@ -0,0 +1,72 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestRegisterRoutesWithContainerDocs(t *testing.T) {
+	testCases := []struct {
+		name           string
+		enabled        bool
+		expectedStatus int
+	}{
+		{"docs enabled", true, http.StatusOK},
+		{"docs disabled", false, http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conf := viper.New()
+			conf.Set("app.name", "scaffold")
+			conf.Set("server.docs.enabled", tc.enabled)
+
+			app := createTestApp()
+			RegisterRoutesWithContainer(&ContainerRouteConfig{App: app, Container: newSQLiteContainer(t, conf, usersSchema)})
+
+			resp, err := app.Test(httptest.NewRequest("GET", SwaggerSpecPath, nil))
+			if err != nil {
+				t.Fatalf("Failed to test %s: %v", SwaggerSpecPath, err)
+			}
+			if resp.StatusCode != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+			if !tc.enabled {
+				return
+			}
+
+			var spec struct {
+				BasePath string                            `json:"basePath"`
+				Paths    map[string]map[string]interface{} `json:"paths"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&spec); err != nil {
+				t.Fatalf("Failed to parse spec: %v", err)
+			}
+			if spec.BasePath != "/api/v1" {
+				t.Errorf("Expected base path '/api/v1', got %q", spec.BasePath)
+			}
+			for _, path := range []string{"/users/admin", "/users/pending-verification"} {
+				if _, ok := spec.Paths[path]["get"]; !ok {
+					t.Errorf("Expected GET %s in spec, got %v", path, spec.Paths)
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterDocsRoutesServesUI(t *testing.T) {
+	app := createTestApp()
+	RegisterDocsRoutes(app)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/swagger/index.html", nil))
+	if err != nil {
+		t.Fatalf("Failed to test Swagger UI: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/spf13/viper"
+	_ "modernc.org/sqlite"
+
+	"github.com/MayukhSobo/scaffold/pkg/container"
+)
+
+// newSQLiteContainer creates a typed container backed by an in-memory SQLite database with the given schema
+func newSQLiteContainer(t *testing.T, conf *viper.Viper, schema string) *container.TypedContainer {
+	t.Helper()
+
+	database, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open SQLite database: %v", err)
+	}
+	database.SetMaxOpenConns(1) // Each connection gets its own in-memory database
+	t.Cleanup(func() { _ = database.Close() })
+
+	if _, err := database.Exec(schema); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+
+	c, err := container.NewTypedContainer(conf, createTestLogger(), database)
+	if err != nil {
+		t.Fatalf("Failed to create container: %v", err)
+	}
+	return c
+}
@@ -0,0 +1,142 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/spf13/viper"
+
+	"github.com/MayukhSobo/scaffold/internal/handler"
+)
+
+// productsSchema mirrors the products migration using SQLite syntax
+const productsSchema = `
+CREATE TABLE products (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(255) NOT NULL,
+    description VARCHAR(1000) NOT NULL DEFAULT '',
+    price_cents BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);`
+
+// setupProductTestApp wires a SQLite backed container and registers the product routes
+func setupProductTestApp(t *testing.T) *httptestApp {
+	t.Helper()
+
+	c := newSQLiteContainer(t, viper.New(), productsSchema)
+
+	app := createTestApp()
+	v1 := app.Group("/api").Group("/v1")
+	RegisterProductRoutesWithContainer(v1, handler.NewHandler(c.GetLogger()), c)
+
+	return &httptestApp{t: t, app: app}
+}
+
+type productResponse struct {
+	Code int `json:"code"`
+	Data struct {
+		Product struct {
+			ID         uint64 `json:"id"`
+			Name       string `json:"name"`
+			PriceCents int64  `json:"price_cents"`
+		} `json:"product"`
+		Products []struct {
+			ID   uint64 `json:"id"`
+			Name string `json:"name"`
+		} `json:"products"`
+		Count int `json:"count"`
+	} `json:"data"`
+}
+
+func TestProductRoutesWithContainer(t *testing.T) {
+	app := setupProductTestApp(t)
+
+	// Create a product
+	status, created := app.do("POST", "/api/v1/products", `{"name":"Widget","description":"A widget","price_cents":1999}`)
+	if status != http.StatusOK {
+		t.Fatalf("Expected status 200 creating product, got %d", status)
+	}
+	if created.Data.Product.ID == 0 {
+		t.Error("Expected created product to have an ID")
+	}
+	if created.Data.Product.Name != "Widget" {
+		t.Errorf("Expected product name 'Widget', got '%s'", created.Data.Product.Name)
+	}
+	if created.Data.Product.PriceCents != 1999 {
+		t.Errorf("Expected price_cents 1999, got %d", created.Data.Product.PriceCents)
+	}
+
+	// Fetch it back by ID
+	status, fetched := app.do("GET", "/api/v1/products/1", "")
+	if status != http.StatusOK {
+		t.Fatalf("Expected status 200 fetching product, got %d", status)
+	}
+	if fetched.Data.Product.Name != "Widget" {
+		t.Errorf("Expected product name 'Widget', got '%s'", fetched.Data.Product.Name)
+	}
+
+	// List all products
+	status, listed := app.do("GET", "/api/v1/products", "")
+	if status != http.StatusOK {
+		t.Fatalf("Expected status 200 listing products, got %d", status)
+	}
+	if listed.Data.Count != 1 || len(listed.Data.Products) != 1 {
+		t.Errorf("Expected 1 product, got count=%d len=%d", listed.Data.Count, len(listed.Data.Products))
+	}
+}
+
+func TestProductRoutesErrors(t *testing.T) {
+	app := setupProductTestApp(t)
+
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		{"missing product", "GET", "/api/v1/products/42", "", http.StatusNotFound},
+		{"invalid id", "GET", "/api/v1/products/abc", "", http.StatusBadRequest},
+		{"missing name", "POST", "/api/v1/products", `{"price_cents":100}`, http.StatusBadRequest},
+		{"malformed body", "POST", "/api/v1/products", `{`, http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, _ := app.do(tc.method, tc.path, tc.body)
+			if status != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, status)
+			}
+		})
+	}
+}
+
+// httptestApp is a small helper around fiber's app.Test for JSON requests
+type httptestApp struct {
+	t   *testing.T
+	app *fiber.App
+}
+
+func (a *httptestApp) do(method, path, body string) (int, productResponse) {
+	a.t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := a.app.Test(req)
+	if err != nil {
+		a.t.Fatalf("Failed to test %s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	var decoded productResponse
+	_ = json.NewDecoder(resp.Body).Decode(&decoded)
+	return resp.StatusCode, decoded
+}
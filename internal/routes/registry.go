@@ -0,0 +1,282 @@
+package routes
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RouteMetadata describes a route for API documentation
+type RouteMetadata struct {
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	Tags        []string
+	Request     reflect.Type // Request body type, nil when the route takes no body
+	Response    reflect.Type // Type of the "data" field of a successful response
+}
+
+// Registry stores the metadata of registered routes
+type Registry struct {
+	mu     sync.RWMutex
+	routes map[string]RouteMetadata
+}
+
+// DefaultRegistry holds the routes registered with RegisterRoute
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry creates an empty route registry
+func NewRegistry() *Registry {
+	return &Registry{routes: make(map[string]RouteMetadata)}
+}
+
+// Add stores the route metadata, replacing any previous entry for the same method and path
+func (r *Registry) Add(meta RouteMetadata) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes[meta.Method+" "+meta.Path] = meta
+}
+
+// Routes returns the registered routes sorted by path and method
+func (r *Registry) Routes() []RouteMetadata {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	routes := make([]RouteMetadata, 0, len(r.routes))
+	for _, meta := range r.routes {
+		routes = append(routes, meta)
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+// RegisterRoute registers the handler on the router and records its metadata in DefaultRegistry.
+// meta.Path is relative to the router; the registry stores the full path.
+func RegisterRoute(router fiber.Router, meta RouteMetadata, handler fiber.Handler) fiber.Router {
+	route := router.Add(meta.Method, meta.Path, handler)
+
+	fullPath := meta.Path
+	if group, ok := router.(*fiber.Group); ok {
+		fullPath = group.Prefix + meta.Path
+	}
+	if len(fullPath) > 1 {
+		fullPath = strings.TrimSuffix(fullPath, "/")
+	}
+	meta.Path = fullPath
+
+	DefaultRegistry.Add(meta)
+	return route
+}
+
+// SpecInfo holds the info section of the generated OpenAPI spec
+type SpecInfo struct {
+	Title       string
+	Version     string
+	Description string
+}
+
+// ToOpenAPISpec builds an OpenAPI 3.0.3 document from the registered routes
+func ToOpenAPISpec(reg *Registry, info SpecInfo) ([]byte, error) {
+	paths := make(map[string]map[string]interface{})
+
+	for _, meta := range reg.Routes() {
+		path, params := openAPIPath(meta.Path)
+
+		operation := map[string]interface{}{
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{
+					"description": "Successful response",
+					"content":     jsonContent(envelopeSchema(meta.Response)),
+				},
+				"default": map[string]interface{}{
+					"description": "Error response",
+					"content":     jsonContent(envelopeSchema(nil)),
+				},
+			},
+		}
+		if meta.Summary != "" {
+			operation["summary"] = meta.Summary
+		}
+		if meta.Description != "" {
+			operation["description"] = meta.Description
+		}
+		if len(meta.Tags) > 0 {
+			operation["tags"] = meta.Tags
+		}
+		if len(params) > 0 {
+			parameters := make([]interface{}, len(params))
+			for i, name := range params {
+				parameters[i] = map[string]interface{}{
+					"name":     name,
+					"in":       "path",
+					"required": true,
+					"schema":   map[string]interface{}{"type": "string"},
+				}
+			}
+			operation["parameters"] = parameters
+		}
+		if meta.Request != nil {
+			operation["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content":  jsonContent(typeSchema(meta.Request, map[reflect.Type]bool{})),
+			}
+		}
+
+		if paths[path] == nil {
+			paths[path] = make(map[string]interface{})
+		}
+		paths[path][strings.ToLower(meta.Method)] = operation
+	}
+
+	specInfo := map[string]interface{}{
+		"title":   info.Title,
+		"version": info.Version,
+	}
+	if info.Description != "" {
+		specInfo["description"] = info.Description
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"openapi": "3.0.3",
+		"info":    specInfo,
+		"paths":   paths,
+	}, "", "  ")
+}
+
+// openAPIPath converts Fiber path parameters (":id") to OpenAPI templates ("{id}")
+func openAPIPath(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	var params []string
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			name := strings.TrimSuffix(strings.TrimPrefix(segment, ":"), "?")
+			segments[i] = "{" + name + "}"
+			params = append(params, name)
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// jsonContent wraps a schema in an application/json media type
+func jsonContent(schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		fiber.MIMEApplicationJSON: map[string]interface{}{"schema": schema},
+	}
+}
+
+// envelopeSchema describes the standard {code, message, data} response body
+func envelopeSchema(data reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{
+		"code":    map[string]interface{}{"type": "integer"},
+		"message": map[string]interface{}{"type": "string"},
+	}
+	if data != nil {
+		properties["data"] = typeSchema(data, map[reflect.Type]bool{})
+	}
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+}
+
+// Well-known types with a fixed schema
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	nullTimeType   = reflect.TypeOf(sql.NullTime{})
+	nullStringType = reflect.TypeOf(sql.NullString{})
+	nullInt64Type  = reflect.TypeOf(sql.NullInt64{})
+	nullBoolType   = reflect.TypeOf(sql.NullBool{})
+)
+
+// typeSchema derives a JSON schema from a Go type using its json tags
+func typeSchema(t reflect.Type, seen map[reflect.Type]bool) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case nullTimeType:
+		return map[string]interface{}{"type": "string", "format": "date-time", "nullable": true}
+	case nullStringType:
+		return map[string]interface{}{"type": "string", "nullable": true}
+	case nullInt64Type:
+		return map[string]interface{}{"type": "integer", "format": "int64", "nullable": true}
+	case nullBoolType:
+		return map[string]interface{}{"type": "boolean", "nullable": true}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Int64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	case reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64", "minimum": 0}
+	case reflect.Float32:
+		return map[string]interface{}{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return map[string]interface{}{"type": "number", "format": "double"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem(), seen)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": typeSchema(t.Elem(), seen)}
+	case reflect.Struct:
+		if seen[t] {
+			return map[string]interface{}{"type": "object"}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+
+		properties := make(map[string]interface{})
+		addStructProperties(t, properties, seen)
+		return map[string]interface{}{"type": "object", "properties": properties}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// addStructProperties adds the exported fields of t to properties, flattening embedded structs
+func addStructProperties(t reflect.Type, properties map[string]interface{}, seen map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			addStructProperties(field.Type, properties, seen)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = typeSchema(field.Type, seen)
+	}
+}
@@ -0,0 +1,132 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/MayukhSobo/scaffold/internal/handler"
+)
+
+type openAPIDoc struct {
+	OpenAPI string `json:"openapi"`
+	Info    struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+	Paths map[string]map[string]struct {
+		Summary    string   `json:"summary"`
+		Tags       []string `json:"tags"`
+		Parameters []struct {
+			Name string `json:"name"`
+			In   string `json:"in"`
+		} `json:"parameters"`
+		RequestBody map[string]interface{} `json:"requestBody"`
+		Responses   map[string]struct {
+			Content map[string]struct {
+				Schema map[string]interface{} `json:"schema"`
+			} `json:"content"`
+		} `json:"responses"`
+	} `json:"paths"`
+}
+
+func TestUserRoutesOpenAPISpec(t *testing.T) {
+	app := createTestApp()
+	v1 := app.Group("/api").Group("/v1")
+	RegisterUserRoutes(v1, handler.NewHandler(createTestLogger()), &mockUserService{})
+
+	data, err := ToOpenAPISpec(DefaultRegistry, SpecInfo{Title: "Scaffold API", Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var doc openAPIDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Spec is not valid JSON: %v", err)
+	}
+
+	if doc.OpenAPI != "3.0.3" {
+		t.Errorf("Expected openapi 3.0.3, got %s", doc.OpenAPI)
+	}
+	if doc.Info.Title != "Scaffold API" || doc.Info.Version != "1.0.0" {
+		t.Errorf("Unexpected info section: %+v", doc.Info)
+	}
+
+	for _, path := range []string{"/api/v1/users/admin", "/api/v1/users/pending-verification"} {
+		operation, ok := doc.Paths[path]["get"]
+		if !ok {
+			t.Errorf("Expected spec to contain GET %s", path)
+			continue
+		}
+		if operation.Summary == "" || !reflect.DeepEqual(operation.Tags, []string{"users"}) {
+			t.Errorf("Expected summary and users tag for %s, got %+v", path, operation)
+		}
+
+		schema := operation.Responses["200"].Content["application/json"].Schema
+		properties, _ := schema["properties"].(map[string]interface{})
+		dataSchema, _ := properties["data"].(map[string]interface{})
+		dataProperties, _ := dataSchema["properties"].(map[string]interface{})
+		if _, ok := dataProperties["users"]; !ok {
+			t.Errorf("Expected %s response schema to describe users, got %v", path, schema)
+		}
+	}
+
+	// Routes registered through RegisterRoute are still served
+	req := httptest.NewRequest("GET", "/api/v1/users/admin", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test registered route: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestToOpenAPISpecParametersAndRequestBody(t *testing.T) {
+	type createItemRequest struct {
+		Name     string   `json:"name"`
+		Quantity int      `json:"quantity,omitempty"`
+		Labels   []string `json:"labels"`
+		Internal string   `json:"-"`
+	}
+
+	reg := NewRegistry()
+	reg.Add(RouteMetadata{Method: fiber.MethodGet, Path: "/items/:id", Summary: "Get item"})
+	reg.Add(RouteMetadata{Method: fiber.MethodPost, Path: "/items", Request: reflect.TypeOf(createItemRequest{})})
+
+	data, err := ToOpenAPISpec(reg, SpecInfo{Title: "Items", Version: "0.1.0"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var doc openAPIDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Spec is not valid JSON: %v", err)
+	}
+
+	getItem, ok := doc.Paths["/items/{id}"]["get"]
+	if !ok {
+		t.Fatalf("Expected path parameter to be converted, got paths %v", doc.Paths)
+	}
+	if len(getItem.Parameters) != 1 || getItem.Parameters[0].Name != "id" || getItem.Parameters[0].In != "path" {
+		t.Errorf("Expected id path parameter, got %+v", getItem.Parameters)
+	}
+
+	createItem := doc.Paths["/items"]["post"]
+	content, _ := createItem.RequestBody["content"].(map[string]interface{})
+	media, _ := content["application/json"].(map[string]interface{})
+	schema, _ := media["schema"].(map[string]interface{})
+	properties, _ := schema["properties"].(map[string]interface{})
+	for _, field := range []string{"name", "quantity", "labels"} {
+		if _, ok := properties[field]; !ok {
+			t.Errorf("Expected request schema to contain %s, got %v", field, properties)
+		}
+	}
+	if _, ok := properties["Internal"]; ok {
+		t.Error("Fields tagged json:\"-\" should be omitted")
+	}
+}
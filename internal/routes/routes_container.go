@@ -27,8 +27,15 @@ func RegisterRoutesWithContainer(crc *ContainerRouteConfig) {
 
 	// Register domain-specific routes
 	RegisterUserRoutesWithContainer(v1, baseHandler, crc.Container)
+	RegisterProductRoutesWithContainer(v1, baseHandler, crc.Container)
+	RegisterAdminRoutes(v1, baseHandler, crc.Container)
+	RegisterAuthRoutesWithContainer(v1, baseHandler, crc.Container)
+
+	// API documentation, off unless enabled since it lists every route
+	if crc.Container.GetConfig().GetBool("server.docs.enabled") {
+		RegisterDocsRoutes(crc.App)
+	}
 	// Future route registrations - no modification needed to existing routes
-	// RegisterProductRoutesWithContainer(v1, baseHandler, crc.Container)
 	// RegisterOrderRoutesWithContainer(v1, baseHandler, crc.Container)
 	// RegisterPaymentRoutesWithContainer(v1, baseHandler, crc.Container)
 }
@@ -43,40 +50,36 @@ func RegisterUserRoutesWithContainer(router fiber.Router, baseHandler *handler.H
 
 	// User routes group
 	users := router.Group("/users")
-
-	// Admin-specific user routes
-	users.Get("/admin", userHandler.GetAdminUsers) // GET /api/v1/users/admin
-
-	// Verification-specific user routes
-	users.Get("/pending-verification", userHandler.GetPendingVerificationUsers) // GET /api/v1/users/pending-verification
+	registerUserGroupRoutes(users, userHandler)
 
 	// Future user routes can be added here without affecting other modules
-	// users.Get("/:id", userHandler.GetUserById)
 	// users.Post("/", userHandler.CreateUser)
 	// users.Put("/:id", userHandler.UpdateUser)
 	// users.Delete("/:id", userHandler.DeleteUser)
 }
 
-// Example template for future route modules
 // RegisterProductRoutesWithContainer sets up product-related routes using container
-// func RegisterProductRoutesWithContainer(router fiber.Router, baseHandler *handler.Handler, container *container.TypedContainer) {
-//     // Get the product service from container
-//     productService := container.GetProductService()
-//
-//     // Create product handler
-//     productHandler := handler.NewProductHandler(baseHandler, productService)
-//
-//     // Product routes group
-//     products := router.Group("/products")
-//
-//     // Product-specific routes
-//     products.Get("/", productHandler.GetAllProducts)
-//     products.Get("/:id", productHandler.GetProductById)
-//     products.Post("/", productHandler.CreateProduct)
-//     products.Put("/:id", productHandler.UpdateProduct)
-//     products.Delete("/:id", productHandler.DeleteProduct)
-// }
+func RegisterProductRoutesWithContainer(router fiber.Router, baseHandler *handler.Handler, container *container.TypedContainer) {
+	// Get the product service from container
+	productService := container.GetProductService()
+
+	// Create product handler
+	productHandler := handler.NewProductHandler(baseHandler, productService)
+
+	// Product routes group
+	products := router.Group("/products")
+
+	// Product-specific routes
+	products.Get("/", productHandler.GetAllProducts)    // GET /api/v1/products
+	products.Get("/:id", productHandler.GetProductById) // GET /api/v1/products/:id
+	products.Post("/", productHandler.CreateProduct)    // POST /api/v1/products
 
+	// Future product routes can be added here without affecting other modules
+	// products.Put("/:id", productHandler.UpdateProduct)
+	// products.Delete("/:id", productHandler.DeleteProduct)
+}
+
+// Example template for future route modules
 // RegisterOrderRoutesWithContainer sets up order-related routes using container
 // func RegisterOrderRoutesWithContainer(router fiber.Router, baseHandler *handler.Handler, container *container.TypedContainer) {
 //     // Get multiple services from container if needed
@@ -110,8 +113,10 @@ func RegisterAllRoutesWithContainer(crc *ContainerRouteConfig) {
 
 	// Register all domain routes - each is independent and scalable
 	RegisterUserRoutesWithContainer(v1, baseHandler, crc.Container)
+	RegisterProductRoutesWithContainer(v1, baseHandler, crc.Container)
+	RegisterAdminRoutes(v1, baseHandler, crc.Container)
+	RegisterAuthRoutesWithContainer(v1, baseHandler, crc.Container)
 	// Uncomment as you implement these modules:
-	// RegisterProductRoutesWithContainer(v1, baseHandler, crc.Container)
 	// RegisterOrderRoutesWithContainer(v1, baseHandler, crc.Container)
 	// RegisterPaymentRoutesWithContainer(v1, baseHandler, crc.Container)
 	// RegisterNotificationRoutesWithContainer(v1, baseHandler, crc.Container)
@@ -1,6 +1,8 @@
 package routes
 
 import (
+	"reflect"
+
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/MayukhSobo/scaffold/internal/handler"
@@ -14,10 +16,46 @@ func RegisterUserRoutes(router fiber.Router, baseHandler *handler.Handler, userS
 
 	// User routes group
 	users := router.Group("/users")
+	registerUserGroupRoutes(users, userHandler)
+}
+
+// registerUserGroupRoutes registers the user routes and their documentation metadata on the /users group
+func registerUserGroupRoutes(users fiber.Router, userHandler *handler.UserHandler) {
+	userList := reflect.TypeOf(handler.UserListResponse{})
 
 	// Admin-specific user routes
-	users.Get("/admin", userHandler.GetAdminUsers) // GET /api/v1/users/admin
+	RegisterRoute(users, RouteMetadata{
+		Method:   fiber.MethodGet,
+		Path:     "/admin",
+		Summary:  "List admin users",
+		Tags:     []string{"users"},
+		Response: userList,
+	}, userHandler.GetAdminUsers) // GET /api/v1/users/admin
 
 	// Verification-specific user routes
-	users.Get("/pending-verification", userHandler.GetPendingVerificationUsers) // GET /api/v1/users/pending-verification
+	RegisterRoute(users, RouteMetadata{
+		Method:   fiber.MethodGet,
+		Path:     "/pending-verification",
+		Summary:  "List users pending verification",
+		Tags:     []string{"users"},
+		Response: userList,
+	}, userHandler.GetPendingVerificationUsers) // GET /api/v1/users/pending-verification
+
+	RegisterRoute(users, RouteMetadata{
+		Method:   fiber.MethodPost,
+		Path:     "/",
+		Summary:  "Create a user",
+		Tags:     []string{"users"},
+		Request:  reflect.TypeOf(service.CreateUserRequest{}),
+		Response: reflect.TypeOf(handler.UserDetailResponse{}),
+	}, userHandler.CreateUser) // POST /api/v1/users
+
+	// Registered after the fixed paths so they are not captured as IDs
+	RegisterRoute(users, RouteMetadata{
+		Method:   fiber.MethodGet,
+		Path:     "/:id",
+		Summary:  "Get a user by ID",
+		Tags:     []string{"users"},
+		Response: reflect.TypeOf(handler.UserDetailResponse{}),
+	}, userHandler.GetUserById) // GET /api/v1/users/:id
 }
@@ -5,12 +5,14 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/MayukhSobo/scaffold/internal/handler"
 	"github.com/MayukhSobo/scaffold/internal/repository/users"
+	"github.com/MayukhSobo/scaffold/internal/service"
 	"github.com/MayukhSobo/scaffold/pkg/log"
 )
 
@@ -18,6 +20,9 @@ import (
 type mockUserService struct{}
 
 func (m *mockUserService) GetUserById(ctx context.Context, id int64) (users.User, error) {
+	if id == 999 {
+		return users.User{}, service.NewServiceError(service.ErrNotFound, "user not found", "USER_NOT_FOUND")
+	}
 	return users.User{
 		ID:       uint64(id),
 		Username: "testuser",
@@ -25,6 +30,13 @@ func (m *mockUserService) GetUserById(ctx context.Context, id int64) (users.User
 	}, nil
 }
 
+func (m *mockUserService) GetUsers(ctx context.Context) ([]users.User, error) {
+	return []users.User{
+		{ID: 1, Username: "admin", Email: "admin@example.com"},
+		{ID: 3, Username: "user1", Email: "user1@example.com"},
+	}, nil
+}
+
 func (m *mockUserService) GetAdminUsers(ctx context.Context) ([]users.User, error) {
 	return []users.User{
 		{
@@ -59,6 +71,19 @@ func (m *mockUserService) GetPendingVerificationUsers(ctx context.Context) ([]us
 	}, nil
 }
 
+func (m *mockUserService) CreateUser(ctx context.Context, req service.CreateUserRequest) (users.User, error) {
+	return users.User{
+		ID:           10,
+		Username:     req.Username,
+		Email:        req.Email,
+		PasswordHash: "hashed",
+	}, nil
+}
+
+func (m *mockUserService) RefreshAccessToken(ctx context.Context, refreshToken string) (string, string, error) {
+	return "", "", nil
+}
+
 func createTestApp() *fiber.App {
 	return fiber.New()
 }
@@ -99,6 +124,66 @@ func TestGetAdminUsersRoute(t *testing.T) {
 	}
 }
 
+func TestGetUserByIdRoute(t *testing.T) {
+	testCases := []struct {
+		name           string
+		path           string
+		expectedStatus int
+	}{
+		{name: "found", path: "/api/v1/users/1", expectedStatus: http.StatusOK},
+		{name: "not found", path: "/api/v1/users/999", expectedStatus: http.StatusNotFound},
+		{name: "invalid ID", path: "/api/v1/users/abc", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := createTestApp()
+			v1 := app.Group("/api").Group("/v1")
+			RegisterUserRoutes(v1, handler.NewHandler(createTestLogger()), &mockUserService{})
+
+			resp, err := app.Test(httptest.NewRequest("GET", tc.path, nil))
+			if err != nil {
+				t.Fatalf("Failed to test user route: %v", err)
+			}
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestCreateUserRoute(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "valid", body: `{"username":"newuser","email":"new@example.com","password":"secret"}`, expectedStatus: http.StatusOK},
+		{name: "missing password", body: `{"username":"newuser","email":"new@example.com"}`, expectedStatus: http.StatusBadRequest},
+		{name: "invalid JSON", body: `{`, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := createTestApp()
+			v1 := app.Group("/api").Group("/v1")
+			RegisterUserRoutes(v1, handler.NewHandler(createTestLogger()), &mockUserService{})
+
+			req := httptest.NewRequest("POST", "/api/v1/users", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test create user route: %v", err)
+			}
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
 func TestGetPendingVerificationUsersRoute(t *testing.T) {
 	// Create test app
 	app := createTestApp()
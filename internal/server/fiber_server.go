@@ -1,38 +1,117 @@
 package server
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
+	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel"
 
+	"github.com/MayukhSobo/scaffold/internal/middleware"
 	"github.com/MayukhSobo/scaffold/internal/routes"
 	"github.com/MayukhSobo/scaffold/internal/service"
+	"github.com/MayukhSobo/scaffold/pkg/build"
+	"github.com/MayukhSobo/scaffold/pkg/config"
 	"github.com/MayukhSobo/scaffold/pkg/container"
+	"github.com/MayukhSobo/scaffold/pkg/db"
+	"github.com/MayukhSobo/scaffold/pkg/health"
 	"github.com/MayukhSobo/scaffold/pkg/log"
+	"github.com/MayukhSobo/scaffold/pkg/utils"
 )
 
-// FiberServer wraps the Fiber app with configuration
+// defaultMaxRequestSize is the request body limit used when server.max_request_size is unset
+const defaultMaxRequestSize = "4MB"
+
+// tracerName is the instrumentation name of the request spans
+const tracerName = "github.com/MayukhSobo/scaffold/internal/server"
+
+// healthCheckTimeout bounds how long /healthz and /readyz wait for component checks
+const healthCheckTimeout = 2 * time.Second
+
+// defaultReadinessDelay is how long /readyz reports not ready after startup when server.readiness_delay is unset
+const defaultReadinessDelay = 5 * time.Second
+
+// requiredConfigKeys must be set for /readyz to report ready
+var requiredConfigKeys = []string{"app.name", "http.port"}
+
+// Rate limit defaults when server.rate_limit.max or server.rate_limit.expiration is unset
+const (
+	defaultRateLimitMax        = 100
+	defaultRateLimitExpiration = time.Minute
+)
+
+// FiberServer wraps the Fiber app with configuration.
+// Routes are registered when Build (or GetApp) is called, so middleware added before that runs for every route.
 type FiberServer struct {
-	app    *fiber.App
-	config *viper.Viper
-	logger log.Logger
+	app       *fiber.App
+	config    *viper.Viper
+	logger    log.Logger
+	container *container.TypedContainer
+	db        *sql.DB              // reported by /healthz and /readyz, see WithDatabase
+	breaker   *db.CircuitBreakerDB // state reported by /healthz, see WithCircuitBreaker
+	metrics   *prometheus.Registry // served on /metrics when server.metrics.enabled is set
+	startedAt time.Time            // /readyz waits server.readiness_delay from here
+	readiness *health.Registry     // checks run by /readyz, see ReadinessChecks
+
+	routeSetups []func()
+	built       bool
 }
 
 // NewFiberServer creates a new Fiber server with the given configuration
 func NewFiberServer(config *viper.Viper, logger log.Logger) *FiberServer {
+	return newFiberServer(config, logger, health.NewRegistry(healthCheckTimeout, logger))
+}
+
+// newFiberServer creates the server, registering its own readiness checks in readiness
+func newFiberServer(config *viper.Viper, logger log.Logger, readiness *health.Registry) *FiberServer {
+	// Request body size limit
+	maxRequestSize := config.GetString("server.max_request_size")
+	if maxRequestSize == "" {
+		maxRequestSize = defaultMaxRequestSize
+	}
+	bodyLimit, err := utils.ParseByteSize(maxRequestSize)
+	if err != nil {
+		logger.Warn("Invalid server.max_request_size, using default", log.Error(err), log.String("default", defaultMaxRequestSize))
+		bodyLimit, _ = utils.ParseByteSize(defaultMaxRequestSize)
+	}
+	if maxBytes := bodyLimitMaxBytes(config); maxBytes > 0 {
+		bodyLimit = maxBytes
+	}
+
 	// Create Fiber app with config
 	app := fiber.New(fiber.Config{
 		AppName:      config.GetString("app.name"),
 		ServerHeader: config.GetString("app.name") + " " + config.GetString("app.version"),
+		BodyLimit:    bodyLimit,
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			// Log the error
-			logger.Error("Server error", log.Error(err), log.String("path", c.Path()))
+			// Already logged and written by a middleware such as the integrity middleware
+			var rendered *middleware.RenderedError
+			if errors.As(err, &rendered) {
+				return nil
+			}
+
+			// Log the error, oversized bodies are a client problem and include the client IP
+			if e, ok := err.(*fiber.Error); ok && e.Code == fiber.StatusRequestEntityTooLarge {
+				logger.Warn("Request body too large", log.String("ip", c.IP()), log.String("path", c.Path()))
+			} else {
+				logger.Error("Server error", log.Err(err), log.String("path", c.Path()))
+			}
 
 			// Handle Fiber errors
 			if e, ok := err.(*fiber.Error); ok {
@@ -53,20 +132,60 @@ func NewFiberServer(config *viper.Viper, logger log.Logger) *FiberServer {
 	})
 
 	server := &FiberServer{
-		app:    app,
-		config: config,
-		logger: logger,
+		app:       app,
+		config:    config,
+		logger:    logger,
+		startedAt: time.Now(),
+		readiness: readiness,
 	}
+	server.readiness.Register("config", server.checkRequiredConfig)
+	server.readiness.Register("startup", server.checkReadinessDelay)
 
-	// Setup middleware
+	// Setup middleware, routes are registered by Build
 	server.setupMiddleware()
 
-	// Setup routes
-	server.setupRoutes()
+	return server
+}
 
+// NewFiberServerFromContainer creates a new Fiber server using the config and logger held by the container.
+// /readyz runs the container's health checks, which the server's own checks are added to.
+func NewFiberServerFromContainer(container *container.TypedContainer) *FiberServer {
+	server := newFiberServer(container.GetConfig(), container.GetLogger(), container.HealthChecks())
+	server.container = container
+	if db := container.GetDatabase(); db != nil {
+		server.WithDatabase(db)
+	}
+	if breaker := container.GetCircuitBreaker(); breaker != nil {
+		server.WithCircuitBreaker(breaker)
+	}
 	return server
 }
 
+// WithDatabase makes /readyz ping db and /healthz report its connection pool stats
+func (s *FiberServer) WithDatabase(db *sql.DB) *FiberServer {
+	s.db = db
+	s.readiness.Register("database", health.NewDatabaseCheck(db))
+	return s
+}
+
+// ReadinessChecks returns the registry run by /readyz, the container's HealthChecks when created from one.
+// Checks can be registered at any time.
+func (s *FiberServer) ReadinessChecks() *health.Registry {
+	return s.readiness
+}
+
+// WithCircuitBreaker makes /healthz report the state of the database circuit breaker, and /readyz fail while it is open
+func (s *FiberServer) WithCircuitBreaker(breaker *db.CircuitBreakerDB) *FiberServer {
+	s.breaker = breaker
+	s.readiness.Register("database_circuit", func(context.Context) error {
+		if breaker.State() == db.CircuitOpen {
+			return db.ErrCircuitOpen
+		}
+		return nil
+	})
+	return s
+}
+
 // setupMiddleware configures all middleware
 func (s *FiberServer) setupMiddleware() {
 	// Recovery middleware
@@ -79,11 +198,42 @@ func (s *FiberServer) setupMiddleware() {
 		s.app.Use(requestid.New())
 	}
 
+	// Correlation ID middleware, forwarded to downstream services
+	if s.config.GetBool("server.middleware.correlation_id") {
+		s.app.Use(middleware.NewCorrelationIDMiddleware())
+	}
+
+	// OpenTelemetry request spans, exported through the global tracer provider set with otel.SetTracerProvider
+	if s.config.GetBool("server.middleware.otel") {
+		s.app.Use(middleware.OTelMiddleware(otel.Tracer(tracerName)))
+	}
+
+	// Prometheus request metrics, served on /metrics
+	if s.config.GetBool("server.metrics.enabled") {
+		s.metrics = prometheus.NewRegistry()
+		s.metrics.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+		s.app.Use(middleware.PrometheusMiddleware(s.metrics))
+	}
+
 	// Custom logger middleware using our structured logger
 	if s.config.GetBool("server.middleware.logger") {
 		s.app.Use(s.createLoggerMiddleware())
 	}
 
+	// Request and response bodies at debug level, truncated to the body limit and with credentials redacted
+	if s.config.GetBool("server.middleware.body_logger") {
+		redactKeys := s.config.GetStringSlice("server.body_logger.redact_keys")
+		if !s.config.IsSet("server.body_logger.redact_keys") {
+			redactKeys = config.DefaultRedactKeys
+		}
+		s.app.Use(middleware.NewBodyLoggerMiddleware(s.logger, s.config.GetInt("server.body_limit.max_bytes"), redactKeys))
+	}
+
+	// Sliding-window rate limiting per client IP
+	if s.config.GetBool("server.rate_limit.enabled") {
+		s.app.Use(s.createRateLimiterMiddleware())
+	}
+
 	// CORS middleware
 	if s.config.GetBool("server.middleware.cors") {
 		s.app.Use(cors.New(cors.Config{
@@ -94,6 +244,90 @@ func (s *FiberServer) setupMiddleware() {
 			MaxAge:           s.config.GetInt("server.cors.max_age"),
 		}))
 	}
+
+	// CSRF protection with the double-submit cookie pattern for state-changing requests
+	if s.config.GetBool("server.middleware.csrf") {
+		s.app.Use(middleware.CSRFMiddleware(
+			middleware.CSRFHeader(s.config.GetString("server.csrf.header")),
+			middleware.CSRFExemptPaths(s.config.GetStringSlice("server.csrf.exempt_paths")...),
+		))
+	}
+
+	// JWT authentication for every route except the excluded public ones, after CORS so preflight requests pass
+	if s.config.GetBool("server.middleware.jwt") {
+		secret := service.JWTSecret(s.config)
+		if secret == "" {
+			s.logger.Error("JWT middleware enabled without server.jwt.secret, all authenticated requests will be rejected")
+		}
+		excluded := s.config.GetStringSlice("server.jwt.exclude_paths")
+		if !s.config.IsSet("server.jwt.exclude_paths") {
+			excluded = []string{"/health", "/healthz", "/readyz", "/version", "/ping"}
+		}
+		s.app.Use(middleware.JWTMiddleware([]byte(secret), s.logger, middleware.ExcludePaths(excluded...)))
+	}
+
+	// Request body size limit, with a warning for the offending client
+	if maxBytes := bodyLimitMaxBytes(s.config); maxBytes > 0 {
+		s.app.Use(middleware.NewBodyLimitMiddleware(maxBytes))
+	}
+
+	// Content-Type enforcement for POST/PUT/PATCH requests
+	if s.config.GetBool("server.middleware.content_type") {
+		required := s.config.GetString("server.content_type.required")
+		if required == "" {
+			required = fiber.MIMEApplicationJSON
+		}
+		s.app.Use(middleware.NewContentTypeMiddleware(required))
+	}
+
+	// Request/response body hashing for integrity verification
+	if s.config.GetBool("server.middleware.integrity.enabled") {
+		integrity, err := middleware.NewIntegrityMiddleware(s.config.GetString("server.middleware.integrity.algorithm"))
+		if err != nil {
+			s.logger.Error("Invalid server.middleware.integrity.algorithm, integrity middleware disabled", log.Err(err))
+		} else {
+			s.app.Use(integrity)
+		}
+	}
+
+	// Response size limit middleware (bytes, disabled when unset or zero)
+	if maxResponseSize := s.config.GetInt("server.max_response_size"); maxResponseSize > 0 {
+		s.app.Use(middleware.NewResponseSizeLimitMiddleware(maxResponseSize))
+	}
+}
+
+// bodyLimitMaxBytes returns server.body_limit.max_bytes when server.middleware.body_limit is on, 0 otherwise
+func bodyLimitMaxBytes(config *viper.Viper) int {
+	if !config.GetBool("server.middleware.body_limit") {
+		return 0
+	}
+	return config.GetInt("server.body_limit.max_bytes")
+}
+
+// createRateLimiterMiddleware allows server.rate_limit.max requests per client IP within server.rate_limit.expiration
+func (s *FiberServer) createRateLimiterMiddleware() fiber.Handler {
+	max := s.config.GetInt("server.rate_limit.max")
+	if max <= 0 {
+		max = defaultRateLimitMax
+	}
+	expiration := s.config.GetDuration("server.rate_limit.expiration")
+	if expiration <= 0 {
+		expiration = defaultRateLimitExpiration
+	}
+
+	return limiter.New(limiter.Config{
+		Max:               max,
+		Expiration:        expiration,
+		LimiterMiddleware: limiter.SlidingWindow{},
+		LimitReached: func(c *fiber.Ctx) error {
+			// Same body as the ErrorHandler
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error":   true,
+				"message": "Too many requests",
+				"code":    fiber.StatusTooManyRequests,
+			})
+		},
+	})
 }
 
 // createLoggerMiddleware creates a custom logger middleware using our structured logger
@@ -145,6 +379,11 @@ func (s *FiberServer) createLoggerMiddleware() fiber.Handler {
 			fields = append(fields, log.String("request_id", rid.(string)))
 		}
 
+		// Errors returned by handlers are rendered by the ErrorHandler after this middleware runs
+		if err != nil {
+			fields = append(fields, log.Err(err))
+		}
+
 		// Log based on status code
 		status := c.Response().StatusCode()
 		switch {
@@ -188,14 +427,90 @@ func (s *FiberServer) formatBytes(bytes int) string {
 	return fmt.Sprintf("%.1fGB", float64(bytes)/(1024*1024*1024))
 }
 
+// healthHandler is the liveness probe: it reports that the process is serving requests, with the database pool
+// stats and circuit state when attached, without contacting any dependency. A database outage must not get every
+// pod restarted, so dependencies are only checked by /readyz.
+func (s *FiberServer) healthHandler(c *fiber.Ctx) error {
+	response := fiber.Map{
+		"status": "healthy",
+		"env":    s.config.GetString("env"),
+		"uptime": time.Since(s.startedAt).Round(time.Second).String(),
+	}
+
+	if s.db != nil {
+		stats := s.db.Stats()
+		response["database_pool"] = fiber.Map{
+			"open_connections": stats.OpenConnections,
+			"in_use":           stats.InUse,
+			"idle":             stats.Idle,
+			"wait_count":       stats.WaitCount,
+			"wait_duration":    stats.WaitDuration.String(),
+		}
+	}
+
+	if s.breaker != nil {
+		response["database_circuit"] = s.breaker.State()
+	}
+
+	return c.JSON(response)
+}
+
+// readyHandler reports whether the server should receive traffic by running the readiness checks:
+// the database answers a ping, the required config keys are set, server.readiness_delay has passed
+// since startup, and any check added with ReadinessChecks, such as the container's dependency checks.
+// The response is 503 when a check other than an optional one fails.
+func (s *FiberServer) readyHandler(c *fiber.Ctx) error {
+	report := s.readiness.Run(c.UserContext())
+	if !report.Ready() {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(report)
+	}
+	return c.JSON(report)
+}
+
+// checkRequiredConfig fails while any of requiredConfigKeys is unset
+func (s *FiberServer) checkRequiredConfig(context.Context) error {
+	var missing []string
+	for _, key := range requiredConfigKeys {
+		if !s.config.IsSet(key) {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required keys: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// checkReadinessDelay fails until server.readiness_delay has passed since the server was created
+func (s *FiberServer) checkReadinessDelay(context.Context) error {
+	delay := defaultReadinessDelay
+	if s.config.IsSet("server.readiness_delay") {
+		delay = s.config.GetDuration("server.readiness_delay")
+	}
+	if uptime := time.Since(s.startedAt); uptime < delay {
+		return fmt.Errorf("running for %s, ready after %s", uptime.Round(time.Millisecond), delay)
+	}
+	return nil
+}
+
 // setupRoutes configures basic routes
 func (s *FiberServer) setupRoutes() {
-	// Health check endpoint
+	// Kubernetes liveness and readiness probes, only /readyz checks the dependencies
+	s.app.Get("/healthz", s.healthHandler)
+	s.app.Get("/readyz", s.readyHandler)
+
+	// Previous health check path, kept for existing probes
 	s.app.Get("/health", func(c *fiber.Ctx) error {
-		s.logger.Info("Health endpoint called")
+		return c.Redirect("/healthz", fiber.StatusMovedPermanently)
+	})
+
+	// Build metadata set with -ldflags, see pkg/build
+	s.app.Get("/version", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
-			"status": "healthy",
-			"env":    s.config.GetString("env"),
+			"version":    build.Version,
+			"commit":     build.Commit,
+			"build_time": build.BuildTime,
+			"app_name":   s.config.GetString("app.name"),
 		})
 	})
 
@@ -217,6 +532,89 @@ func (s *FiberServer) setupRoutes() {
 			"status":  "running",
 		})
 	})
+
+	// Config dump endpoint (debug mode only)
+	if s.config.GetBool("server.debug") {
+		s.app.Get("/debug/config", func(c *fiber.Ctx) error {
+			data, err := config.ExportJSON(s.config, config.DefaultRedactKeys)
+			if err != nil {
+				return fiber.NewError(fiber.StatusInternalServerError, "Failed to export config")
+			}
+			c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			return c.Send(data)
+		})
+	}
+
+	// Prometheus metrics, unauthenticated unless server.middleware.jwt is on
+	if s.metrics != nil {
+		s.app.Get("/metrics", adaptor.HTTPHandler(promhttp.HandlerFor(s.metrics, promhttp.HandlerOpts{})))
+	}
+
+	// Runtime log level control, always restricted to admin tokens whether or not server.middleware.jwt is on
+	if s.config.GetBool("server.log_level_endpoint") {
+		requireAdmin := []fiber.Handler{
+			middleware.JWTMiddleware([]byte(service.JWTSecret(s.config)), s.logger),
+			middleware.NewRoleMiddleware("admin"),
+		}
+		s.app.Get("/admin/log-level", append(requireAdmin, s.getLogLevelHandler)...)
+		s.app.Put("/admin/log-level", append(requireAdmin, s.setLogLevelHandler)...)
+	}
+}
+
+// logLevelRequest is the body of PUT /admin/log-level
+type logLevelRequest struct {
+	Level string `json:"level"`
+}
+
+// validLogLevels are the levels accepted by PUT /admin/log-level
+var validLogLevels = map[string]log.Level{
+	"debug": log.DebugLevel,
+	"info":  log.InfoLevel,
+	"warn":  log.WarnLevel,
+	"error": log.ErrorLevel,
+	"fatal": log.FatalLevel,
+	"panic": log.PanicLevel,
+}
+
+// levelController returns the server logger's level control, or a 501 error when it has none
+func (s *FiberServer) levelController() (log.LevelController, error) {
+	controller, ok := s.logger.(log.LevelController)
+	if !ok {
+		return nil, fiber.NewError(fiber.StatusNotImplemented, "Logger does not support changing the level")
+	}
+	return controller, nil
+}
+
+// getLogLevelHandler returns the current log level
+func (s *FiberServer) getLogLevelHandler(c *fiber.Ctx) error {
+	controller, err := s.levelController()
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"level": controller.GetLevel()})
+}
+
+// setLogLevelHandler changes the log level without restarting the server
+func (s *FiberServer) setLogLevelHandler(c *fiber.Ctx) error {
+	controller, err := s.levelController()
+	if err != nil {
+		return err
+	}
+
+	var req logLevelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+	level, ok := validLogLevels[strings.ToLower(req.Level)]
+	if !ok {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid log level, expected one of debug, info, warn, error, fatal, panic")
+	}
+
+	previous := controller.GetLevel()
+	controller.SetLevel(level)
+	s.logger.Info("Log level changed", log.String("old", string(previous)), log.String("new", string(level)))
+
+	return c.JSON(fiber.Map{"level": level})
 }
 
 // SetupBusinessRoutes configures business logic routes with dependencies
@@ -230,7 +628,22 @@ func (s *FiberServer) SetupBusinessRoutes(userService service.UserService) {
 	}
 
 	// Register business routes
-	routes.RegisterRoutes(routeConfig)
+	s.addRouteSetup(func() {
+		routes.RegisterRoutes(routeConfig)
+	})
+}
+
+// ErrNoContainer is returned by SetupContainerRoutes when the server was not created from a container
+var ErrNoContainer = errors.New("server was not created with NewFiberServerFromContainer")
+
+// SetupContainerRoutes configures business logic routes using the container the server was created with,
+// see NewFiberServerFromContainer. It returns ErrNoContainer when the server has no container.
+func (s *FiberServer) SetupContainerRoutes() error {
+	if s.container == nil {
+		return ErrNoContainer
+	}
+	s.SetupBusinessRoutesWithContainer(s.container)
+	return nil
 }
 
 // SetupBusinessRoutesWithContainer configures business logic routes using the container pattern
@@ -243,28 +656,116 @@ func (s *FiberServer) SetupBusinessRoutesWithContainer(container *container.Type
 	}
 
 	// Register business routes using container pattern
-	routes.RegisterRoutesWithContainer(routeConfig)
+	s.addRouteSetup(func() {
+		routes.RegisterRoutesWithContainer(routeConfig)
+	})
 }
 
-// GetApp returns the underlying Fiber app
-func (s *FiberServer) GetApp() *fiber.App {
+// Build registers the built-in and queued routes and returns the Fiber app.
+// It is idempotent; routes added afterwards are registered immediately.
+func (s *FiberServer) Build() *fiber.App {
+	if s.built {
+		return s.app
+	}
+	s.built = true
+
+	s.setupRoutes()
+	for _, setup := range s.routeSetups {
+		setup()
+	}
+	s.routeSetups = nil
+
 	return s.app
 }
 
+// GetApp builds the server if needed and returns the underlying Fiber app
+func (s *FiberServer) GetApp() *fiber.App {
+	return s.Build()
+}
+
+// addRouteSetup queues a route registration until Build, or runs it once the server is built
+func (s *FiberServer) addRouteSetup(setup func()) {
+	if s.built {
+		setup()
+		return
+	}
+	s.routeSetups = append(s.routeSetups, setup)
+}
+
 // AddRoutes allows adding additional routes to the server
 func (s *FiberServer) AddRoutes(setupFunc func(*fiber.App)) {
-	setupFunc(s.app)
+	s.addRouteSetup(func() {
+		setupFunc(s.app)
+	})
 }
 
-// AddMiddleware allows adding additional middleware
+// AddMiddleware adds middleware for all routes.
+// It only intercepts every route when called before Build or GetApp; afterwards it
+// only runs for requests that reach it, i.e. routes without a matching handler.
 func (s *FiberServer) AddMiddleware(middleware ...fiber.Handler) {
+	if s.built {
+		s.logger.Warn("Middleware added after the server was built does not run for existing routes")
+	}
 	for _, m := range middleware {
 		s.app.Use(m)
 	}
 }
 
+// InsertMiddlewareBefore adds middleware for routes under the marker path prefix, e.g. "/api".
+// Like AddMiddleware it must be called before Build or GetApp to intercept those routes.
+func (s *FiberServer) InsertMiddlewareBefore(marker string, middleware fiber.Handler) {
+	if s.built {
+		s.logger.Warn("Middleware added after the server was built does not run for existing routes", log.String("prefix", marker))
+	}
+	s.app.Use(marker, middleware)
+}
+
+// MountGRPCGateway serves a grpc-gateway mux under prefix, e.g. "/grpc".
+// The prefix is stripped before the request reaches the gateway, so gateway paths are relative to it.
+func (s *FiberServer) MountGRPCGateway(gwMux *runtime.ServeMux, prefix string) {
+	prefix = strings.TrimSuffix(prefix, "/")
+	s.addRouteSetup(func() {
+		if prefix == "" {
+			s.app.Use(NewGRPCGatewayAdapter(gwMux))
+			return
+		}
+		s.app.Use(prefix, NewGRPCGatewayAdapter(http.StripPrefix(prefix, gwMux)))
+	})
+}
+
+// AddWebSocket serves handler on WebSocket connections upgraded at path, rejecting other requests with 426.
+// The connection buffers are sized by server.websocket.read_buffer_size and write_buffer_size.
+func (s *FiberServer) AddWebSocket(path string, handler func(*websocket.Conn)) *FiberServer {
+	wsConfig := websocket.Config{
+		ReadBufferSize:  s.config.GetInt("server.websocket.read_buffer_size"),
+		WriteBufferSize: s.config.GetInt("server.websocket.write_buffer_size"),
+	}
+	// The guard is part of the route, app.Use would also reject every route below path
+	upgradeGuard := func(c *fiber.Ctx) error {
+		if !websocket.IsWebSocketUpgrade(c) {
+			return fiber.ErrUpgradeRequired
+		}
+		return c.Next()
+	}
+	s.addRouteSetup(func() {
+		s.app.Get(path, upgradeGuard, websocket.New(handler, wsConfig))
+	})
+	return s
+}
+
+// AddSSE serves a Server-Sent Events stream on GET path, written by handler, see utils.StreamSSE
+func (s *FiberServer) AddSSE(path string, handler func(*utils.SSEWriter, *fiber.Ctx)) {
+	s.addRouteSetup(func() {
+		s.app.Get(path, func(c *fiber.Ctx) error {
+			return utils.StreamSSE(c, handler)
+		})
+	})
+}
+
 // AddGroup creates a new route group
 func (s *FiberServer) AddGroup(prefix string, setupFunc func(fiber.Router)) {
-	group := s.app.Group(prefix)
-	setupFunc(group)
+	s.addRouteSetup(func() {
+		group := s.app.Group(prefix)
+		setupFunc(group)
+	})
 }
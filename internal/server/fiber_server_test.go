@@ -2,16 +2,35 @@ package server
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/spf13/viper"
-
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	_ "modernc.org/sqlite"
+
+	"github.com/MayukhSobo/scaffold/pkg/build"
+	"github.com/MayukhSobo/scaffold/pkg/container"
+	"github.com/MayukhSobo/scaffold/pkg/db"
+	"github.com/MayukhSobo/scaffold/pkg/health"
 	"github.com/MayukhSobo/scaffold/pkg/log"
+	"github.com/MayukhSobo/scaffold/pkg/utils"
 )
 
 func createTestConfig() *viper.Viper {
@@ -91,7 +110,7 @@ func TestFiberServerHealthEndpoint(t *testing.T) {
 	app := server.GetApp()
 
 	// Create a test request
-	req := httptest.NewRequest("GET", "/health", nil)
+	req := httptest.NewRequest("GET", "/healthz", nil)
 	resp, err := app.Test(req)
 
 	if err != nil {
@@ -122,6 +141,349 @@ func TestFiberServerHealthEndpoint(t *testing.T) {
 	}
 }
 
+func TestFiberServerReadyEndpointComponents(t *testing.T) {
+	failing := func(ctx context.Context) error { return errors.New("connection refused") }
+	passing := func(ctx context.Context) error { return nil }
+
+	testCases := []struct {
+		name           string
+		databaseCheck  health.Check
+		datadogCheck   health.Check
+		expectedCode   int
+		expectedStatus string
+		checks         map[string]string
+	}{
+		{
+			name:           "all healthy",
+			databaseCheck:  passing,
+			datadogCheck:   passing,
+			expectedCode:   http.StatusOK,
+			expectedStatus: "ready",
+			checks:         map[string]string{"database": "ok", "datadog": "ok"},
+		},
+		{
+			name:           "non-critical failure",
+			databaseCheck:  passing,
+			datadogCheck:   failing,
+			expectedCode:   http.StatusOK,
+			expectedStatus: "ready",
+			checks:         map[string]string{"database": "ok", "datadog": "fail"},
+		},
+		{
+			name:           "critical failure",
+			databaseCheck:  failing,
+			datadogCheck:   passing,
+			expectedCode:   http.StatusServiceUnavailable,
+			expectedStatus: "not_ready",
+			checks:         map[string]string{"database": "fail", "datadog": "ok"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := createTestConfig()
+			config.Set("server.readiness_delay", "0s")
+			c := container.NewTypedContainerWithoutDB(config, createTestLogger())
+			c.HealthChecks().Register("database", tc.databaseCheck)
+			c.HealthChecks().RegisterOptional("datadog", tc.datadogCheck)
+			app := NewFiberServerFromContainer(c).GetApp()
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/readyz", nil))
+			if err != nil {
+				t.Fatalf("Failed to test readiness endpoint: %v", err)
+			}
+			if resp.StatusCode != tc.expectedCode {
+				t.Errorf("Expected status %d, got %d", tc.expectedCode, resp.StatusCode)
+			}
+
+			var response struct {
+				Status string            `json:"status"`
+				Checks map[string]string `json:"checks"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to parse JSON response: %v", err)
+			}
+			if response.Status != tc.expectedStatus {
+				t.Errorf("Expected status '%s', got '%s'", tc.expectedStatus, response.Status)
+			}
+			for name, want := range tc.checks {
+				if got := response.Checks[name]; got != want {
+					t.Errorf("Expected %s check '%s', got '%s'", name, want, got)
+				}
+			}
+
+			// The liveness probe does not depend on the components
+			resp, err = app.Test(httptest.NewRequest("GET", "/healthz", nil))
+			if err != nil {
+				t.Fatalf("Failed to test health endpoint: %v", err)
+			}
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected /healthz status 200, got %d", resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestFiberServerHealthEndpointDatabasePool(t *testing.T) {
+	testCases := []struct {
+		name    string
+		closeDB bool
+	}{
+		{"database reachable", false},
+		{"database unreachable", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := sql.Open("sqlite", ":memory:")
+			if err != nil {
+				t.Fatalf("Failed to open sqlite database: %v", err)
+			}
+			defer db.Close()
+			if tc.closeDB {
+				db.Close()
+			}
+
+			server := NewFiberServer(createTestConfig(), createTestLogger()).WithDatabase(db)
+			resp, err := server.GetApp().Test(httptest.NewRequest("GET", "/healthz", nil))
+			if err != nil {
+				t.Fatalf("Failed to test health endpoint: %v", err)
+			}
+			// Liveness does not ping the database
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected status 200, got %d", resp.StatusCode)
+			}
+
+			var response map[string]interface{}
+			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to parse JSON response: %v", err)
+			}
+			if response["status"] != "healthy" {
+				t.Errorf("Expected status 'healthy', got %v", response["status"])
+			}
+
+			pool, ok := response["database_pool"].(map[string]interface{})
+			if !ok {
+				t.Fatalf("Expected database_pool in response, got %v", response)
+			}
+			for _, key := range []string{"open_connections", "in_use", "idle", "wait_count", "wait_duration"} {
+				if _, ok := pool[key]; !ok {
+					t.Errorf("Expected %s in database_pool, got %v", key, pool)
+				}
+			}
+		})
+	}
+}
+
+func TestFiberServerHealthEndpointCircuitBreaker(t *testing.T) {
+	conn, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open sqlite database: %v", err)
+	}
+	defer conn.Close()
+
+	breaker := db.NewCircuitBreakerDB(conn, db.CircuitBreakerConfig{})
+	server := NewFiberServer(createTestConfig(), createTestLogger()).WithCircuitBreaker(breaker)
+	resp, err := server.GetApp().Test(httptest.NewRequest("GET", "/healthz", nil))
+	if err != nil {
+		t.Fatalf("Failed to test health endpoint: %v", err)
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v", err)
+	}
+	if response["database_circuit"] != db.CircuitClosed {
+		t.Errorf("Expected database_circuit %q, got %v", db.CircuitClosed, response["database_circuit"])
+	}
+
+	readyz := func() map[string]string {
+		resp, err := server.GetApp().Test(httptest.NewRequest("GET", "/readyz", nil))
+		if err != nil {
+			t.Fatalf("Failed to test readiness endpoint: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		var report struct {
+			Checks map[string]string `json:"checks"`
+		}
+		if err := json.Unmarshal(body, &report); err != nil {
+			t.Fatalf("Failed to parse JSON response: %v", err)
+		}
+		return report.Checks
+	}
+	if got := readyz()["database_circuit"]; got != "ok" {
+		t.Errorf("Expected database_circuit check 'ok' while closed, got '%s'", got)
+	}
+
+	// Open the circuit with pings refused by a server that is not listening
+	unreachable, err := sql.Open("mysql", "scaffold:secret@tcp(127.0.0.1:1)/user?timeout=1s")
+	if err != nil {
+		t.Fatalf("Failed to open mysql database: %v", err)
+	}
+	defer unreachable.Close()
+	breaker = db.NewCircuitBreakerDB(unreachable, db.CircuitBreakerConfig{Threshold: 1})
+	server = NewFiberServer(createTestConfig(), createTestLogger()).WithCircuitBreaker(breaker)
+	_ = breaker.PingContext(context.Background())
+	if got := readyz()["database_circuit"]; got != health.CheckFail {
+		t.Errorf("Expected database_circuit check to fail while open, got '%s'", got)
+	}
+}
+
+func TestFiberServerHealthRedirect(t *testing.T) {
+	server := NewFiberServer(createTestConfig(), createTestLogger())
+	resp, err := server.GetApp().Test(httptest.NewRequest("GET", "/health", nil))
+	if err != nil {
+		t.Fatalf("Failed to test health endpoint: %v", err)
+	}
+	if resp.StatusCode != http.StatusMovedPermanently {
+		t.Errorf("Expected status %d, got %d", http.StatusMovedPermanently, resp.StatusCode)
+	}
+	if location := resp.Header.Get("Location"); location != "/healthz" {
+		t.Errorf("Expected Location '/healthz', got '%s'", location)
+	}
+}
+
+func TestFiberServerReadyEndpoint(t *testing.T) {
+	testCases := []struct {
+		name           string
+		delay          string
+		unsetAppName   bool
+		closeDB        bool
+		expectedCode   int
+		expectedStatus string
+		expectedFailed []string
+	}{
+		{"ready", "0s", false, false, http.StatusOK, "ready", nil},
+		{"within readiness delay", "", false, false, http.StatusServiceUnavailable, "not_ready", []string{"startup"}},
+		{"missing required key", "0s", true, false, http.StatusServiceUnavailable, "not_ready", []string{"config"}},
+		{"database unreachable", "0s", false, true, http.StatusServiceUnavailable, "not_ready", []string{"database"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conn, err := sql.Open("sqlite", ":memory:")
+			if err != nil {
+				t.Fatalf("Failed to open sqlite database: %v", err)
+			}
+			defer conn.Close()
+			if tc.closeDB {
+				conn.Close()
+			}
+
+			config := createTestConfig()
+			if tc.unsetAppName {
+				config = viper.New()
+				config.Set("http.port", 8080)
+			}
+			if tc.delay != "" {
+				config.Set("server.readiness_delay", tc.delay)
+			}
+			server := NewFiberServer(config, createTestLogger()).WithDatabase(conn)
+
+			resp, err := server.GetApp().Test(httptest.NewRequest("GET", "/readyz", nil))
+			if err != nil {
+				t.Fatalf("Failed to test readiness endpoint: %v", err)
+			}
+			if resp.StatusCode != tc.expectedCode {
+				t.Errorf("Expected status %d, got %d", tc.expectedCode, resp.StatusCode)
+			}
+
+			var response map[string]interface{}
+			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to parse JSON response: %v", err)
+			}
+			if response["status"] != tc.expectedStatus {
+				t.Errorf("Expected status '%s', got %v", tc.expectedStatus, response["status"])
+			}
+
+			checks, _ := response["checks"].(map[string]interface{})
+			for _, check := range []string{"config", "startup", "database"} {
+				if _, ok := checks[check]; !ok {
+					t.Errorf("Expected check %s in response, got %v", check, checks)
+				}
+			}
+			var failed []string
+			for name, result := range checks {
+				if result != "ok" {
+					failed = append(failed, name)
+				}
+			}
+			if len(failed) != len(tc.expectedFailed) || (len(failed) == 1 && failed[0] != tc.expectedFailed[0]) {
+				t.Errorf("Expected failed checks %v, got %v", tc.expectedFailed, checks)
+			}
+		})
+	}
+}
+
+func TestFiberServerReadyEndpointCustomCheck(t *testing.T) {
+	config := createTestConfig()
+	config.Set("server.readiness_delay", "0s")
+	server := NewFiberServer(config, createTestLogger())
+	app := server.GetApp()
+
+	// Checks registered after the routes are built still run
+	server.ReadinessChecks().Register("queue", func(ctx context.Context) error {
+		return errors.New("broker unreachable")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/readyz", nil))
+	if err != nil {
+		t.Fatalf("Failed to test readiness endpoint: %v", err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", resp.StatusCode)
+	}
+
+	var response struct {
+		Status string            `json:"status"`
+		Checks map[string]string `json:"checks"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v", err)
+	}
+	if response.Status != "not_ready" {
+		t.Errorf("Expected status 'not_ready', got '%s'", response.Status)
+	}
+	if response.Checks["queue"] != "fail" || response.Checks["config"] != "ok" {
+		t.Errorf("Expected the queue check to fail alone, got %v", response.Checks)
+	}
+}
+
+func TestFiberServerVersionEndpoint(t *testing.T) {
+	defer func(version, commit, buildTime string) {
+		build.Version, build.Commit, build.BuildTime = version, commit, buildTime
+	}(build.Version, build.Commit, build.BuildTime)
+	build.Version, build.Commit, build.BuildTime = "v1.2.0", "abc1234", "2024-01-02T03:04:05Z"
+
+	server := NewFiberServer(createTestConfig(), createTestLogger())
+	resp, err := server.GetApp().Test(httptest.NewRequest("GET", "/version", nil))
+	if err != nil {
+		t.Fatalf("Failed to test version endpoint: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	var response map[string]string
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v", err)
+	}
+	expected := map[string]string{
+		"version":    "v1.2.0",
+		"commit":     "abc1234",
+		"build_time": "2024-01-02T03:04:05Z",
+		"app_name":   "TestApp",
+	}
+	if len(response) != len(expected) {
+		t.Errorf("Expected keys %v, got %v", expected, response)
+	}
+	for key, value := range expected {
+		if response[key] != value {
+			t.Errorf("Expected %s '%s', got '%s'", key, value, response[key])
+		}
+	}
+}
+
 func TestFiberServerPingEndpoint(t *testing.T) {
 	config := createTestConfig()
 	logger := createTestLogger()
@@ -331,6 +693,92 @@ func TestFiberServerAddMiddleware(t *testing.T) {
 	}
 }
 
+func TestFiberServerAddMiddlewareBeforeBuild(t *testing.T) {
+	server := NewFiberServer(createTestConfig(), createTestLogger())
+
+	server.AddRoutes(func(app *fiber.App) {
+		app.Get("/custom", func(c *fiber.Ctx) error {
+			return c.SendString("custom")
+		})
+	})
+
+	// Added after NewFiberServer and AddRoutes but before GetApp
+	server.AddMiddleware(func(c *fiber.Ctx) error {
+		c.Set("X-Intercepted", "true")
+		return c.Next()
+	})
+
+	app := server.GetApp()
+	for _, path := range []string{"/healthz", "/ping", "/custom"} {
+		resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+		if err != nil {
+			t.Fatalf("Failed to test %s: %v", path, err)
+		}
+		if resp.Header.Get("X-Intercepted") != "true" {
+			t.Errorf("Expected middleware to intercept %s", path)
+		}
+	}
+}
+
+func TestFiberServerInsertMiddlewareBefore(t *testing.T) {
+	server := NewFiberServer(createTestConfig(), createTestLogger())
+
+	server.AddGroup("/api", func(router fiber.Router) {
+		router.Get("/items", func(c *fiber.Ctx) error {
+			return c.SendString("items")
+		})
+	})
+
+	server.InsertMiddlewareBefore("/api", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusForbidden, "blocked")
+	})
+
+	app := server.GetApp()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/items", nil))
+	if err != nil {
+		t.Fatalf("Failed to test /api/items: %v", err)
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected status 403 for /api/items, got %d", resp.StatusCode)
+	}
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/ping", nil))
+	if err != nil {
+		t.Fatalf("Failed to test /ping: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200 for /ping, got %d", resp.StatusCode)
+	}
+}
+
+func TestFiberServerBuildIdempotent(t *testing.T) {
+	server := NewFiberServer(createTestConfig(), createTestLogger())
+
+	app := server.Build()
+	if server.GetApp() != app {
+		t.Error("Build and GetApp should return the same app")
+	}
+
+	// Routes added after building are registered immediately
+	server.AddRoutes(func(app *fiber.App) {
+		app.Get("/late", func(c *fiber.Ctx) error {
+			return c.SendString("late")
+		})
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/late", nil))
+	if err != nil {
+		t.Fatalf("Failed to test /late: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if len(app.GetRoutes()) == 0 {
+		t.Error("Expected routes to be registered")
+	}
+}
+
 func TestFiberServerErrorHandler(t *testing.T) {
 	config := createTestConfig()
 	logger := createTestLogger()
@@ -382,6 +830,29 @@ func TestFiberServerErrorHandler(t *testing.T) {
 	}
 }
 
+func TestFiberServerErrorLogsErrKey(t *testing.T) {
+	var buf bytes.Buffer
+	server := NewFiberServer(createTestConfig(), log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false))
+
+	server.AddRoutes(func(app *fiber.App) {
+		app.Get("/error", func(c *fiber.Ctx) error {
+			return fiber.NewError(fiber.StatusBadRequest, "Test error")
+		})
+	})
+
+	if _, err := server.GetApp().Test(httptest.NewRequest("GET", "/error", nil)); err != nil {
+		t.Fatalf("Failed to test error handler: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, `"err":"Test error"`) {
+		t.Errorf("Expected error logged under the err key, got: %s", output)
+	}
+	if strings.Contains(output, `"error":`) {
+		t.Errorf("Expected no error key in log output, got: %s", output)
+	}
+}
+
 func TestFiberServerWithDisabledMiddleware(t *testing.T) {
 	config := createTestConfig()
 	// Disable all middleware
@@ -400,7 +871,7 @@ func TestFiberServerWithDisabledMiddleware(t *testing.T) {
 
 	// Test that basic endpoints still work
 	app := server.GetApp()
-	req := httptest.NewRequest("GET", "/health", nil)
+	req := httptest.NewRequest("GET", "/healthz", nil)
 	resp, err := app.Test(req)
 
 	if err != nil {
@@ -420,7 +891,7 @@ func TestFiberServerCORSConfiguration(t *testing.T) {
 	app := server.GetApp()
 
 	// Test CORS preflight request
-	req := httptest.NewRequest("OPTIONS", "/health", nil)
+	req := httptest.NewRequest("OPTIONS", "/healthz", nil)
 	req.Header.Set("Origin", "http://localhost:3000")
 	req.Header.Set("Access-Control-Request-Method", "GET")
 
@@ -450,7 +921,7 @@ func TestFiberServerConfiguration(t *testing.T) {
 	}
 
 	// Test that we can make requests (indicating the server is configured)
-	req := httptest.NewRequest("GET", "/health", nil)
+	req := httptest.NewRequest("GET", "/healthz", nil)
 	resp, err := app.Test(req)
 
 	if err != nil {
@@ -461,3 +932,590 @@ func TestFiberServerConfiguration(t *testing.T) {
 		t.Errorf("Expected status 200, got %d", resp.StatusCode)
 	}
 }
+
+func TestFiberServerMaxResponseSize(t *testing.T) {
+	config := createTestConfig()
+	config.Set("server.max_response_size", 16)
+	logger := createTestLogger()
+
+	server := NewFiberServer(config, logger)
+
+	server.AddRoutes(func(app *fiber.App) {
+		app.Get("/large-response", func(c *fiber.Ctx) error {
+			return c.SendString("this response is longer than sixteen bytes")
+		})
+	})
+
+	app := server.GetApp()
+
+	req := httptest.NewRequest("GET", "/large-response", nil)
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to test response size limit: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", resp.StatusCode)
+	}
+}
+
+func TestFiberServerDebugConfigEndpoint(t *testing.T) {
+	testCases := []struct {
+		name           string
+		debug          bool
+		expectedStatus int
+	}{
+		{"debug mode enabled", true, http.StatusOK},
+		{"debug mode disabled", false, http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := createTestConfig()
+			config.Set("server.debug", tc.debug)
+			config.Set("db.mysql.password", "super-secret")
+			logger := createTestLogger()
+
+			server := NewFiberServer(config, logger)
+			app := server.GetApp()
+
+			req := httptest.NewRequest("GET", "/debug/config", nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test debug config endpoint: %v", err)
+			}
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.debug {
+				body, _ := io.ReadAll(resp.Body)
+				if strings.Contains(string(body), "super-secret") {
+					t.Error("Debug config should not expose the database password")
+				}
+				if !strings.Contains(string(body), `"TestApp"`) {
+					t.Errorf("Expected debug config to contain app name, got: %s", string(body))
+				}
+			}
+		})
+	}
+}
+
+// signTestToken signs an HS256 token for the given role that is valid for an hour
+func signTestToken(t *testing.T, secret, role string) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":  "1",
+		"role": role,
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	return token
+}
+
+func TestFiberServerLogLevelEndpoint(t *testing.T) {
+	const secret = "log-level-secret"
+	config := createTestConfig()
+	config.Set("server.log_level_endpoint", true)
+	config.Set("server.jwt.secret", secret)
+	logger := createTestLogger()
+	app := NewFiberServer(config, logger).GetApp()
+
+	adminToken := signTestToken(t, secret, "admin")
+	userToken := signTestToken(t, secret, "user")
+
+	testCases := []struct {
+		name           string
+		method         string
+		body           string
+		token          string
+		expectedStatus int
+		expectedLevel  string
+	}{
+		{"unauthenticated get", "GET", "", "", http.StatusUnauthorized, ""},
+		{"unauthenticated set", "PUT", `{"level":"debug"}`, "", http.StatusUnauthorized, ""},
+		{"non-admin set", "PUT", `{"level":"debug"}`, userToken, http.StatusForbidden, ""},
+		{"get current level", "GET", "", adminToken, http.StatusOK, "info"},
+		{"set debug level", "PUT", `{"level":"debug"}`, adminToken, http.StatusOK, "debug"},
+		{"level is persisted", "GET", "", adminToken, http.StatusOK, "debug"},
+		{"invalid level", "PUT", `{"level":"verbose"}`, adminToken, http.StatusBadRequest, ""},
+		{"invalid body", "PUT", `not json`, adminToken, http.StatusBadRequest, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/admin/log-level", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test log level endpoint: %v", err)
+			}
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.expectedLevel != "" {
+				var body map[string]string
+				if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if body["level"] != tc.expectedLevel {
+					t.Errorf("Expected level %s, got %s", tc.expectedLevel, body["level"])
+				}
+			}
+		})
+	}
+
+	if got := logger.(log.LevelController).GetLevel(); got != log.DebugLevel {
+		t.Errorf("Expected logger level debug, got %s", got)
+	}
+}
+
+func TestFiberServerLogLevelEndpointDisabled(t *testing.T) {
+	app := NewFiberServer(createTestConfig(), createTestLogger()).GetApp()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/admin/log-level", nil))
+	if err != nil {
+		t.Fatalf("Failed to test log level endpoint: %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, resp.StatusCode)
+	}
+}
+
+func TestFiberServerJWTMiddleware(t *testing.T) {
+	const secret = "server-test-secret"
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "42",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+
+	config := createTestConfig()
+	config.Set("server.middleware.jwt", true)
+	config.Set("server.jwt.secret", secret)
+	server := NewFiberServer(config, createTestLogger())
+	server.AddRoutes(func(app *fiber.App) {
+		app.Get("/private", func(c *fiber.Ctx) error {
+			return c.SendString("private")
+		})
+	})
+	app := server.GetApp()
+
+	testCases := []struct {
+		name           string
+		path           string
+		token          string
+		expectedStatus int
+	}{
+		{"healthz is excluded by default", "/healthz", "", http.StatusOK},
+		{"ping is excluded by default", "/ping", "", http.StatusOK},
+		{"private route without token", "/private", "", http.StatusUnauthorized},
+		{"private route with token", "/private", token, http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tc.path, nil)
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestFiberServerCSRFMiddleware(t *testing.T) {
+	config := createTestConfig()
+	config.Set("server.middleware.csrf", true)
+	config.Set("server.csrf.exempt_paths", []string{"/webhooks/*"})
+	server := NewFiberServer(config, createTestLogger())
+	server.AddRoutes(func(app *fiber.App) {
+		app.Post("/items", func(c *fiber.Ctx) error {
+			return c.SendString("created")
+		})
+		app.Post("/webhooks/github", func(c *fiber.Ctx) error {
+			return c.SendString("received")
+		})
+	})
+	app := server.GetApp()
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/items", nil))
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("Expected status %d without a token, got %d", http.StatusForbidden, resp.StatusCode)
+	}
+	var body map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if body["error"] != true || body["code"] != float64(http.StatusForbidden) {
+		t.Errorf("Expected the standard error body, got %v", body)
+	}
+
+	req := httptest.NewRequest("POST", "/items", nil)
+	req.AddCookie(&http.Cookie{Name: "csrf_token", Value: "token"})
+	req.Header.Set("X-CSRF-Token", "token")
+	if resp, err = app.Test(req); err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status %d with a matching token, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	if resp, err = app.Test(httptest.NewRequest("POST", "/webhooks/github", nil)); err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status %d for an exempt path, got %d", http.StatusOK, resp.StatusCode)
+	}
+}
+
+func TestFiberServerRateLimit(t *testing.T) {
+	const max = 3
+
+	config := createTestConfig()
+	config.Set("server.rate_limit.enabled", true)
+	config.Set("server.rate_limit.max", max)
+	config.Set("server.rate_limit.expiration", "1m")
+	app := NewFiberServer(config, createTestLogger()).GetApp()
+
+	for i := 1; i <= max+1; i++ {
+		resp, err := app.Test(httptest.NewRequest("GET", "/ping", nil))
+		if err != nil {
+			t.Fatalf("Failed to test request %d: %v", i, err)
+		}
+
+		if i <= max {
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected request %d to return 200, got %d", i, resp.StatusCode)
+			}
+			continue
+		}
+
+		if resp.StatusCode != http.StatusTooManyRequests {
+			t.Fatalf("Expected request %d to return 429, got %d", i, resp.StatusCode)
+		}
+		var body struct {
+			Error   bool   `json:"error"`
+			Message string `json:"message"`
+			Code    int    `json:"code"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to parse JSON response: %v", err)
+		}
+		if !body.Error || body.Code != http.StatusTooManyRequests || body.Message == "" {
+			t.Errorf("Expected the error handler format with code 429, got %+v", body)
+		}
+	}
+}
+
+func TestFiberServerOTelMiddleware(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer otel.SetTracerProvider(previous)
+
+	config := createTestConfig()
+	config.Set("server.middleware.otel", true)
+	app := NewFiberServer(config, createTestLogger()).GetApp()
+
+	if _, err := app.Test(httptest.NewRequest("GET", "/ping", nil)); err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 || spans[0].Name != "/ping" {
+		t.Errorf("Expected one /ping span, got %+v", spans)
+	}
+}
+
+func TestFiberServerMetricsEndpoint(t *testing.T) {
+	config := createTestConfig()
+	config.Set("server.metrics.enabled", true)
+	app := NewFiberServer(config, createTestLogger()).Build()
+
+	if _, err := app.Test(httptest.NewRequest("GET", "/ping", nil)); err != nil {
+		t.Fatalf("Failed to test ping: %v", err)
+	}
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	if err != nil {
+		t.Fatalf("Failed to test metrics: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	body, _ := io.ReadAll(resp.Body)
+
+	if !strings.Contains(string(body), `http_requests_total{method="GET",path="/ping",status="200"} 1`) {
+		t.Errorf("Expected the /ping request counter, got:\n%s", body)
+	}
+
+	// Disabled by default
+	resp, err = NewFiberServer(createTestConfig(), createTestLogger()).Build().Test(httptest.NewRequest("GET", "/metrics", nil))
+	if err != nil {
+		t.Fatalf("Failed to test metrics: %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status 404 without server.metrics.enabled, got %d", resp.StatusCode)
+	}
+}
+
+func TestFiberServerContentTypeMiddleware(t *testing.T) {
+	config := createTestConfig()
+	config.Set("server.middleware.content_type", true)
+	logger := createTestLogger()
+
+	server := NewFiberServer(config, logger)
+	server.AddRoutes(func(app *fiber.App) {
+		app.Post("/items", func(c *fiber.Ctx) error {
+			return c.SendString("created")
+		})
+	})
+	app := server.GetApp()
+
+	req := httptest.NewRequest("POST", "/items", strings.NewReader("plain text"))
+	req.Header.Set("Content-Type", "text/plain")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test content type middleware: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusUnsupportedMediaType {
+		t.Errorf("Expected status 415, got %d", resp.StatusCode)
+	}
+}
+
+func TestFiberServerMaxRequestSize(t *testing.T) {
+	config := createTestConfig()
+	config.Set("server.max_request_size", "1KB")
+	logger := createTestLogger()
+
+	server := NewFiberServer(config, logger)
+	server.AddRoutes(func(app *fiber.App) {
+		app.Post("/upload", func(c *fiber.Ctx) error {
+			return c.SendString("ok")
+		})
+	})
+	app := server.GetApp()
+
+	// The body limit is enforced while reading the connection, which app.Test reports as an error,
+	// so serve on a real listener to observe the 413 response
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go func() { _ = app.Listener(ln) }()
+	defer func() { _ = app.Shutdown() }()
+
+	url := "http://" + ln.Addr().String() + "/upload"
+
+	testCases := []struct {
+		name           string
+		size           int
+		expectedStatus int
+	}{
+		{"within limit", 512, http.StatusOK},
+		{"exceeds limit", 2048, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Post(url, "text/plain", strings.NewReader(strings.Repeat("a", tc.size)))
+			if err != nil {
+				t.Fatalf("Failed to test request size limit: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestNewFiberServerFromContainer(t *testing.T) {
+	config := createTestConfig()
+	logger := createTestLogger()
+	c := container.NewTypedContainerWithoutDB(config, logger)
+
+	server := NewFiberServerFromContainer(c)
+
+	if server.config != config {
+		t.Error("Server config should come from the container")
+	}
+	if server.logger != logger {
+		t.Error("Server logger should come from the container")
+	}
+	if server.container != c {
+		t.Error("Server should keep a reference to the container")
+	}
+
+	// Routes registered from the stored container
+	if err := server.SetupContainerRoutes(); err != nil {
+		t.Fatalf("SetupContainerRoutes() returned error: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/api/v1/products/abc", nil)
+	resp, err := server.GetApp().Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test container routes: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestSetupContainerRoutesWithoutContainer(t *testing.T) {
+	server := NewFiberServer(createTestConfig(), createTestLogger())
+
+	if err := server.SetupContainerRoutes(); !errors.Is(err, ErrNoContainer) {
+		t.Errorf("Expected ErrNoContainer, got %v", err)
+	}
+}
+
+func TestFiberServerIntegrityMiddleware(t *testing.T) {
+	config := createTestConfig()
+	config.Set("server.middleware.integrity.enabled", true)
+	config.Set("server.middleware.integrity.algorithm", "sha256")
+	logger := createTestLogger()
+
+	server := NewFiberServer(config, logger)
+	app := server.GetApp()
+
+	req := httptest.NewRequest("GET", "/ping", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test integrity middleware: %v", err)
+	}
+
+	if resp.Header.Get("X-Response-Body-Hash") == "" {
+		t.Error("Expected X-Response-Body-Hash header to be set")
+	}
+	if resp.Header.Get("X-Request-Body-Hash") == "" {
+		t.Error("Expected X-Request-Body-Hash header to be set")
+	}
+}
+
+func TestFiberServerAddWebSocket(t *testing.T) {
+	config := createTestConfig()
+	config.Set("server.websocket.read_buffer_size", 2048)
+	config.Set("server.websocket.write_buffer_size", 2048)
+	server := NewFiberServer(config, createTestLogger())
+
+	received := make(chan string, 1)
+	if got := server.AddWebSocket("/ws", func(conn *websocket.Conn) {
+		messageType, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		received <- string(message)
+		_ = conn.WriteMessage(messageType, append([]byte("echo: "), message...))
+	}); got != server {
+		t.Error("Expected AddWebSocket to return the server for chaining")
+	}
+	server.AddRoutes(func(app *fiber.App) {
+		app.Get("/ws/status", func(c *fiber.Ctx) error {
+			return c.SendString("ok")
+		})
+	})
+
+	app := server.GetApp()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go func() { _ = app.Listener(ln) }()
+	t.Cleanup(func() { _ = app.Shutdown() })
+
+	// Plain requests are not upgraded
+	resp, err := app.Test(httptest.NewRequest("GET", "/ws", nil))
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusUpgradeRequired {
+		t.Errorf("Expected status %d, got %d", fiber.StatusUpgradeRequired, resp.StatusCode)
+	}
+
+	// Routes below the WebSocket path are not guarded
+	resp, err = app.Test(httptest.NewRequest("GET", "/ws/status", nil))
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status %d for /ws/status, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, _, _, err := ws.Dial(ctx, "ws://"+ln.Addr().String()+"/ws")
+	if err != nil {
+		t.Fatalf("Failed to dial websocket: %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if err := wsutil.WriteClientText(conn, []byte("hello")); err != nil {
+		t.Fatalf("Failed to send message: %v", err)
+	}
+	select {
+	case message := <-received:
+		if message != "hello" {
+			t.Errorf("Expected handler to receive %q, got %q", "hello", message)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for the handler to receive the message")
+	}
+
+	reply, err := wsutil.ReadServerText(conn)
+	if err != nil {
+		t.Fatalf("Failed to read reply: %v", err)
+	}
+	if string(reply) != "echo: hello" {
+		t.Errorf("Expected reply %q, got %q", "echo: hello", reply)
+	}
+}
+
+func TestFiberServerAddSSE(t *testing.T) {
+	server := NewFiberServer(createTestConfig(), createTestLogger())
+	server.AddSSE("/events", func(w *utils.SSEWriter, c *fiber.Ctx) {
+		_ = w.Comment("connected")
+		_ = w.Send("greeting", "hello")
+	})
+
+	resp, err := server.GetApp().Test(httptest.NewRequest("GET", "/events", nil))
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if contentType := resp.Header.Get(fiber.HeaderContentType); contentType != "text/event-stream" {
+		t.Errorf("Expected content type %q, got %q", "text/event-stream", contentType)
+	}
+	if cacheControl := resp.Header.Get(fiber.HeaderCacheControl); cacheControl != "no-cache" {
+		t.Errorf("Expected cache control %q, got %q", "no-cache", cacheControl)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	expected := ": connected\n\nevent: greeting\ndata: hello\n\n"
+	if string(body) != expected {
+		t.Errorf("Expected stream %q, got %q", expected, body)
+	}
+}
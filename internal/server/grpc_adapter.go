@@ -0,0 +1,13 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+)
+
+// NewGRPCGatewayAdapter wraps a grpc-gateway mux (or any net/http handler) as a Fiber handler
+func NewGRPCGatewayAdapter(gwMux http.Handler) fiber.Handler {
+	return adaptor.HTTPHandler(gwMux)
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// createTestGatewayMux registers endpoints the way generated gateway code does,
+// transcoding protobuf messages through the mux's marshaler
+func createTestGatewayMux(t *testing.T) *runtime.ServeMux {
+	t.Helper()
+	mux := runtime.NewServeMux()
+
+	err := mux.HandlePath("GET", "/v1/greeter/{name}", func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+		reply, err := structpb.NewStruct(map[string]interface{}{"message": "Hello, " + pathParams["name"]})
+		if err != nil {
+			runtime.HTTPError(r.Context(), mux, outbound, w, r, err)
+			return
+		}
+		runtime.ForwardResponseMessage(r.Context(), mux, outbound, w, r, reply)
+	})
+	if err != nil {
+		t.Fatalf("Failed to register greeter endpoint: %v", err)
+	}
+
+	err = mux.HandlePath("POST", "/v1/echo", func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		inbound, outbound := runtime.MarshalerForRequest(mux, r)
+		var message structpb.Struct
+		if err := inbound.NewDecoder(r.Body).Decode(&message); err != nil {
+			runtime.HTTPError(r.Context(), mux, outbound, w, r, err)
+			return
+		}
+		runtime.ForwardResponseMessage(r.Context(), mux, outbound, w, r, &message)
+	})
+	if err != nil {
+		t.Fatalf("Failed to register echo endpoint: %v", err)
+	}
+
+	return mux
+}
+
+func TestFiberServerMountGRPCGateway(t *testing.T) {
+	server := NewFiberServer(createTestConfig(), createTestLogger())
+	server.MountGRPCGateway(createTestGatewayMux(t), "/grpc")
+	app := server.GetApp()
+
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expected       map[string]interface{}
+	}{
+		{
+			name:           "path parameter transcoded",
+			method:         "GET",
+			path:           "/grpc/v1/greeter/gopher",
+			expectedStatus: http.StatusOK,
+			expected:       map[string]interface{}{"message": "Hello, gopher"},
+		},
+		{
+			name:           "request body transcoded",
+			method:         "POST",
+			path:           "/grpc/v1/echo",
+			body:           `{"amount":42,"currency":"EUR"}`,
+			expectedStatus: http.StatusOK,
+			expected:       map[string]interface{}{"amount": float64(42), "currency": "EUR"},
+		},
+		{
+			name:           "unknown gateway route",
+			method:         "GET",
+			path:           "/grpc/v1/unknown",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test gateway route: %v", err)
+			}
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.expected != nil {
+				body, _ := io.ReadAll(resp.Body)
+				var result map[string]interface{}
+				if err := json.Unmarshal(body, &result); err != nil {
+					t.Fatalf("Expected JSON response, got %s", string(body))
+				}
+				for key, want := range tc.expected {
+					if result[key] != want {
+						t.Errorf("Expected %s=%v, got %v", key, want, result[key])
+					}
+				}
+			}
+		})
+	}
+
+	// Fiber routes outside the prefix are unaffected
+	resp, err := app.Test(httptest.NewRequest("GET", "/ping", nil))
+	if err != nil {
+		t.Fatalf("Failed to test ping endpoint: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200 for /ping, got %d", resp.StatusCode)
+	}
+}
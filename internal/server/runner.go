@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
@@ -10,9 +11,18 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/spf13/viper"
 
+	"github.com/MayukhSobo/scaffold/pkg/config"
+	"github.com/MayukhSobo/scaffold/pkg/container"
 	"github.com/MayukhSobo/scaffold/pkg/log"
 )
 
+const (
+	// listenMaxRestarts is the number of times a panicking app.Listen is restarted
+	listenMaxRestarts = 3
+	// listenRestartBackoff is the wait before the first restart of app.Listen, doubled for each next one
+	listenRestartBackoff = time.Second
+)
+
 // RunServer starts the Fiber server with graceful shutdown
 func RunServer(config *viper.Viper, logger log.Logger) {
 	// Create the server
@@ -27,6 +37,12 @@ func RunServer(config *viper.Viper, logger log.Logger) {
 
 // RunFiberApp runs a Fiber app with graceful shutdown
 func RunFiberApp(app *fiber.App, config *viper.Viper, logger log.Logger) {
+	runFiberApp(app, config, logger, nil)
+}
+
+// runFiberApp runs a Fiber app with graceful shutdown. The Fiber shutdown is registered with the container's
+// shutdown registry, if any, so the container's resources are closed after it within the same deadline.
+func runFiberApp(app *fiber.App, config *viper.Viper, logger log.Logger, appContainer *container.TypedContainer) {
 	// Get port from config
 	port := config.GetString("http.port")
 	if port == "" {
@@ -37,17 +53,24 @@ func RunFiberApp(app *fiber.App, config *viper.Viper, logger log.Logger) {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
-	// Start server in a goroutine
-	go func() {
+	// Start server in a supervised goroutine, restarted when it panics
+	listenExhausted := make(chan struct{}, 1)
+	Go("fiber-listen", func() {
 		logger.Infof("Server starting on port %s", port)
 		if err := app.Listen(":" + port); err != nil {
 			logger.Errorf("Server startup failed: %v", err)
 			os.Exit(1)
 		}
-	}()
-
-	// Wait for interrupt signal
-	<-quit
+	}, logger, listenMaxRestarts, listenRestartBackoff, listenExhausted)
+
+	// Wait for interrupt signal, or for the server to keep panicking
+	exitCode := 0
+	select {
+	case <-quit:
+	case <-listenExhausted:
+		logger.Error("Server listener kept panicking, giving up")
+		exitCode = 1
+	}
 	logger.Info("Shutting down server...")
 
 	// Get shutdown timeout from config
@@ -60,19 +83,81 @@ func RunFiberApp(app *fiber.App, config *viper.Viper, logger log.Logger) {
 	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
-	// Shutdown server
-	if err := app.ShutdownWithContext(ctx); err != nil {
-		logger.Errorf("Server forced to shutdown: %v", err)
-		os.Exit(1)
+	if err := shutdown(ctx, app, logger, appContainer); err != nil {
+		exitCode = 1
+	}
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+}
+
+// shutdown stops the server, then releases the container resources within the same deadline.
+// It returns the error of the server shutdown, failures closing other resources are only logged.
+func shutdown(ctx context.Context, app *fiber.App, logger log.Logger, appContainer *container.TypedContainer) error {
+	registry := container.NewShutdownRegistry()
+	if appContainer != nil {
+		registry = appContainer.ShutdownRegistry()
+	}
+
+	serverDone := make(chan error, 1)
+	registry.Register("fiber", container.ShutdownPriorityServer, func(ctx context.Context) error {
+		err := app.ShutdownWithContext(ctx)
+		if err != nil {
+			logger.Errorf("Server forced to shutdown: %v", err)
+		} else {
+			logger.Info("Server exited")
+		}
+		serverDone <- err
+		return err
+	})
+
+	if appContainer == nil {
+		registry.Shutdown(ctx)
+	} else {
+		closeContainer(ctx, appContainer, logger)
+	}
+
+	// The server hook has not finished when closing the container was interrupted by the deadline
+	select {
+	case err := <-serverDone:
+		return err
+	default:
+		return ctx.Err()
 	}
+}
 
-	logger.Info("Server exited")
+// closeContainer runs the container's shutdown registry, closing its database, loggers and registered resources
+func closeContainer(ctx context.Context, container *container.TypedContainer, logger log.Logger) {
+	if container == nil {
+		return
+	}
+	if err := container.Close(ctx); err != nil {
+		logger.Error("Failed to close container resources", log.Error(err))
+	}
 }
 
 // RunWithCustomSetup allows custom setup before starting the server
-func RunWithCustomSetup(config *viper.Viper, logger log.Logger, setupFunc func(*FiberServer)) {
-	// Create the server
-	server := NewFiberServer(config, logger)
+func RunWithCustomSetup(conf *viper.Viper, logger log.Logger, setupFunc func(*FiberServer)) {
+	runWithSetup(NewFiberServer(conf, logger), conf, logger, setupFunc)
+}
+
+// RunWithContainer creates the server from the container and runs it after the custom setup
+func RunWithContainer(container *container.TypedContainer, setupFunc func(*FiberServer)) {
+	runWithSetup(NewFiberServerFromContainer(container), container.GetConfig(), container.GetLogger(), setupFunc)
+}
+
+// runWithSetup applies the setup function to the server and runs it with graceful shutdown
+func runWithSetup(server *FiberServer, conf *viper.Viper, logger log.Logger, setupFunc func(*FiberServer)) {
+	// Reload the log level when log.level changes in the config file, which is only watched with APP_WATCH_CONFIG=true
+	cancelLevelHook := config.OnChange(conf, "log.level", func(oldVal, newVal interface{}) {
+		if controller, ok := logger.(log.LevelController); ok {
+			controller.SetLevel(log.Level(fmt.Sprint(newVal)))
+		} else {
+			log.HotReloadLevel(fmt.Sprint(newVal))
+		}
+		logger.Info("Log level changed", log.Any("old", oldVal), log.Any("new", newVal))
+	})
+	defer cancelLevelHook()
 
 	// Apply custom setup
 	if setupFunc != nil {
@@ -83,5 +168,5 @@ func RunWithCustomSetup(config *viper.Viper, logger log.Logger, setupFunc func(*
 	app := server.GetApp()
 
 	// Run the server
-	RunFiberApp(app, config, logger)
+	runFiberApp(app, conf, logger, server.container)
 }
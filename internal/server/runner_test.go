@@ -3,14 +3,18 @@ package server
 import (
 	"bytes"
 	"context"
+	"database/sql"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/spf13/viper"
 
+	"github.com/MayukhSobo/scaffold/pkg/container"
 	"github.com/MayukhSobo/scaffold/pkg/log"
 )
 
@@ -200,7 +204,7 @@ func TestFiberServerLifecycle(t *testing.T) {
 	}
 
 	// Test that the server can handle requests
-	req := httptest.NewRequest("GET", "/health", nil)
+	req := httptest.NewRequest("GET", "/healthz", nil)
 	resp, err := app.Test(req)
 
 	if err != nil {
@@ -296,7 +300,7 @@ func TestServerConfiguration(t *testing.T) {
 
 			// Test that the server can handle basic requests
 			app := server.GetApp()
-			req := httptest.NewRequest("GET", "/health", nil)
+			req := httptest.NewRequest("GET", "/healthz", nil)
 			resp, err := app.Test(req)
 
 			if err != nil {
@@ -321,7 +325,7 @@ func TestLoggerIntegration(t *testing.T) {
 
 	// Test that the server can make requests and log them
 	app := server.GetApp()
-	req := httptest.NewRequest("GET", "/health", nil)
+	req := httptest.NewRequest("GET", "/healthz", nil)
 	_, err := app.Test(req)
 
 	if err != nil {
@@ -353,7 +357,7 @@ func BenchmarkFiberServerRequest(b *testing.B) {
 	server := NewFiberServer(config, logger)
 	app := server.GetApp()
 
-	req := httptest.NewRequest("GET", "/health", nil)
+	req := httptest.NewRequest("GET", "/healthz", nil)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -366,3 +370,48 @@ func BenchmarkFiberServerRequest(b *testing.B) {
 		}
 	}
 }
+
+func TestShutdownClosesContainerAfterServer(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open sqlite database: %v", err)
+	}
+	appContainer, err := container.NewTypedContainer(createTestConfig(), createTestLogger(), db)
+	if err != nil {
+		t.Fatalf("Failed to create container: %v", err)
+	}
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	served := make(chan struct{})
+	app.Hooks().OnListen(func(fiber.ListenData) error {
+		close(served)
+		return nil
+	})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go func() { _ = app.Listener(listener) }()
+	<-served
+
+	// Runs between the server and the database hooks
+	var logs bytes.Buffer
+	var serverExited, dbOpen bool
+	appContainer.ShutdownRegistry().Register("probe", container.ShutdownPriorityServer-1, func(ctx context.Context) error {
+		serverExited = strings.Contains(logs.String(), "Server exited")
+		dbOpen = db.PingContext(ctx) == nil
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdown(ctx, app, log.NewConsoleLoggerWithWriter(log.InfoLevel, &logs, false), appContainer); err != nil {
+		t.Fatalf("Expected a clean shutdown, got %v", err)
+	}
+	if !serverExited || !dbOpen {
+		t.Errorf("Expected the server to stop before the database closes, got server exited %v and database open %v", serverExited, dbOpen)
+	}
+	if err := db.Ping(); err == nil {
+		t.Error("Expected the database to be closed after shutdown")
+	}
+}
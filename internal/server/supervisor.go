@@ -0,0 +1,51 @@
+package server
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// Go runs fn in a goroutine and restarts it when it panics, up to maxRestarts times.
+// The panic and its stack trace are logged, and the wait before each restart starts at backoff and doubles.
+// A value is sent on exhausted, without blocking, when fn panics after the last restart; pass a buffered
+// channel to not miss it. Supervision ends when fn returns normally.
+func Go(name string, fn func(), logger log.Logger, maxRestarts int, backoff time.Duration, exhausted chan<- struct{}) {
+	go func() {
+		for restarts := 0; ; restarts++ {
+			if !runRecovered(name, fn, logger) {
+				return
+			}
+			if restarts >= maxRestarts {
+				logger.Error("Goroutine restarts exhausted", log.String("goroutine", name), log.Int("restarts", restarts))
+				select {
+				case exhausted <- struct{}{}:
+				default:
+				}
+				return
+			}
+
+			logger.Warn("Restarting goroutine", log.String("goroutine", name), log.Int("restart", restarts+1), log.Duration("backoff", backoff))
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}()
+}
+
+// runRecovered runs fn and reports whether it panicked
+func runRecovered(name string, fn func(), logger log.Logger) (panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = true
+			logger.Error("Goroutine panicked",
+				log.String("goroutine", name),
+				log.String("panic", fmt.Sprint(r)),
+				log.String("stack", string(debug.Stack())),
+			)
+		}
+	}()
+	fn()
+	return false
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// syncBuffer is a bytes.Buffer safe to write from the supervised goroutine and read from the test
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestGoRestartsPanickingFunction(t *testing.T) {
+	tests := []struct {
+		name            string
+		panics          int32
+		maxRestarts     int
+		expectCalls     int32
+		expectExhausted bool
+	}{
+		{name: "succeeds after two panics", panics: 2, maxRestarts: 3, expectCalls: 3, expectExhausted: false},
+		{name: "restarts exhausted", panics: 5, maxRestarts: 2, expectCalls: 3, expectExhausted: true},
+		{name: "no panic", panics: 0, maxRestarts: 3, expectCalls: 1, expectExhausted: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf syncBuffer
+			logger := log.NewConsoleLoggerWithWriter(log.DebugLevel, &buf, false)
+
+			var calls atomic.Int32
+			succeeded := make(chan struct{})
+			exhausted := make(chan struct{}, 1)
+			Go("worker", func() {
+				if calls.Add(1) <= tt.panics {
+					panic("boom")
+				}
+				close(succeeded)
+			}, logger, tt.maxRestarts, time.Millisecond, exhausted)
+
+			select {
+			case <-succeeded:
+				if tt.expectExhausted {
+					t.Fatal("Expected the restarts to be exhausted, got a successful run")
+				}
+			case <-exhausted:
+				if !tt.expectExhausted {
+					t.Fatal("Expected a successful run, got the restarts exhausted")
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Timed out waiting for the supervised function")
+			}
+
+			if got := calls.Load(); got != tt.expectCalls {
+				t.Errorf("Expected %d calls, got %d", tt.expectCalls, got)
+			}
+
+			output := buf.String()
+			expectPanics := min(tt.panics, tt.expectCalls)
+			if got := int32(strings.Count(output, "Goroutine panicked")); got != expectPanics {
+				t.Errorf("Expected %d logged panics, got %d", expectPanics, got)
+			}
+			if expectPanics > 0 && !strings.Contains(output, "runtime/debug.Stack") {
+				t.Error("Expected the stack trace to be logged")
+			}
+		})
+	}
+}
+
+func TestGoDoublesBackoff(t *testing.T) {
+	var buf syncBuffer
+	logger := log.NewConsoleLoggerWithWriter(log.DebugLevel, &buf, false)
+
+	exhausted := make(chan struct{}, 1)
+	Go("worker", func() { panic("boom") }, logger, 3, time.Millisecond, exhausted)
+
+	select {
+	case <-exhausted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the restarts to be exhausted")
+	}
+
+	output := buf.String()
+	for _, backoff := range []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond} {
+		if !strings.Contains(output, `"backoff":`+strconv.FormatInt(int64(backoff), 10)) {
+			t.Errorf("Expected a restart with a %v backoff, got %s", backoff, output)
+		}
+	}
+}
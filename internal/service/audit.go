@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MayukhSobo/scaffold/pkg/events"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// Audit log batching
+const (
+	// MaxAuditBatchSize is the most audit records written by a single INSERT
+	MaxAuditBatchSize = 100
+	// MaxAuditPendingRecords is the most records buffered, including those of failed INSERTs kept for a retry;
+	// the oldest records are dropped beyond it
+	MaxAuditPendingRecords = 10 * MaxAuditBatchSize
+	// DefaultAuditFlushInterval is how often buffered records are written when audit.flush_interval is unset
+	DefaultAuditFlushInterval = time.Second
+)
+
+// AuditDB is the subset of *sql.DB used by the AuditService
+type AuditDB interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// AuditRecord is a row of the audit_log table
+type AuditRecord struct {
+	EventType string
+	ActorID   uint64 // stored as NULL when 0
+	Payload   json.RawMessage
+	CreatedAt time.Time
+}
+
+// AuditService persists every domain event published on the bus to the audit_log table.
+// Records are buffered and written in the background in multi-row INSERTs of up to MaxAuditBatchSize rows,
+// once a batch is full and every flush interval, so publishers never wait for the database.
+// Records of a failed INSERT are logged and buffered again for the next flush, up to MaxAuditPendingRecords.
+type AuditService struct {
+	db     AuditDB
+	logger log.Logger
+
+	mu      sync.Mutex
+	pending []AuditRecord
+
+	full      chan struct{} // signals run that a batch is full
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewAuditService creates the service and starts writing the buffered records every flushInterval,
+// DefaultAuditFlushInterval when it is 0. Call Subscribe to receive the events and Close to flush the last ones.
+func NewAuditService(db AuditDB, logger log.Logger, flushInterval time.Duration) *AuditService {
+	if flushInterval <= 0 {
+		flushInterval = DefaultAuditFlushInterval
+	}
+	s := &AuditService{
+		db:     db,
+		logger: logger,
+		full:   make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go s.run(flushInterval)
+	return s
+}
+
+// Subscribe records every event published on bus
+func (s *AuditService) Subscribe(bus events.Bus) {
+	bus.Subscribe(events.AllEvents, s.handle)
+}
+
+// handle buffers the event as an audit record, signalling run to write the batch once it is full
+func (s *AuditService) handle(ctx context.Context, event events.Event) error {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit payload: %w", err)
+	}
+
+	record := AuditRecord{EventType: event.Type, Payload: payload, CreatedAt: event.OccurredAt}
+	if attributed, ok := event.Payload.(events.Attributed); ok {
+		record.ActorID = attributed.Actor()
+	}
+
+	s.mu.Lock()
+	s.pending = append(s.pending, record)
+	s.dropOverflowLocked()
+	full := len(s.pending) >= MaxAuditBatchSize
+	s.mu.Unlock()
+
+	if full {
+		select {
+		case s.full <- struct{}{}:
+		default: // run has a pending signal already
+		}
+	}
+	return nil
+}
+
+// Flush writes the buffered records, buffering the records of failed INSERTs again
+func (s *AuditService) Flush(ctx context.Context) {
+	s.mu.Lock()
+	batch := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+
+	if failed := s.write(ctx, batch); len(failed) > 0 {
+		s.mu.Lock()
+		s.pending = append(failed, s.pending...)
+		s.dropOverflowLocked()
+		s.mu.Unlock()
+	}
+}
+
+// Close stops the background writes and writes the buffered records, dropping those still failing
+func (s *AuditService) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.stop)
+		<-s.done
+		s.Flush(context.Background())
+
+		s.mu.Lock()
+		dropped := len(s.pending)
+		s.pending = nil
+		s.mu.Unlock()
+		if dropped > 0 {
+			s.logger.Error("Dropping unwritten audit records on close", log.Int("records", dropped))
+		}
+	})
+	return nil
+}
+
+// dropOverflowLocked drops the oldest buffered records beyond MaxAuditPendingRecords, s.mu must be held
+func (s *AuditService) dropOverflowLocked() {
+	if overflow := len(s.pending) - MaxAuditPendingRecords; overflow > 0 {
+		s.logger.Error("Dropping audit records, the buffer is full", log.Int("records", overflow))
+		s.pending = append([]AuditRecord(nil), s.pending[overflow:]...)
+	}
+}
+
+// run flushes the buffered records whenever a batch is full and every interval until Close
+func (s *AuditService) run(interval time.Duration) {
+	defer close(s.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.Flush(context.Background())
+		case <-s.full:
+			s.Flush(context.Background())
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// write inserts the records in batches of up to MaxAuditBatchSize rows and returns the records of the failed ones
+func (s *AuditService) write(ctx context.Context, records []AuditRecord) []AuditRecord {
+	var failed []AuditRecord
+	for len(records) > 0 {
+		batch := records[:min(len(records), MaxAuditBatchSize)]
+		records = records[len(batch):]
+
+		query, args := buildAuditInsert(batch)
+		if _, err := s.db.ExecContext(ctx, query, args...); err != nil {
+			s.logger.Error("Failed to write audit records", log.Int("records", len(batch)), log.Error(err))
+			failed = append(failed, batch...)
+		}
+	}
+	return failed
+}
+
+// buildAuditInsert returns a single INSERT of records into audit_log and its arguments
+func buildAuditInsert(records []AuditRecord) (string, []interface{}) {
+	var sb strings.Builder
+	sb.WriteString("INSERT INTO audit_log (event_type, actor_id, payload, created_at) VALUES ")
+
+	args := make([]interface{}, 0, len(records)*4)
+	for i, record := range records {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("(?, ?, ?, ?)")
+
+		var actorID interface{}
+		if record.ActorID != 0 {
+			actorID = record.ActorID
+		}
+		args = append(args, record.EventType, actorID, string(record.Payload), record.CreatedAt)
+	}
+	return sb.String(), args
+}
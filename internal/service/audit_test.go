@@ -0,0 +1,281 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MayukhSobo/scaffold/pkg/events"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// execCall is a statement executed on the fake audit database
+type execCall struct {
+	query string
+	args  []interface{}
+}
+
+// fakeAuditDB records the executed statements, failing them with err when set.
+// Statements wait for release to be closed when it is set.
+type fakeAuditDB struct {
+	mu      sync.Mutex
+	calls   []execCall
+	err     error
+	release chan struct{}
+}
+
+func (db *fakeAuditDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if db.release != nil {
+		<-db.release
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.calls = append(db.calls, execCall{query: query, args: args})
+	return mockResult{}, db.err
+}
+
+func (db *fakeAuditDB) setErr(err error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.err = err
+}
+
+func (db *fakeAuditDB) recorded() []execCall {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return append([]execCall(nil), db.calls...)
+}
+
+func TestBuildAuditInsert(t *testing.T) {
+	createdAt := time.Date(2025, 7, 15, 9, 0, 0, 0, time.UTC)
+	records := []AuditRecord{
+		{EventType: "user.created", ActorID: 9, Payload: []byte(`{"user_id":5}`), CreatedAt: createdAt},
+		{EventType: "user.deleted", Payload: []byte(`{}`), CreatedAt: createdAt},
+	}
+
+	query, args := buildAuditInsert(records)
+
+	expectedQuery := "INSERT INTO audit_log (event_type, actor_id, payload, created_at) VALUES (?, ?, ?, ?), (?, ?, ?, ?)"
+	if query != expectedQuery {
+		t.Errorf("Expected query %q, got %q", expectedQuery, query)
+	}
+	expectedArgs := []interface{}{"user.created", uint64(9), `{"user_id":5}`, createdAt, "user.deleted", nil, `{}`, createdAt}
+	if len(args) != len(expectedArgs) {
+		t.Fatalf("Expected %d args, got %d: %v", len(expectedArgs), len(args), args)
+	}
+	for i := range expectedArgs {
+		if args[i] != expectedArgs[i] {
+			t.Errorf("Expected arg %d to be %v, got %v", i, expectedArgs[i], args[i])
+		}
+	}
+}
+
+func TestAuditServiceBatchesInserts(t *testing.T) {
+	tests := []struct {
+		name       string
+		events     int
+		minInserts int
+	}{
+		{name: "partial batch", events: 3, minInserts: 1},
+		{name: "full batch", events: MaxAuditBatchSize, minInserts: 1},
+		{name: "several batches", events: 250, minInserts: 3},
+		{name: "no events", events: 0, minInserts: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &fakeAuditDB{}
+			audit := NewAuditService(db, log.NewConsoleLoggerWithWriter(log.InfoLevel, &bytes.Buffer{}, false), time.Hour)
+			bus := events.NewMemoryBus()
+			audit.Subscribe(bus)
+
+			for i := 0; i < tt.events; i++ {
+				if err := bus.Publish(context.Background(), events.Event{Type: EventUserCreated, Payload: UserCreated{UserID: uint64(i)}}); err != nil {
+					t.Fatalf("Publish() returned error: %v", err)
+				}
+			}
+			if err := audit.Close(); err != nil {
+				t.Fatalf("Close() returned error: %v", err)
+			}
+
+			// Full batches are written in the background, so how the records are split depends on timing
+			calls := db.recorded()
+			if len(calls) < tt.minInserts {
+				t.Fatalf("Expected at least %d INSERTs, got %d", tt.minInserts, len(calls))
+			}
+			total := 0
+			for i, call := range calls {
+				rows := strings.Count(call.query, "(?, ?, ?, ?)")
+				if rows > MaxAuditBatchSize {
+					t.Errorf("Expected INSERT %d to have at most %d rows, got %d", i, MaxAuditBatchSize, rows)
+				}
+				if len(call.args) != rows*4 {
+					t.Errorf("Expected INSERT %d to have %d args, got %d", i, rows*4, len(call.args))
+				}
+				total += rows
+			}
+			if total != tt.events {
+				t.Errorf("Expected %d rows, got %d", tt.events, total)
+			}
+		})
+	}
+}
+
+func TestAuditServiceRecord(t *testing.T) {
+	db := &fakeAuditDB{}
+	audit := NewAuditService(db, log.NewConsoleLoggerWithWriter(log.InfoLevel, &bytes.Buffer{}, false), time.Hour)
+	bus := events.NewMemoryBus()
+	audit.Subscribe(bus)
+
+	occurredAt := time.Date(2025, 7, 15, 9, 0, 0, 0, time.UTC)
+	payload := UserCreated{DomainEvent: events.DomainEvent{ActorID: 9}, UserID: 5, Username: "new", Email: "new@example.com"}
+	if err := bus.Publish(context.Background(), events.Event{Type: EventUserCreated, Payload: payload, OccurredAt: occurredAt}); err != nil {
+		t.Fatalf("Publish() returned error: %v", err)
+	}
+	_ = audit.Close()
+
+	calls := db.recorded()
+	if len(calls) != 1 {
+		t.Fatalf("Expected 1 INSERT, got %d", len(calls))
+	}
+	args := calls[0].args
+	if args[0] != EventUserCreated {
+		t.Errorf("Expected event type %q, got %v", EventUserCreated, args[0])
+	}
+	if args[1] != uint64(9) {
+		t.Errorf("Expected actor ID 9, got %v", args[1])
+	}
+	expectedPayload := `{"actor_id":9,"user_id":5,"username":"new","email":"new@example.com"}`
+	if args[2] != expectedPayload {
+		t.Errorf("Expected payload %s, got %v", expectedPayload, args[2])
+	}
+	if args[3] != occurredAt {
+		t.Errorf("Expected created_at %v, got %v", occurredAt, args[3])
+	}
+}
+
+func TestAuditServicePeriodicFlush(t *testing.T) {
+	db := &fakeAuditDB{}
+	audit := NewAuditService(db, log.NewConsoleLoggerWithWriter(log.InfoLevel, &bytes.Buffer{}, false), 10*time.Millisecond)
+	defer func() { _ = audit.Close() }()
+	bus := events.NewMemoryBus()
+	audit.Subscribe(bus)
+
+	if err := bus.Publish(context.Background(), events.Event{Type: EventUserCreated, Payload: UserCreated{UserID: 1}}); err != nil {
+		t.Fatalf("Publish() returned error: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(db.recorded()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the buffered record to be written by the periodic flush")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestAuditServiceWriteFailure(t *testing.T) {
+	var buf bytes.Buffer
+	db := &fakeAuditDB{err: errors.New("table audit_log does not exist")}
+	audit := NewAuditService(db, log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false), time.Hour)
+	bus := events.NewMemoryBus()
+	audit.Subscribe(bus)
+
+	if err := bus.Publish(context.Background(), events.Event{Type: EventUserCreated, Payload: UserCreated{UserID: 1}}); err != nil {
+		t.Fatalf("Publish() returned error: %v", err)
+	}
+	_ = audit.Close()
+
+	if !strings.Contains(buf.String(), "Failed to write audit records") || !strings.Contains(buf.String(), "table audit_log does not exist") {
+		t.Errorf("Expected the failed INSERT to be logged, got %s", buf.String())
+	}
+}
+
+func TestAuditServiceFullBatchWrittenInBackground(t *testing.T) {
+	db := &fakeAuditDB{release: make(chan struct{})}
+	audit := NewAuditService(db, log.NewConsoleLoggerWithWriter(log.InfoLevel, &bytes.Buffer{}, false), time.Hour)
+	bus := events.NewMemoryBus()
+	audit.Subscribe(bus)
+
+	// The INSERT blocks until release is closed, so publishing a full batch must not wait for it
+	published := make(chan error, 1)
+	go func() {
+		for i := 0; i < MaxAuditBatchSize; i++ {
+			if err := bus.Publish(context.Background(), events.Event{Type: EventUserCreated, Payload: UserCreated{UserID: uint64(i)}}); err != nil {
+				published <- err
+				return
+			}
+		}
+		published <- nil
+	}()
+
+	select {
+	case err := <-published:
+		if err != nil {
+			t.Fatalf("Publish() returned error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected Publish to return without waiting for the INSERT")
+	}
+	close(db.release)
+	_ = audit.Close()
+
+	total := 0
+	for _, call := range db.recorded() {
+		total += strings.Count(call.query, "(?, ?, ?, ?)")
+	}
+	if total != MaxAuditBatchSize {
+		t.Errorf("Expected %d rows, got %d", MaxAuditBatchSize, total)
+	}
+}
+
+func TestAuditServiceRetriesFailedRecords(t *testing.T) {
+	db := &fakeAuditDB{err: errors.New("connection refused")}
+	audit := NewAuditService(db, log.NewConsoleLoggerWithWriter(log.InfoLevel, &bytes.Buffer{}, false), time.Hour)
+	bus := events.NewMemoryBus()
+	audit.Subscribe(bus)
+
+	if err := bus.Publish(context.Background(), events.Event{Type: EventUserCreated, Payload: UserCreated{UserID: 1}}); err != nil {
+		t.Fatalf("Publish() returned error: %v", err)
+	}
+	audit.Flush(context.Background())
+	db.setErr(nil)
+	_ = audit.Close()
+
+	calls := db.recorded()
+	if len(calls) != 2 {
+		t.Fatalf("Expected the failed INSERT to be retried, got %d INSERTs", len(calls))
+	}
+	if calls[1].args[0] != EventUserCreated {
+		t.Errorf("Expected the retried record to be %q, got %v", EventUserCreated, calls[1].args[0])
+	}
+}
+
+func TestAuditServicePendingRecordsBounded(t *testing.T) {
+	var buf bytes.Buffer
+	db := &fakeAuditDB{err: errors.New("connection refused")}
+	audit := NewAuditService(db, log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false), time.Hour)
+
+	for i := 0; i < MaxAuditPendingRecords+10; i++ {
+		if err := audit.handle(context.Background(), events.Event{Type: EventUserCreated, Payload: UserCreated{UserID: uint64(i)}}); err != nil {
+			t.Fatalf("handle() returned error: %v", err)
+		}
+	}
+	audit.Flush(context.Background())
+
+	audit.mu.Lock()
+	pending := len(audit.pending)
+	audit.mu.Unlock()
+	if pending > MaxAuditPendingRecords {
+		t.Errorf("Expected at most %d buffered records, got %d", MaxAuditPendingRecords, pending)
+	}
+	_ = audit.Close()
+	if !strings.Contains(buf.String(), "Dropping audit records, the buffer is full") {
+		t.Errorf("Expected the dropped records to be logged, got %s", buf.String())
+	}
+}
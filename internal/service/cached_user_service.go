@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MayukhSobo/scaffold/internal/repository/users"
+	"github.com/MayukhSobo/scaffold/pkg/cache"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// DefaultUserCacheTTL is how long user lookups are cached when service.cache.user_ttl is unset
+const DefaultUserCacheTTL = time.Minute
+
+// Cache keys of the user lookups
+const (
+	userCacheKeyPrefix   = "users:id:"
+	usersCacheKey        = "users:all"
+	adminUsersCacheKey   = "users:admins"
+	pendingUsersCacheKey = "users:pending_verification"
+)
+
+// CachedUserService caches the user lookups of the wrapped UserService for ttl. Errors are not cached.
+// Password hashes are never cached, possibly in a shared Redis, so the cached lookups return users without them.
+// Cache hits do not reach the wrapped service, so they are audited through service with the same actions.
+// CreateUser invalidates the cached user lists; other changes show up once the entries expire.
+type CachedUserService struct {
+	UserService
+	service *Service
+	cache   cache.Cache
+	ttl     time.Duration
+}
+
+// NewCachedUserService wraps next with a cache, ttl 0 uses DefaultUserCacheTTL. service audits the cache hits.
+func NewCachedUserService(service *Service, next UserService, c cache.Cache, ttl time.Duration) *CachedUserService {
+	if ttl <= 0 {
+		ttl = DefaultUserCacheTTL
+	}
+	return &CachedUserService{UserService: next, service: service, cache: c, ttl: ttl}
+}
+
+func (s *CachedUserService) GetUserById(ctx context.Context, id int64) (users.User, error) {
+	key := fmt.Sprintf("%s%d", userCacheKeyPrefix, id)
+	if cached, ok := s.cache.Get(key); ok {
+		if user, ok := cache.As[users.User](cached); ok {
+			s.service.audit(ctx, "users.get", log.Int64("target_id", id))
+			return user, nil
+		}
+	}
+
+	user, err := s.UserService.GetUserById(ctx, id)
+	if err != nil {
+		return users.User{}, err
+	}
+	user.PasswordHash = ""
+	s.cache.Set(key, user, s.ttl)
+	return user, nil
+}
+
+func (s *CachedUserService) GetUsers(ctx context.Context) ([]users.User, error) {
+	return s.cachedList(ctx, usersCacheKey, "users.list", s.UserService.GetUsers)
+}
+
+func (s *CachedUserService) GetAdminUsers(ctx context.Context) ([]users.User, error) {
+	return s.cachedList(ctx, adminUsersCacheKey, "users.list_admins", s.UserService.GetAdminUsers)
+}
+
+func (s *CachedUserService) GetPendingVerificationUsers(ctx context.Context) ([]users.User, error) {
+	return s.cachedList(ctx, pendingUsersCacheKey, "users.list_pending", s.UserService.GetPendingVerificationUsers)
+}
+
+// CreateUser creates the user and invalidates the cached user lists, which may now miss it
+func (s *CachedUserService) CreateUser(ctx context.Context, req CreateUserRequest) (users.User, error) {
+	user, err := s.UserService.CreateUser(ctx, req)
+	if err != nil {
+		return users.User{}, err
+	}
+	for _, key := range []string{usersCacheKey, adminUsersCacheKey, pendingUsersCacheKey} {
+		s.cache.Delete(key)
+	}
+	return user, nil
+}
+
+// cachedList returns a copy of the list cached under key, auditing the hit as action, and loads and caches it
+// with load on a miss.
+// Copies keep callers from modifying the cached list, and loaded lists are copied without password hashes.
+func (s *CachedUserService) cachedList(ctx context.Context, key, action string, load func(context.Context) ([]users.User, error)) ([]users.User, error) {
+	if cached, ok := s.cache.Get(key); ok {
+		if list, ok := cache.As[[]users.User](cached); ok {
+			s.service.audit(ctx, action)
+			return append([]users.User(nil), list...), nil
+		}
+	}
+
+	loaded, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]users.User, len(loaded))
+	for i, user := range loaded {
+		user.PasswordHash = ""
+		list[i] = user
+	}
+	s.cache.Set(key, append([]users.User(nil), list...), s.ttl)
+	return list, nil
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/MayukhSobo/scaffold/internal/repository/users"
+	"github.com/MayukhSobo/scaffold/internal/repository/users/mocks"
+	"github.com/MayukhSobo/scaffold/pkg/cache"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+func setupCachedUserService(t *testing.T) (*CachedUserService, *mocks.Querier) {
+	cached, querier, _ := setupCachedUserServiceWithCache(t, cache.NewMemoryCache(time.Minute))
+	return cached, querier
+}
+
+// setupCachedUserServiceWithCache returns a CachedUserService caching in c and the buffer of its audit log
+func setupCachedUserServiceWithCache(t *testing.T, c cache.Cache) (*CachedUserService, *mocks.Querier, *bytes.Buffer) {
+	var buf bytes.Buffer
+	baseService := NewService(log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false))
+	querier := mocks.NewQuerier(t)
+	userService := NewUserService(baseService, querier, TokenConfig{Secret: []byte("test-secret")})
+	return NewCachedUserService(baseService, userService, c, time.Minute), querier, &buf
+}
+
+func TestCachedUserServiceGetAdminUsers(t *testing.T) {
+	cached, querier := setupCachedUserService(t)
+	// Once makes the mock fail the test if the repository is called again
+	querier.On("GetAdminUsers", mock.Anything).
+		Return([]users.User{{ID: 1, Username: "admin"}}, nil).Once()
+
+	for i := 0; i < 2; i++ {
+		adminUsers, err := cached.GetAdminUsers(context.Background())
+		if err != nil {
+			t.Fatalf("GetAdminUsers() returned error: %v", err)
+		}
+		if len(adminUsers) != 1 || adminUsers[0].Username != "admin" {
+			t.Errorf("Expected the admin user, got %v", adminUsers)
+		}
+		// Modifying the result must not change the cached list
+		adminUsers[0].Username = "modified"
+	}
+	querier.AssertNumberOfCalls(t, "GetAdminUsers", 1)
+}
+
+func TestCachedUserServiceGetUserById(t *testing.T) {
+	cached, querier := setupCachedUserService(t)
+	querier.On("GetUser", mock.Anything, uint64(1)).
+		Return(users.User{ID: 1, Username: "testuser"}, nil).Once()
+	querier.On("GetUser", mock.Anything, uint64(2)).
+		Return(users.User{ID: 2, Username: "other"}, nil).Once()
+
+	for _, id := range []int64{1, 1, 2, 2} {
+		user, err := cached.GetUserById(context.Background(), id)
+		if err != nil {
+			t.Fatalf("GetUserById() returned error: %v", err)
+		}
+		if user.ID != uint64(id) {
+			t.Errorf("Expected user ID %d, got %d", id, user.ID)
+		}
+	}
+	querier.AssertNumberOfCalls(t, "GetUser", 2)
+}
+
+func TestCachedUserServiceErrorsNotCached(t *testing.T) {
+	cached, querier := setupCachedUserService(t)
+	querier.On("GetUsers", mock.Anything).Return(nil, errors.New("connection refused")).Once()
+	querier.On("GetUsers", mock.Anything).Return([]users.User{{ID: 1}}, nil).Once()
+
+	if _, err := cached.GetUsers(context.Background()); err == nil {
+		t.Fatal("Expected the repository error")
+	}
+	allUsers, err := cached.GetUsers(context.Background())
+	if err != nil {
+		t.Fatalf("GetUsers() returned error: %v", err)
+	}
+	if len(allUsers) != 1 {
+		t.Errorf("Expected 1 user, got %d", len(allUsers))
+	}
+}
+
+func TestCachedUserServiceCreateUserInvalidatesLists(t *testing.T) {
+	cached, querier := setupCachedUserService(t)
+	querier.On("GetPendingVerificationUsers", mock.Anything).Return([]users.User{}, nil).Once()
+	querier.On("CreateUser", mock.Anything, mock.Anything).Return(mockResult{lastInsertID: 3}, nil).Once()
+	querier.On("GetUser", mock.Anything, uint64(3)).Return(users.User{ID: 3}, nil).Once()
+	querier.On("GetPendingVerificationUsers", mock.Anything).Return([]users.User{{ID: 3}}, nil).Once()
+
+	if _, err := cached.GetPendingVerificationUsers(context.Background()); err != nil {
+		t.Fatalf("GetPendingVerificationUsers() returned error: %v", err)
+	}
+	if _, err := cached.CreateUser(context.Background(), CreateUserRequest{Username: "new", Email: "new@example.com", Password: "secret"}); err != nil {
+		t.Fatalf("CreateUser() returned error: %v", err)
+	}
+
+	pending, err := cached.GetPendingVerificationUsers(context.Background())
+	if err != nil {
+		t.Fatalf("GetPendingVerificationUsers() returned error: %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != 3 {
+		t.Errorf("Expected the created user after invalidation, got %v", pending)
+	}
+}
+
+func TestCachedUserServiceStripsPasswordHashes(t *testing.T) {
+	memory := cache.NewMemoryCache(time.Minute)
+	cached, querier, _ := setupCachedUserServiceWithCache(t, memory)
+	querier.On("GetUser", mock.Anything, uint64(1)).
+		Return(users.User{ID: 1, PasswordHash: "hash"}, nil).Once()
+	querier.On("GetUsers", mock.Anything).
+		Return([]users.User{{ID: 1, PasswordHash: "hash"}}, nil).Once()
+
+	user, err := cached.GetUserById(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetUserById() returned error: %v", err)
+	}
+	if user.PasswordHash != "" {
+		t.Errorf("Expected no password hash, got %q", user.PasswordHash)
+	}
+	allUsers, err := cached.GetUsers(context.Background())
+	if err != nil {
+		t.Fatalf("GetUsers() returned error: %v", err)
+	}
+	if allUsers[0].PasswordHash != "" {
+		t.Errorf("Expected no password hash, got %q", allUsers[0].PasswordHash)
+	}
+
+	for _, key := range []string{"users:id:1", "users:all"} {
+		val, ok := memory.Get(key)
+		if !ok {
+			t.Fatalf("Expected %s to be cached", key)
+		}
+		encoded, err := json.Marshal(val)
+		if err != nil {
+			t.Fatalf("Failed to encode %s: %v", key, err)
+		}
+		if strings.Contains(string(encoded), `"hash"`) {
+			t.Errorf("Expected %s to be cached without the password hash, got %s", key, encoded)
+		}
+	}
+}
+
+func TestCachedUserServiceAuditsCacheHits(t *testing.T) {
+	cached, querier, buf := setupCachedUserServiceWithCache(t, cache.NewMemoryCache(time.Minute))
+	querier.On("GetUser", mock.Anything, uint64(1)).Return(users.User{ID: 1}, nil).Once()
+	querier.On("GetAdminUsers", mock.Anything).Return([]users.User{{ID: 1}}, nil).Once()
+
+	tests := []struct {
+		action string
+		call   func() error
+	}{
+		{"users.get", func() error { _, err := cached.GetUserById(context.Background(), 1); return err }},
+		{"users.list_admins", func() error { _, err := cached.GetAdminUsers(context.Background()); return err }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			for i := 0; i < 2; i++ {
+				if err := tt.call(); err != nil {
+					t.Fatalf("Call %d returned error: %v", i, err)
+				}
+			}
+			if got := strings.Count(buf.String(), tt.action+"\""); got != 2 {
+				t.Errorf("Expected 2 %s audit entries, one for the miss and one for the hit, got %d", tt.action, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import "errors"
+
+// Error kinds returned by the service layer, the handler layer maps them to HTTP status codes with errors.Is
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrValidation   = errors.New("validation failed")
+)
+
+// ServiceError wraps one of the error kinds with a message that is safe to show to clients.
+// Err keeps the underlying cause for errors.Is and errors.As but is left out of Error,
+// so the error can be logged and returned without leaking queries or credentials.
+type ServiceError struct {
+	Kind    error             // One of ErrNotFound, ErrConflict, ErrUnauthorized or ErrValidation
+	Message string            // Human-readable message, eg: "user not found"
+	Code    string            // Optional machine-readable code, eg: USER_NOT_FOUND
+	Details map[string]string // Optional field-level details, field name to problem
+	Err     error             // Optional underlying cause
+}
+
+// NewServiceError creates a ServiceError of the given kind
+func NewServiceError(kind error, message, code string) *ServiceError {
+	return &ServiceError{Kind: kind, Message: message, Code: code}
+}
+
+// WithDetail adds a field-level detail and returns e
+func (e *ServiceError) WithDetail(field, problem string) *ServiceError {
+	if e.Details == nil {
+		e.Details = make(map[string]string)
+	}
+	e.Details[field] = problem
+	return e
+}
+
+// WithCause records the underlying error and returns e
+func (e *ServiceError) WithCause(err error) *ServiceError {
+	e.Err = err
+	return e
+}
+
+func (e *ServiceError) Error() string {
+	if e.Kind == nil {
+		return e.Message
+	}
+	return e.Kind.Error() + ": " + e.Message
+}
+
+// Unwrap exposes both the kind and the cause to errors.Is and errors.As
+func (e *ServiceError) Unwrap() []error {
+	var errs []error
+	if e.Kind != nil {
+		errs = append(errs, e.Kind)
+	}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestServiceErrorKinds(t *testing.T) {
+	cause := fmt.Errorf("query users where id = 42: %w", sql.ErrNoRows)
+	err := fmt.Errorf("get user: %w", NewServiceError(ErrNotFound, "user not found", "USER_NOT_FOUND").WithCause(cause))
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("Expected errors.Is to match ErrNotFound")
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Error("Expected errors.Is to match the underlying cause")
+	}
+	for _, other := range []error{ErrConflict, ErrUnauthorized, ErrValidation} {
+		if errors.Is(err, other) {
+			t.Errorf("Expected errors.Is not to match %v", other)
+		}
+	}
+
+	var serr *ServiceError
+	if !errors.As(err, &serr) || serr.Code != "USER_NOT_FOUND" {
+		t.Errorf("Expected a *ServiceError with code USER_NOT_FOUND, got %v", err)
+	}
+}
+
+func TestServiceErrorStringIsSafeToLog(t *testing.T) {
+	err := NewServiceError(ErrValidation, "invalid user", "").
+		WithDetail("password", "must not contain the username").
+		WithCause(errors.New("dial tcp: user=root password=hunter2"))
+
+	if got := err.Error(); got != "validation failed: invalid user" {
+		t.Errorf("Expected 'validation failed: invalid user', got '%s'", got)
+	}
+	if strings.Contains(err.Error(), "hunter2") || strings.Contains(err.Error(), "password") {
+		t.Errorf("Expected Error() to leave out the cause and details, got '%s'", err.Error())
+	}
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+
+	"github.com/MayukhSobo/scaffold/pkg/events"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+	"github.com/MayukhSobo/scaffold/pkg/utils"
+)
+
+// Domain event types published by the services
+const (
+	EventUserCreated = "user.created"
+)
+
+// UserCreated is the payload of EventUserCreated
+type UserCreated struct {
+	events.DomainEvent
+	UserID   uint64 `json:"user_id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+// WithEventBus sets the bus the service publishes its domain events on and returns the service.
+// Events are dropped when no bus is set.
+func (s *Service) WithEventBus(bus events.Bus) *Service {
+	s.events = bus
+	return s
+}
+
+// domainEvent returns the DomainEvent attributing an event to the caller in ctx
+func domainEvent(ctx context.Context) events.DomainEvent {
+	rc, _ := utils.GetRequestContext(ctx)
+	return events.DomainEvent{ActorID: rc.UserID}
+}
+
+// emit publishes a domain event. Publishing failures are logged, the change the event reports already happened.
+func (s *Service) emit(ctx context.Context, eventType string, payload interface{}) {
+	if s.events == nil {
+		return
+	}
+	if err := s.events.Publish(ctx, events.Event{Type: eventType, Payload: payload}); err != nil {
+		s.contextLogger(ctx).Error("Failed to publish event", log.String("event_type", eventType), log.Error(err))
+	}
+}
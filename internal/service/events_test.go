@@ -0,0 +1,58 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/MayukhSobo/scaffold/internal/repository/users"
+	"github.com/MayukhSobo/scaffold/internal/repository/users/mocks"
+	"github.com/MayukhSobo/scaffold/pkg/events"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+	"github.com/MayukhSobo/scaffold/pkg/utils"
+)
+
+func TestUserServiceCreateUserEmitsUserCreated(t *testing.T) {
+	bus := events.NewMemoryBus()
+	var published []events.Event
+	bus.Subscribe(EventUserCreated, func(ctx context.Context, event events.Event) error {
+		published = append(published, event)
+		return nil
+	})
+
+	logger := log.NewConsoleLoggerWithWriter(log.InfoLevel, &bytes.Buffer{}, false)
+	querier := mocks.NewQuerier(t)
+	querier.On("CreateUser", mock.Anything, mock.Anything).Return(mockResult{lastInsertID: 5}, nil).Once()
+	querier.On("GetUser", mock.Anything, uint64(5)).
+		Return(users.User{ID: 5, Username: "new", Email: "new@example.com"}, nil).Once()
+	userService := NewUserService(NewService(logger).WithEventBus(bus), querier, TokenConfig{Secret: []byte("test-secret")})
+
+	ctx := utils.WithRequestContext(context.Background(), utils.RequestContext{UserID: 9})
+	if _, err := userService.CreateUser(ctx, CreateUserRequest{Username: "new", Email: "new@example.com", Password: "secret"}); err != nil {
+		t.Fatalf("CreateUser() returned error: %v", err)
+	}
+
+	if len(published) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(published))
+	}
+	payload, ok := published[0].Payload.(UserCreated)
+	if !ok {
+		t.Fatalf("Expected a UserCreated payload, got %T", published[0].Payload)
+	}
+	expected := UserCreated{DomainEvent: events.DomainEvent{ActorID: 9}, UserID: 5, Username: "new", Email: "new@example.com"}
+	if payload != expected {
+		t.Errorf("Expected payload %+v, got %+v", expected, payload)
+	}
+}
+
+func TestUserServiceCreateUserWithoutEventBus(t *testing.T) {
+	userService, querier := setupTestsWithQuerierMock(t)
+	querier.On("CreateUser", mock.Anything, mock.Anything).Return(mockResult{lastInsertID: 5}, nil).Once()
+	querier.On("GetUser", mock.Anything, uint64(5)).Return(users.User{ID: 5}, nil).Once()
+
+	if _, err := userService.CreateUser(context.Background(), CreateUserRequest{Username: "new", Email: "new@example.com", Password: "secret"}); err != nil {
+		t.Fatalf("CreateUser() returned error: %v", err)
+	}
+}
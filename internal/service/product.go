@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+
+	"github.com/MayukhSobo/scaffold/internal/repository/products"
+)
+
+type ProductService interface {
+	GetProduct(ctx context.Context, id int64) (products.Product, error)
+	GetProducts(ctx context.Context) ([]products.Product, error)
+	CreateProduct(ctx context.Context, arg products.CreateProductParams) (products.Product, error)
+}
+
+type productService struct {
+	*Service
+	productRepository products.Querier
+}
+
+func NewProductService(service *Service, productRepository products.Querier) ProductService {
+	return &productService{
+		Service:           service,
+		productRepository: productRepository,
+	}
+}
+
+func (s *productService) GetProduct(ctx context.Context, id int64) (products.Product, error) {
+	return s.productRepository.GetProduct(ctx, uint64(id))
+}
+
+func (s *productService) GetProducts(ctx context.Context) ([]products.Product, error) {
+	return s.productRepository.GetProducts(ctx)
+}
+
+// CreateProduct inserts a new product and returns the stored row
+func (s *productService) CreateProduct(ctx context.Context, arg products.CreateProductParams) (products.Product, error) {
+	result, err := s.productRepository.CreateProduct(ctx, arg)
+	if err != nil {
+		return products.Product{}, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return products.Product{}, err
+	}
+
+	return s.productRepository.GetProduct(ctx, uint64(id))
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/MayukhSobo/scaffold/internal/repository/products"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// mockProductRepository implements products.Querier for testing
+type mockProductRepository struct {
+	products  []products.Product
+	createErr error
+}
+
+// mockResult implements sql.Result for the mock repository
+type mockResult struct {
+	lastInsertID int64
+}
+
+func (r mockResult) LastInsertId() (int64, error) { return r.lastInsertID, nil }
+func (r mockResult) RowsAffected() (int64, error) { return 1, nil }
+
+func (m *mockProductRepository) CreateProduct(ctx context.Context, arg products.CreateProductParams) (sql.Result, error) {
+	if m.createErr != nil {
+		return nil, m.createErr
+	}
+	id := uint64(len(m.products) + 1)
+	m.products = append(m.products, products.Product{
+		ID:          id,
+		Name:        arg.Name,
+		Description: arg.Description,
+		PriceCents:  arg.PriceCents,
+	})
+	return mockResult{lastInsertID: int64(id)}, nil
+}
+
+func (m *mockProductRepository) GetProduct(ctx context.Context, id uint64) (products.Product, error) {
+	for _, product := range m.products {
+		if product.ID == id {
+			return product, nil
+		}
+	}
+	return products.Product{}, sql.ErrNoRows
+}
+
+func (m *mockProductRepository) GetProducts(ctx context.Context) ([]products.Product, error) {
+	return m.products, nil
+}
+
+// setupProductTestsWithMock initializes the product service with a mock repository
+func setupProductTestsWithMock(t *testing.T) (ProductService, *mockProductRepository) {
+	var buf bytes.Buffer
+	logger := log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false)
+
+	mockRepo := &mockProductRepository{
+		products: []products.Product{
+			{ID: 1, Name: "Widget", PriceCents: 1999},
+		},
+	}
+
+	return NewProductService(NewService(logger), mockRepo), mockRepo
+}
+
+func TestProductServiceGetProduct(t *testing.T) {
+	productService, _ := setupProductTestsWithMock(t)
+
+	product, err := productService.GetProduct(context.Background(), 1)
+	if err != nil {
+		t.Errorf("GetProduct() returned error: %v", err)
+	}
+	if product.Name != "Widget" {
+		t.Errorf("Expected product name 'Widget', got %s", product.Name)
+	}
+
+	_, err = productService.GetProduct(context.Background(), 999)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows for missing product, got %v", err)
+	}
+}
+
+func TestProductServiceGetProducts(t *testing.T) {
+	productService, _ := setupProductTestsWithMock(t)
+
+	allProducts, err := productService.GetProducts(context.Background())
+	if err != nil {
+		t.Errorf("GetProducts() returned error: %v", err)
+	}
+	if len(allProducts) != 1 {
+		t.Errorf("Expected 1 product, got %d", len(allProducts))
+	}
+}
+
+func TestProductServiceCreateProduct(t *testing.T) {
+	productService, mockRepo := setupProductTestsWithMock(t)
+
+	product, err := productService.CreateProduct(context.Background(), products.CreateProductParams{
+		Name:       "Gadget",
+		PriceCents: 500,
+	})
+	if err != nil {
+		t.Fatalf("CreateProduct() returned error: %v", err)
+	}
+	if product.ID != 2 {
+		t.Errorf("Expected product ID 2, got %d", product.ID)
+	}
+	if product.Name != "Gadget" {
+		t.Errorf("Expected product name 'Gadget', got %s", product.Name)
+	}
+
+	mockRepo.createErr = errors.New("insert failed")
+	if _, err := productService.CreateProduct(context.Background(), products.CreateProductParams{Name: "Broken"}); err == nil {
+		t.Error("Expected error when repository insert fails")
+	}
+}
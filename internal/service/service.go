@@ -1,9 +1,16 @@
 package service
 
-import "github.com/MayukhSobo/scaffold/pkg/log"
+import (
+	"context"
+
+	"github.com/MayukhSobo/scaffold/pkg/events"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+	"github.com/MayukhSobo/scaffold/pkg/utils"
+)
 
 type Service struct {
 	logger log.Logger
+	events events.Bus // domain events are published on it, see WithEventBus
 }
 
 func NewService(logger log.Logger) *Service {
@@ -11,3 +18,35 @@ func NewService(logger log.Logger) *Service {
 		logger: logger,
 	}
 }
+
+// contextLogger returns the service logger enriched with the caller's utils.RequestContext
+func (s *Service) contextLogger(ctx context.Context) log.Logger {
+	rc, ok := utils.GetRequestContext(ctx)
+	if !ok {
+		return s.logger
+	}
+
+	var fields []log.Field
+	if rc.RequestID != "" {
+		fields = append(fields, log.String("request_id", rc.RequestID))
+	}
+	if rc.TraceID != "" {
+		fields = append(fields, log.String("trace_id", rc.TraceID))
+	}
+	if rc.UserID != 0 {
+		fields = append(fields, log.Any("actor_id", rc.UserID))
+	}
+	if rc.Role != "" {
+		fields = append(fields, log.String("actor_role", rc.Role))
+	}
+
+	if len(fields) == 0 {
+		return s.logger
+	}
+	return s.logger.WithFields(fields...)
+}
+
+// audit records an access event attributed to the caller in ctx
+func (s *Service) audit(ctx context.Context, action string, fields ...log.Field) {
+	s.contextLogger(ctx).Info("Audit", append([]log.Field{log.String("action", action)}, fields...)...)
+}
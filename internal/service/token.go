@@ -0,0 +1,90 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/viper"
+
+	"github.com/MayukhSobo/scaffold/internal/repository/users"
+)
+
+// Token lifetimes used when none are configured
+const (
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// TokenConfig holds the settings used to issue access and refresh tokens
+type TokenConfig struct {
+	Secret     []byte        // Signs access tokens and keys the refresh token HMAC
+	AccessTTL  time.Duration // Lifetime of access tokens
+	RefreshTTL time.Duration // Lifetime of refresh tokens
+}
+
+// JWTSecret returns server.jwt.secret, falling back to security.jwt.key. Tokens are signed and verified
+// with it everywhere, so a token issued by the service passes every JWT middleware.
+func JWTSecret(conf *viper.Viper) string {
+	if secret := conf.GetString("server.jwt.secret"); secret != "" {
+		return secret
+	}
+	return conf.GetString("security.jwt.key")
+}
+
+// NewTokenConfig reads the token settings from the security.jwt section, signing with JWTSecret
+func NewTokenConfig(conf *viper.Viper) TokenConfig {
+	return TokenConfig{
+		Secret:     []byte(JWTSecret(conf)),
+		AccessTTL:  conf.GetDuration("security.jwt.access_ttl"),
+		RefreshTTL: conf.GetDuration("security.jwt.refresh_ttl"),
+	}.withDefaults()
+}
+
+// withDefaults fills unset lifetimes
+func (t TokenConfig) withDefaults() TokenConfig {
+	if t.AccessTTL <= 0 {
+		t.AccessTTL = defaultAccessTokenTTL
+	}
+	if t.RefreshTTL <= 0 {
+		t.RefreshTTL = defaultRefreshTokenTTL
+	}
+	return t
+}
+
+// hashRefreshToken returns the hex HMAC-SHA256 of the token, which is what gets stored
+func (t TokenConfig) hashRefreshToken(token string) string {
+	mac := hmac.New(sha256.New, t.Secret)
+	mac.Write([]byte(token))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// signAccessToken issues an HS256 access token with the claims read by the JWT middleware
+func (t TokenConfig) signAccessToken(user users.User) (string, error) {
+	now := time.Now()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":  strconv.FormatUint(user.ID, 10),
+		"role": string(user.Role),
+		"iat":  now.Unix(),
+		"exp":  now.Add(t.AccessTTL).Unix(),
+	}).SignedString(t.Secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign access token: %w", err)
+	}
+	return token, nil
+}
+
+// generateRefreshToken returns a random, URL-safe refresh token
+func generateRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
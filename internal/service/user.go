@@ -2,36 +2,246 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/MayukhSobo/scaffold/internal/repository/users"
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// Refresh token errors, all of which should be reported to clients as 401
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenExpired = errors.New("refresh token expired")
+	ErrRefreshTokenReused  = errors.New("refresh token already used")
 )
 
+// CreateUserRequest is the input for creating a user; Password is stored as a bcrypt hash
+type CreateUserRequest struct {
+	Username  string `json:"username" validate:"required"`
+	Email     string `json:"email" validate:"required,email"`
+	Password  string `json:"password" validate:"required"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
 type UserService interface {
 	GetUserById(ctx context.Context, id int64) (users.User, error)
+	GetUsers(ctx context.Context) ([]users.User, error)
 	GetAdminUsers(ctx context.Context) ([]users.User, error)
 	GetPendingVerificationUsers(ctx context.Context) ([]users.User, error)
+	CreateUser(ctx context.Context, req CreateUserRequest) (users.User, error)
+	RefreshAccessToken(ctx context.Context, refreshToken string) (accessToken, newRefreshToken string, err error)
 }
 
 type userService struct {
 	*Service
 	userRepository users.Querier
+	tokens         TokenConfig
+	inTx           TxRunner // nil runs the grouped queries on userRepository without a transaction
+}
+
+// TxRunner runs fn with user queries bound to a single transaction, committed when fn returns nil
+// and rolled back otherwise
+type TxRunner func(ctx context.Context, fn func(q users.Querier) error) error
+
+// NewTxRunner returns a TxRunner beginning its transactions on db
+func NewTxRunner(db *sql.DB) TxRunner {
+	return func(ctx context.Context, fn func(q users.Querier) error) error {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		if err := fn(users.New(tx)); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return nil
+	}
+}
+
+// UserServiceOption configures NewUserService
+type UserServiceOption func(*userService)
+
+// WithTransactions runs the queries that must succeed or fail together, such as the refresh token rotation,
+// in transactions of runner
+func WithTransactions(runner TxRunner) UserServiceOption {
+	return func(s *userService) {
+		s.inTx = runner
+	}
 }
 
-func NewUserService(service *Service, userRepository users.Querier) UserService {
-	return &userService{
+func NewUserService(service *Service, userRepository users.Querier, tokens TokenConfig, opts ...UserServiceOption) UserService {
+	s := &userService{
 		Service:        service,
 		userRepository: userRepository,
+		tokens:         tokens.withDefaults(),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// runInTx runs fn in a transaction when WithTransactions is set, otherwise on userRepository
+func (s *userService) runInTx(ctx context.Context, fn func(q users.Querier) error) error {
+	if s.inTx == nil {
+		return fn(s.userRepository)
+	}
+	return s.inTx(ctx, fn)
 }
 
+// GetUserById returns the user with the given ID, or an ErrNotFound ServiceError if there is none
 func (s *userService) GetUserById(ctx context.Context, id int64) (users.User, error) {
-	return s.userRepository.GetUser(ctx, uint64(id))
+	s.audit(ctx, "users.get", log.Int64("target_id", id))
+
+	user, err := s.userRepository.GetUser(ctx, uint64(id))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return users.User{}, err
+	}
+	if err != nil || user.ID == 0 {
+		return users.User{}, NewServiceError(ErrNotFound, "user not found", "USER_NOT_FOUND").WithCause(err)
+	}
+	return user, nil
+}
+
+func (s *userService) GetUsers(ctx context.Context) ([]users.User, error) {
+	s.audit(ctx, "users.list")
+	return s.userRepository.GetUsers(ctx)
 }
 
 func (s *userService) GetAdminUsers(ctx context.Context) ([]users.User, error) {
+	s.audit(ctx, "users.list_admins")
 	return s.userRepository.GetAdminUsers(ctx)
 }
 
 func (s *userService) GetPendingVerificationUsers(ctx context.Context) ([]users.User, error) {
+	s.audit(ctx, "users.list_pending")
 	return s.userRepository.GetPendingVerificationUsers(ctx)
 }
+
+// CreateUser stores a new user with a hashed password and returns the stored row
+func (s *userService) CreateUser(ctx context.Context, req CreateUserRequest) (users.User, error) {
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return users.User{}, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	result, err := s.userRepository.CreateUser(ctx, users.CreateUserParams{
+		Username:     req.Username,
+		Email:        req.Email,
+		PasswordHash: string(passwordHash),
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+	})
+	if err != nil {
+		return users.User{}, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return users.User{}, err
+	}
+
+	s.audit(ctx, "users.create", log.Int64("target_id", id))
+	user, err := s.userRepository.GetUser(ctx, uint64(id))
+	if err != nil {
+		return users.User{}, err
+	}
+
+	s.emit(ctx, EventUserCreated, UserCreated{
+		DomainEvent: domainEvent(ctx),
+		UserID:      user.ID,
+		Username:    user.Username,
+		Email:       user.Email,
+	})
+	return user, nil
+}
+
+// RefreshAccessToken exchanges a valid refresh token for a new access token and a rotated refresh token.
+// Presenting a token that was already rotated revokes every refresh token of its user. The old token is
+// revoked and the new one stored in one transaction, so a failure does not leave the user without either.
+func (s *userService) RefreshAccessToken(ctx context.Context, refreshToken string) (string, string, error) {
+	if refreshToken == "" {
+		return "", "", ErrInvalidRefreshToken
+	}
+
+	stored, err := s.userRepository.GetRefreshTokenByHash(ctx, s.tokens.hashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", ErrInvalidRefreshToken
+		}
+		return "", "", fmt.Errorf("failed to look up refresh token: %w", err)
+	}
+
+	// A revoked token being presented again means it leaked, so end the whole session family
+	if stored.RevokedAt.Valid {
+		if err := s.userRepository.RevokeUserRefreshTokens(ctx, stored.UserID); err != nil {
+			return "", "", fmt.Errorf("failed to revoke refresh tokens: %w", err)
+		}
+		s.contextLogger(ctx).Warn("Revoked refresh tokens after reuse", log.Any("user_id", stored.UserID))
+		return "", "", ErrRefreshTokenReused
+	}
+
+	if !time.Now().Before(stored.ExpiresAt) {
+		return "", "", ErrRefreshTokenExpired
+	}
+
+	var accessToken, newRefreshToken string
+	err = s.runInTx(ctx, func(q users.Querier) error {
+		// Rotate: only one concurrent request can revoke the token
+		revoked, err := q.RevokeRefreshToken(ctx, stored.ID)
+		if err != nil {
+			return fmt.Errorf("failed to revoke refresh token: %w", err)
+		}
+		if revoked == 0 {
+			return ErrRefreshTokenReused
+		}
+
+		user, err := q.GetUser(ctx, stored.UserID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInvalidRefreshToken
+			}
+			return fmt.Errorf("failed to load user: %w", err)
+		}
+
+		if accessToken, err = s.tokens.signAccessToken(user); err != nil {
+			return err
+		}
+		newRefreshToken, err = s.issueRefreshToken(ctx, q, user.ID)
+		return err
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	s.audit(ctx, "auth.refresh", log.Any("user_id", stored.UserID))
+	return accessToken, newRefreshToken, nil
+}
+
+// issueRefreshToken creates a new refresh token for the user and stores its hash with q
+func (s *userService) issueRefreshToken(ctx context.Context, q users.Querier, userID uint64) (string, error) {
+	token, err := generateRefreshToken()
+	if err != nil {
+		return "", err
+	}
+
+	err = q.CreateRefreshToken(ctx, users.CreateRefreshTokenParams{
+		UserID:    userID,
+		TokenHash: s.tokens.hashRefreshToken(token),
+		ExpiresAt: time.Now().Add(s.tokens.RefreshTTL),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return token, nil
+}
@@ -3,15 +3,28 @@ package service
 import (
 	"bytes"
 	"context"
+	"database/sql"
+	"errors"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+	_ "modernc.org/sqlite"
 
 	"github.com/MayukhSobo/scaffold/internal/repository/users"
+	"github.com/MayukhSobo/scaffold/internal/repository/users/mocks"
 	"github.com/MayukhSobo/scaffold/pkg/log"
+	"github.com/MayukhSobo/scaffold/pkg/utils"
 )
 
-// mockUserRepository implements users.Querier for testing
+// mockUserRepository is a stateful users.Querier fake for the flows that write and read back,
+// such as refresh token rotation. Single-call tests use the generated mocks.Querier instead.
 type mockUserRepository struct {
-	users []users.User
+	users         []users.User
+	refreshTokens []users.RefreshToken
 }
 
 func (m *mockUserRepository) GetUser(ctx context.Context, id uint64) (users.User, error) {
@@ -20,7 +33,22 @@ func (m *mockUserRepository) GetUser(ctx context.Context, id uint64) (users.User
 			return user, nil
 		}
 	}
-	return users.User{}, nil // Return empty user if not found
+	return users.User{}, sql.ErrNoRows
+}
+
+func (m *mockUserRepository) CreateUser(ctx context.Context, arg users.CreateUserParams) (sql.Result, error) {
+	id := uint64(len(m.users) + 1)
+	m.users = append(m.users, users.User{
+		ID:           id,
+		Username:     arg.Username,
+		Email:        arg.Email,
+		PasswordHash: arg.PasswordHash,
+		FirstName:    arg.FirstName,
+		LastName:     arg.LastName,
+		Status:       users.UsersStatusPendingVerification,
+		Role:         users.UsersRoleUser,
+	})
+	return mockResult{lastInsertID: int64(id)}, nil
 }
 
 func (m *mockUserRepository) GetUsers(ctx context.Context) ([]users.User, error) {
@@ -47,6 +75,44 @@ func (m *mockUserRepository) GetPendingVerificationUsers(ctx context.Context) ([
 	return pendingUsers, nil
 }
 
+func (m *mockUserRepository) CreateRefreshToken(ctx context.Context, arg users.CreateRefreshTokenParams) error {
+	m.refreshTokens = append(m.refreshTokens, users.RefreshToken{
+		ID:        uint64(len(m.refreshTokens) + 1),
+		UserID:    arg.UserID,
+		TokenHash: arg.TokenHash,
+		ExpiresAt: arg.ExpiresAt,
+	})
+	return nil
+}
+
+func (m *mockUserRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (users.RefreshToken, error) {
+	for _, token := range m.refreshTokens {
+		if token.TokenHash == tokenHash {
+			return token, nil
+		}
+	}
+	return users.RefreshToken{}, sql.ErrNoRows
+}
+
+func (m *mockUserRepository) RevokeRefreshToken(ctx context.Context, id uint64) (int64, error) {
+	for i, token := range m.refreshTokens {
+		if token.ID == id && !token.RevokedAt.Valid {
+			m.refreshTokens[i].RevokedAt = sql.NullTime{Time: time.Now(), Valid: true}
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (m *mockUserRepository) RevokeUserRefreshTokens(ctx context.Context, userID uint64) error {
+	for i, token := range m.refreshTokens {
+		if token.UserID == userID && !token.RevokedAt.Valid {
+			m.refreshTokens[i].RevokedAt = sql.NullTime{Time: time.Now(), Valid: true}
+		}
+	}
+	return nil
+}
+
 // setupTestsWithMock initializes dependencies for testing using mocks
 func setupTestsWithMock(t *testing.T) (UserService, *mockUserRepository) {
 	var buf bytes.Buffer
@@ -83,7 +149,7 @@ func setupTestsWithMock(t *testing.T) (UserService, *mockUserRepository) {
 	}
 
 	baseService := NewService(logger)
-	userService := NewUserService(baseService, mockRepo)
+	userService := NewUserService(baseService, mockRepo, TokenConfig{Secret: []byte("test-secret")})
 
 	return userService, mockRepo
 }
@@ -95,8 +161,18 @@ func TestNewUserService(t *testing.T) {
 	}
 }
 
+// setupTestsWithQuerierMock creates a user service backed by the generated users.Querier mock.
+// The mock asserts its expectations when the test ends.
+func setupTestsWithQuerierMock(t *testing.T) (UserService, *mocks.Querier) {
+	logger := log.NewConsoleLoggerWithWriter(log.InfoLevel, &bytes.Buffer{}, false)
+	querier := mocks.NewQuerier(t)
+	return NewUserService(NewService(logger), querier, TokenConfig{Secret: []byte("test-secret")}), querier
+}
+
 func TestUserServiceGetUserById(t *testing.T) {
-	userService, _ := setupTestsWithMock(t)
+	userService, querier := setupTestsWithQuerierMock(t)
+	querier.On("GetUser", mock.Anything, uint64(1)).
+		Return(users.User{ID: 1, Username: "testuser"}, nil).Once()
 
 	user, err := userService.GetUserById(context.Background(), 1)
 	if err != nil {
@@ -112,7 +188,9 @@ func TestUserServiceGetUserById(t *testing.T) {
 }
 
 func TestUserServiceGetAdminUsers(t *testing.T) {
-	userService, _ := setupTestsWithMock(t)
+	userService, querier := setupTestsWithQuerierMock(t)
+	querier.On("GetAdminUsers", mock.Anything).
+		Return([]users.User{{ID: 2, Username: "admin", Role: users.UsersRoleAdmin}}, nil).Once()
 
 	adminUsers, err := userService.GetAdminUsers(context.Background())
 	if err != nil {
@@ -129,7 +207,9 @@ func TestUserServiceGetAdminUsers(t *testing.T) {
 }
 
 func TestUserServiceGetPendingVerificationUsers(t *testing.T) {
-	userService, _ := setupTestsWithMock(t)
+	userService, querier := setupTestsWithQuerierMock(t)
+	querier.On("GetPendingVerificationUsers", mock.Anything).
+		Return([]users.User{{ID: 3, Username: "pending", Status: users.UsersStatusPendingVerification}}, nil).Once()
 
 	pendingUsers, err := userService.GetPendingVerificationUsers(context.Background())
 	if err != nil {
@@ -146,14 +226,234 @@ func TestUserServiceGetPendingVerificationUsers(t *testing.T) {
 }
 
 func TestUserServiceGetUserByIdNotFound(t *testing.T) {
-	userService, _ := setupTestsWithMock(t)
+	userService, querier := setupTestsWithQuerierMock(t)
+	querier.On("GetUser", mock.Anything, uint64(999)).Return(users.User{}, sql.ErrNoRows).Once()
 
 	user, err := userService.GetUserById(context.Background(), 999)
-	if err != nil {
-		t.Errorf("GetUserById() returned error: %v", err)
-	}
 
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+	var notFound *ServiceError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Expected *ServiceError, got %v", err)
+	}
+	if notFound.Code != "USER_NOT_FOUND" {
+		t.Errorf("Expected code USER_NOT_FOUND, got %s", notFound.Code)
+	}
 	if user.ID != 0 {
 		t.Errorf("Expected empty user (ID 0) for non-existent user, got ID %d", user.ID)
 	}
 }
+
+func TestUserServiceQuerierMockExpectations(t *testing.T) {
+	userService, querier := setupTestsWithQuerierMock(t)
+	querier.On("GetAdminUsers", mock.Anything).Return([]users.User{}, nil).Once()
+	querier.On("GetPendingVerificationUsers", mock.Anything).Return([]users.User{}, nil).Once()
+
+	if _, err := userService.GetAdminUsers(context.Background()); err != nil {
+		t.Fatalf("GetAdminUsers() returned error: %v", err)
+	}
+	if _, err := userService.GetPendingVerificationUsers(context.Background()); err != nil {
+		t.Fatalf("GetPendingVerificationUsers() returned error: %v", err)
+	}
+
+	querier.AssertExpectations(t)
+	querier.AssertNumberOfCalls(t, "GetUser", 0)
+}
+
+func TestUserServiceRefreshAccessToken(t *testing.T) {
+	service, mockRepo := setupTestsWithMock(t)
+	svc := service.(*userService)
+	ctx := context.Background()
+
+	// Valid token
+	refreshToken, err := svc.issueRefreshToken(ctx, svc.userRepository, 2)
+	if err != nil {
+		t.Fatalf("Failed to issue refresh token: %v", err)
+	}
+	if mockRepo.refreshTokens[0].TokenHash == refreshToken {
+		t.Error("Refresh token should be stored as a hash")
+	}
+
+	accessToken, newRefreshToken, err := service.RefreshAccessToken(ctx, refreshToken)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if accessToken == "" || newRefreshToken == "" || newRefreshToken == refreshToken {
+		t.Error("Expected a new access token and a rotated refresh token")
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(accessToken, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte("test-secret"), nil
+	}); err != nil {
+		t.Fatalf("Access token should be valid: %v", err)
+	}
+	if claims["sub"] != "2" || claims["role"] != "admin" {
+		t.Errorf("Expected sub 2 and role admin, got %v and %v", claims["sub"], claims["role"])
+	}
+
+	// Replay of the rotated token revokes the whole family
+	if _, _, err := service.RefreshAccessToken(ctx, refreshToken); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Errorf("Expected ErrRefreshTokenReused, got %v", err)
+	}
+	if _, _, err := service.RefreshAccessToken(ctx, newRefreshToken); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Errorf("Expected tokens issued after a replay to be revoked, got %v", err)
+	}
+}
+
+func TestUserServiceRefreshAccessTokenRejected(t *testing.T) {
+	testCases := []struct {
+		name        string
+		setup       func(svc *userService, repo *mockUserRepository) string
+		expectedErr error
+	}{
+		{
+			name: "expired token",
+			setup: func(svc *userService, repo *mockUserRepository) string {
+				token, _ := svc.issueRefreshToken(context.Background(), svc.userRepository, 1)
+				repo.refreshTokens[0].ExpiresAt = time.Now().Add(-time.Minute)
+				return token
+			},
+			expectedErr: ErrRefreshTokenExpired,
+		},
+		{
+			name: "unknown token",
+			setup: func(svc *userService, repo *mockUserRepository) string {
+				return "not-a-real-token"
+			},
+			expectedErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "empty token",
+			setup: func(svc *userService, repo *mockUserRepository) string {
+				return ""
+			},
+			expectedErr: ErrInvalidRefreshToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, mockRepo := setupTestsWithMock(t)
+			token := tc.setup(service.(*userService), mockRepo)
+
+			_, _, err := service.RefreshAccessToken(context.Background(), token)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Expected %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestUserServiceAuditsRequestContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false)
+	userService := NewUserService(NewService(logger), &mockUserRepository{}, TokenConfig{Secret: []byte("test-secret")})
+
+	ctx := utils.WithRequestContext(context.Background(), utils.RequestContext{
+		UserID:    7,
+		Role:      "admin",
+		RequestID: "req-1",
+		TraceID:   "trace-1",
+	})
+	if _, err := userService.GetUsers(ctx); err != nil {
+		t.Fatalf("GetUsers() returned error: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{`"action":"users.list"`, `"actor_id":7`, `"actor_role":"admin"`, `"request_id":"req-1"`, `"trace_id":"trace-1"`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected audit log to contain %s, got: %s", want, output)
+		}
+	}
+}
+
+func TestUserServiceAuditsWithoutRequestContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false)
+	userService := NewUserService(NewService(logger), &mockUserRepository{}, TokenConfig{Secret: []byte("test-secret")})
+
+	if _, err := userService.GetAdminUsers(context.Background()); err != nil {
+		t.Fatalf("GetAdminUsers() returned error: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, `"action":"users.list_admins"`) {
+		t.Errorf("Expected audit log to contain action, got: %s", output)
+	}
+	if strings.Contains(output, "actor_id") {
+		t.Errorf("Expected no actor fields without a request context, got: %s", output)
+	}
+}
+
+func TestUserServiceCreateUser(t *testing.T) {
+	userService, mockRepo := setupTestsWithMock(t)
+
+	user, err := userService.CreateUser(context.Background(), CreateUserRequest{
+		Username: "newuser",
+		Email:    "newuser@example.com",
+		Password: "correct horse battery staple",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser() returned error: %v", err)
+	}
+
+	if user.ID != 4 {
+		t.Errorf("Expected user ID 4, got %d", user.ID)
+	}
+	if user.Username != "newuser" {
+		t.Errorf("Expected username 'newuser', got %s", user.Username)
+	}
+
+	stored := mockRepo.users[len(mockRepo.users)-1]
+	if stored.PasswordHash == "correct horse battery staple" {
+		t.Error("Expected password to be stored hashed")
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(stored.PasswordHash), []byte("correct horse battery staple")); err != nil {
+		t.Errorf("Expected stored hash to match password: %v", err)
+	}
+}
+
+func TestUserServiceRefreshAccessTokenRollsBack(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open sqlite database: %v", err)
+	}
+	defer db.Close()
+	// Every connection to :memory: is a new database
+	db.SetMaxOpenConns(1)
+
+	// Without a users table the user lookup fails after the old token was revoked
+	if _, err := db.Exec(`CREATE TABLE refresh_tokens (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL,
+		token_hash VARCHAR(64) NOT NULL UNIQUE,
+		expires_at TIMESTAMP NOT NULL,
+		revoked_at TIMESTAMP NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+
+	logger := log.NewConsoleLoggerWithWriter(log.InfoLevel, &bytes.Buffer{}, false)
+	service := NewUserService(NewService(logger), users.New(db), TokenConfig{Secret: []byte("test-secret")}, WithTransactions(NewTxRunner(db)))
+	svc := service.(*userService)
+	refreshToken, err := svc.issueRefreshToken(context.Background(), svc.userRepository, 1)
+	if err != nil {
+		t.Fatalf("Failed to issue refresh token: %v", err)
+	}
+
+	if _, _, err := service.RefreshAccessToken(context.Background(), refreshToken); err == nil {
+		t.Fatal("Expected the failed user lookup to be returned")
+	}
+
+	var revoked int
+	if err := db.QueryRow("SELECT COUNT(*) FROM refresh_tokens WHERE revoked_at IS NOT NULL").Scan(&revoked); err != nil {
+		t.Fatalf("Failed to count revoked tokens: %v", err)
+	}
+	if revoked != 0 {
+		t.Errorf("Expected the revocation to be rolled back, got %d revoked tokens", revoked)
+	}
+}
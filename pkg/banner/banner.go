@@ -0,0 +1,82 @@
+// Package banner renders the startup banner from the app metadata in the config.
+package banner
+
+import (
+	"bytes"
+	"os"
+	"runtime"
+	"text/template"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/MayukhSobo/scaffold/pkg/build"
+)
+
+// DefaultTemplate is used when banner.template is not set or the file cannot be read
+const DefaultTemplate = `
+███████╗ ██████╗ █████╗ ███████╗███████╗ ██████╗ ██╗     ██████╗ 
+██╔════╝██╔════╝██╔══██╗██╔════╝██╔════╝██╔═══██╗██║     ██╔══██╗
+███████╗██║     ███████║█████╗  █████╗  ██║   ██║██║     ██║  ██║
+╚════██║██║     ██╔══██║██╔══╝  ██╔══╝  ██║   ██║██║     ██║  ██║
+███████║╚██████╗██║  ██║██║     ██║     ╚██████╔╝███████╗██████╔╝
+╚══════╝ ╚═════╝╚═╝  ╚═╝╚═╝     ╚═╝      ╚═════╝ ╚══════╝╚═════╝
+🚀 High-Performance Application Scaffold 🚀
+
+{{.AppName}} {{.Version}} ({{.Environment}}) - {{.GoVersion}} - started {{.StartTime.Format "2006-01-02 15:04:05 MST"}}
+`
+
+// Data is the app metadata available to the banner template
+type Data struct {
+	AppName     string
+	Version     string
+	Environment string
+	GoVersion   string
+	StartTime   time.Time
+}
+
+// Generate renders the banner template from banner.template, or DefaultTemplate, with the app metadata in conf.
+// It returns an empty string when banner.enabled is false.
+func Generate(conf *viper.Viper) string {
+	if conf.IsSet("banner.enabled") && !conf.GetBool("banner.enabled") {
+		return ""
+	}
+
+	data := Data{
+		AppName:     conf.GetString("app.name"),
+		Version:     conf.GetString("app.version"),
+		Environment: conf.GetString("env"),
+		GoVersion:   runtime.Version(),
+		StartTime:   time.Now(),
+	}
+	if data.Version == "" {
+		data.Version = build.Version
+	}
+
+	text := DefaultTemplate
+	if path := conf.GetString("banner.template"); path != "" {
+		if content, err := os.ReadFile(path); err == nil {
+			text = string(content)
+		}
+	}
+
+	rendered, err := render(text, data)
+	if err != nil {
+		// A broken custom template should not prevent the server from starting
+		rendered, _ = render(DefaultTemplate, data)
+	}
+	return rendered
+}
+
+// render executes the banner template text with data
+func render(text string, data Data) (string, error) {
+	tmpl, err := template.New("banner").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,79 @@
+package banner
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/MayukhSobo/scaffold/pkg/build"
+)
+
+func TestGenerate(t *testing.T) {
+	templateFile := filepath.Join(t.TempDir(), "banner.txt")
+	if err := os.WriteFile(templateFile, []byte("== {{.AppName}} v{{.Version}} =="), 0o644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	brokenFile := filepath.Join(t.TempDir(), "broken.txt")
+	if err := os.WriteFile(brokenFile, []byte("{{.AppName"), 0o644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		settings map[string]interface{}
+		expected []string
+		exact    bool
+	}{
+		{
+			name:     "custom template",
+			settings: map[string]interface{}{"app.name": "Scaffold", "app.version": "1.2.0", "banner.template": templateFile},
+			expected: []string{"== Scaffold v1.2.0 =="},
+			exact:    true,
+		},
+		{
+			name:     "missing template file",
+			settings: map[string]interface{}{"app.name": "Scaffold", "app.version": "1.2.0", "env": "local", "banner.template": "does-not-exist.txt"},
+			expected: []string{"High-Performance Application Scaffold", "Scaffold 1.2.0 (local)", runtime.Version()},
+		},
+		{
+			name:     "broken template file",
+			settings: map[string]interface{}{"app.name": "Scaffold", "banner.template": brokenFile},
+			expected: []string{"High-Performance Application Scaffold"},
+		},
+		{
+			name:     "version from build info",
+			settings: map[string]interface{}{"app.name": "Scaffold", "banner.template": templateFile},
+			expected: []string{"== Scaffold v" + build.Version + " =="},
+			exact:    true,
+		},
+		{
+			name:     "disabled",
+			settings: map[string]interface{}{"app.name": "Scaffold", "banner.enabled": false},
+			expected: []string{""},
+			exact:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := viper.New()
+			for key, value := range tt.settings {
+				conf.Set(key, value)
+			}
+
+			got := Generate(conf)
+			for _, expected := range tt.expected {
+				if tt.exact && got != expected {
+					t.Errorf("Expected %q, got %q", expected, got)
+				}
+				if !tt.exact && !strings.Contains(got, expected) {
+					t.Errorf("Expected banner to contain %q, got %q", expected, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+// Package build holds the build metadata of the running binary.
+// The values are set with -ldflags at build time, see cmd/server/version.go and the Makefile.
+package build
+
+var (
+	// Version is the release version, eg: v1.2.0
+	Version = "dev"
+	// Commit is the git commit the binary was built from
+	Commit = "unknown"
+	// BuildTime is the UTC build time in RFC 3339 format
+	BuildTime = "unknown"
+)
@@ -0,0 +1,111 @@
+// Package cache provides the cache used by the service layer and its implementations.
+package cache
+
+import (
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// memorySweepInterval is how often the memory cache removes expired entries while it is written to
+const memorySweepInterval = time.Minute
+
+// Cache stores values by key until their TTL expires
+type Cache interface {
+	// Get returns the value stored for key, false when there is none or it expired
+	Get(key string) (interface{}, bool)
+	// Set stores val for key for ttl, or for the default TTL of the cache when ttl is 0
+	Set(key string, val interface{}, ttl time.Duration)
+	// Delete removes the value stored for key, if any
+	Delete(key string)
+}
+
+// memoryEntry is a cached value with its expiry, the zero time never expires
+type memoryEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// expired reports whether the entry expired at now
+func (e *memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// memoryCache is an in-process Cache. Expired entries are removed when they are read and by the sweep of
+// Set, not in the background.
+type memoryCache struct {
+	defaultTTL time.Duration
+	items      sync.Map // string -> *memoryEntry
+	lastSweep  atomic.Int64
+}
+
+// NewMemoryCache creates an in-process cache whose values expire after defaultTTL unless Set is given
+// another TTL. Values never expire when both are 0.
+func NewMemoryCache(defaultTTL time.Duration) Cache {
+	c := &memoryCache{defaultTTL: defaultTTL}
+	c.lastSweep.Store(time.Now().UnixNano())
+	return c
+}
+
+func (c *memoryCache) Get(key string) (interface{}, bool) {
+	item, ok := c.items.Load(key)
+	if !ok {
+		return nil, false
+	}
+	entry := item.(*memoryEntry)
+	if entry.expired(time.Now()) {
+		// Only delete this entry, not one stored by a concurrent Set
+		c.items.CompareAndDelete(key, item)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *memoryCache) Set(key string, val interface{}, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = c.defaultTTL
+	}
+	entry := &memoryEntry{value: val}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	c.sweep()
+	c.items.Store(key, entry)
+}
+
+func (c *memoryCache) Delete(key string) {
+	c.items.Delete(key)
+}
+
+// sweep removes every expired entry when the last sweep is older than memorySweepInterval,
+// so keys that are never read again, such as users:id:<n>, do not stay in memory
+func (c *memoryCache) sweep() {
+	now := time.Now()
+	last := c.lastSweep.Load()
+	if now.Sub(time.Unix(0, last)) < memorySweepInterval || !c.lastSweep.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+	c.items.Range(func(key, item interface{}) bool {
+		if item.(*memoryEntry).expired(now) {
+			c.items.CompareAndDelete(key, item)
+		}
+		return true
+	})
+}
+
+// As returns a cached value as a T. Values of caches storing JSON, such as RedisCache, are decoded into a T;
+// false is returned when val is neither a T nor JSON decoding into one.
+func As[T any](val interface{}) (T, bool) {
+	switch v := val.(type) {
+	case T:
+		return v, true
+	case json.RawMessage:
+		var decoded T
+		if err := json.Unmarshal(v, &decoded); err == nil {
+			return decoded, true
+		}
+	}
+	var zero T
+	return zero, false
+}
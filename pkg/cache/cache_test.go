@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache(t *testing.T) {
+	tests := []struct {
+		name        string
+		defaultTTL  time.Duration
+		ttl         time.Duration
+		wait        time.Duration
+		expectFound bool
+	}{
+		{name: "within default TTL", defaultTTL: time.Minute, expectFound: true},
+		{name: "default TTL expired", defaultTTL: 10 * time.Millisecond, wait: 20 * time.Millisecond, expectFound: false},
+		{name: "explicit TTL overrides default", defaultTTL: time.Minute, ttl: 10 * time.Millisecond, wait: 20 * time.Millisecond, expectFound: false},
+		{name: "explicit TTL longer than default", defaultTTL: 10 * time.Millisecond, ttl: time.Minute, wait: 20 * time.Millisecond, expectFound: true},
+		{name: "no expiry", wait: 20 * time.Millisecond, expectFound: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMemoryCache(tt.defaultTTL)
+			c.Set("key", "value", tt.ttl)
+			time.Sleep(tt.wait)
+
+			val, found := c.Get("key")
+			if found != tt.expectFound {
+				t.Fatalf("Expected found %v, got %v", tt.expectFound, found)
+			}
+			if found && val != "value" {
+				t.Errorf("Expected value %q, got %v", "value", val)
+			}
+		})
+	}
+}
+
+func TestMemoryCacheDelete(t *testing.T) {
+	c := NewMemoryCache(time.Minute)
+	c.Set("key", "value", 0)
+	c.Delete("key")
+
+	if _, found := c.Get("key"); found {
+		t.Error("Expected the deleted key to be missing")
+	}
+	// Deleting a missing key is a no-op
+	c.Delete("missing")
+}
+
+func TestMemoryCacheExpiredEntryRemoved(t *testing.T) {
+	c := NewMemoryCache(time.Millisecond).(*memoryCache)
+	c.Set("key", "value", 0)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, found := c.Get("key"); found {
+		t.Fatal("Expected the key to be expired")
+	}
+	if _, stored := c.items.Load("key"); stored {
+		t.Error("Expected the expired entry to be removed on read")
+	}
+}
+
+func TestMemoryCacheSweep(t *testing.T) {
+	c := NewMemoryCache(0).(*memoryCache)
+	c.Set("expired", "value", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	// Force the next write to sweep
+	c.lastSweep.Store(time.Now().Add(-2 * memorySweepInterval).UnixNano())
+	c.Set("live", "value", time.Minute)
+
+	if _, stored := c.items.Load("expired"); stored {
+		t.Error("Expected the expired entry to be swept without being read")
+	}
+	if _, stored := c.items.Load("live"); !stored {
+		t.Error("Expected the live entry to be kept")
+	}
+}
+
+func TestAs(t *testing.T) {
+	type user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	tests := []struct {
+		name     string
+		val      interface{}
+		expected user
+		expectOK bool
+	}{
+		{name: "stored value", val: user{ID: 1, Name: "alice"}, expected: user{ID: 1, Name: "alice"}, expectOK: true},
+		{name: "JSON value", val: json.RawMessage(`{"id":2,"name":"bob"}`), expected: user{ID: 2, Name: "bob"}, expectOK: true},
+		{name: "invalid JSON", val: json.RawMessage(`{"id":`), expectOK: false},
+		{name: "other type", val: "alice", expectOK: false},
+		{name: "nil", val: nil, expectOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := As[user](tt.val)
+			if ok != tt.expectOK {
+				t.Fatalf("Expected ok %v, got %v", tt.expectOK, ok)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
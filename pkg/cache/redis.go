@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// Redis defaults used when the cache.redis keys are not set
+const (
+	defaultRedisAddr = "localhost:6379"
+	// redisConnectTimeout bounds the ping of NewRedisCache
+	redisConnectTimeout = 5 * time.Second
+	// redisOperationTimeout bounds Get, Set and Delete, which have no context of their own
+	redisOperationTimeout = time.Second
+)
+
+// RedisConfig holds the Redis connection settings
+type RedisConfig struct {
+	Addr     string `mapstructure:"addr"`
+	Password string `mapstructure:"password"`
+	DB       int    `mapstructure:"db"`
+}
+
+// RedisCache is a Cache stored in Redis, shared by every instance of the service.
+// Values are stored as JSON and Get returns them as json.RawMessage, see As.
+// Redis errors are logged and reported as cache misses, so an unavailable cache only slows callers down.
+type RedisCache struct {
+	client *redis.Client
+	logger log.Logger
+}
+
+// NewRedisCache connects to the Redis server in the cache.redis section of the config.
+// It returns an error when the server does not answer a ping.
+func NewRedisCache(conf *viper.Viper, logger log.Logger) (Cache, error) {
+	var config RedisConfig
+	if err := conf.UnmarshalKey("cache.redis", &config); err != nil {
+		return nil, fmt.Errorf("failed to parse redis cache config: %w", err)
+	}
+	if config.Addr == "" {
+		config.Addr = defaultRedisAddr
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     config.Addr,
+		Password: config.Password,
+		DB:       config.DB,
+	})
+	c := &RedisCache{client: client, logger: logger}
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisConnectTimeout)
+	defer cancel()
+	if err := c.HealthCheck(ctx); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to connect to redis at %s: %w", config.Addr, err)
+	}
+
+	logger.Info("Redis cache connected", log.String("addr", config.Addr), log.Int("db", config.DB))
+	return c, nil
+}
+
+func (c *RedisCache) Get(key string) (interface{}, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisOperationTimeout)
+	defer cancel()
+
+	data, err := c.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			c.logger.Warn("Failed to read from redis cache", log.String("key", key), log.Error(err))
+		}
+		return nil, false
+	}
+	return json.RawMessage(data), true
+}
+
+// Set stores val as JSON for ttl, without expiry when ttl is 0
+func (c *RedisCache) Set(key string, val interface{}, ttl time.Duration) {
+	data, err := json.Marshal(val)
+	if err != nil {
+		c.logger.Warn("Failed to encode redis cache value", log.String("key", key), log.Error(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisOperationTimeout)
+	defer cancel()
+	if err := c.client.Set(ctx, key, data, ttl).Err(); err != nil {
+		c.logger.Warn("Failed to write to redis cache", log.String("key", key), log.Error(err))
+	}
+}
+
+func (c *RedisCache) Delete(key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisOperationTimeout)
+	defer cancel()
+	if err := c.client.Del(ctx, key).Err(); err != nil {
+		c.logger.Warn("Failed to delete from redis cache", log.String("key", key), log.Error(err))
+	}
+}
+
+// HealthCheck pings the Redis server
+func (c *RedisCache) HealthCheck(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
+
+// Close closes the connections to the Redis server
+func (c *RedisCache) Close() error {
+	return c.client.Close()
+}
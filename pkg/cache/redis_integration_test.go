@@ -0,0 +1,101 @@
+//go:build integration
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// newTestRedisCache connects to the server in TEST_REDIS_ADDR, e.g. localhost:6379
+func newTestRedisCache(t *testing.T) *RedisCache {
+	t.Helper()
+	addr := os.Getenv("TEST_REDIS_ADDR")
+	if addr == "" {
+		t.Skip("Set TEST_REDIS_ADDR to run the Redis integration tests")
+	}
+
+	conf := viper.New()
+	conf.Set("cache.redis.addr", addr)
+	c, err := NewRedisCache(conf, log.NewConsoleLogger(log.InfoLevel))
+	if err != nil {
+		t.Fatalf("Failed to create Redis cache: %v", err)
+	}
+	rc := c.(*RedisCache)
+	t.Cleanup(func() { _ = rc.Close() })
+	return rc
+}
+
+// testKey returns a key unique to the test run, so runs do not see each other's values
+func testKey(t *testing.T, name string) string {
+	return fmt.Sprintf("scaffold-test:%s:%s:%d", t.Name(), name, time.Now().UnixNano())
+}
+
+func TestRedisCacheSetGetIntegration(t *testing.T) {
+	c := newTestRedisCache(t)
+	type user struct {
+		ID       int      `json:"id"`
+		Username string   `json:"username"`
+		Roles    []string `json:"roles"`
+	}
+	key := testKey(t, "user")
+	defer c.Delete(key)
+
+	c.Set(key, user{ID: 1, Username: "alice", Roles: []string{"admin"}}, time.Minute)
+
+	cached, found := c.Get(key)
+	if !found {
+		t.Fatal("Expected the value to be found")
+	}
+	got, ok := As[user](cached)
+	if !ok {
+		t.Fatalf("Expected the value to decode, got %s", cached)
+	}
+	if got.ID != 1 || got.Username != "alice" || len(got.Roles) != 1 || got.Roles[0] != "admin" {
+		t.Errorf("Expected the stored user, got %+v", got)
+	}
+}
+
+func TestRedisCacheExpiryIntegration(t *testing.T) {
+	c := newTestRedisCache(t)
+	key := testKey(t, "expiring")
+	defer c.Delete(key)
+
+	c.Set(key, "value", 100*time.Millisecond)
+	if _, found := c.Get(key); !found {
+		t.Fatal("Expected the value to be found before it expires")
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	if _, found := c.Get(key); found {
+		t.Error("Expected the value to expire")
+	}
+}
+
+func TestRedisCacheDeleteIntegration(t *testing.T) {
+	c := newTestRedisCache(t)
+	key := testKey(t, "deleted")
+
+	c.Set(key, "value", time.Minute)
+	c.Delete(key)
+	if _, found := c.Get(key); found {
+		t.Error("Expected the deleted value to be missing")
+	}
+}
+
+func TestRedisCacheHealthCheckIntegration(t *testing.T) {
+	c := newTestRedisCache(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := c.HealthCheck(ctx); err != nil {
+		t.Errorf("Expected a healthy Redis server, got %v", err)
+	}
+}
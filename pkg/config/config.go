@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/viper"
@@ -16,7 +17,7 @@ func NewConfig() *viper.Viper {
 
 	if envConf == "" {
 		// Support both --config and --conf flags for backwards compatibility
-		flag.StringVar(&configPath, "config", "", "config path, eg: --config @/local.yml or --config configs/local.yml")
+		flag.StringVar(&configPath, "config", "", "config path, eg: --config @/local.yml, --config @/configs/local.yml or --config configs/local.yml")
 		flag.StringVar(&envConf, "conf", "", "config path (deprecated, use --config), eg: --conf configs/local.yml")
 
 		// Add validation flag for config files
@@ -31,10 +32,8 @@ func NewConfig() *viper.Viper {
 		}
 	}
 
-	// Handle @/configs path alias
-	if strings.HasPrefix(envConf, "@/") {
-		envConf = strings.Replace(envConf, "@/", "configs/", 1)
-	}
+	// Handle @/ path alias
+	envConf = resolveConfigPath(envConf)
 
 	// Set default if no config specified
 	if envConf == "" {
@@ -48,6 +47,12 @@ func NewConfig() *viper.Viper {
 	if len(os.Args) > 1 {
 		for _, arg := range os.Args[1:] {
 			if arg == "--validate-config" {
+				// The schema is looked up next to the config file, e.g. configs/schema.json
+				schemaPath := filepath.Join(filepath.Dir(envConf), DefaultSchemaFile)
+				if err := ValidateConfig(conf, schemaPath); err != nil {
+					fmt.Fprintf(os.Stderr, "✗ Config file %s is invalid, %v\n", envConf, err)
+					os.Exit(1)
+				}
 				fmt.Printf("✓ Config file %s is valid\n", envConf)
 				os.Exit(0)
 			}
@@ -57,11 +62,68 @@ func NewConfig() *viper.Viper {
 	return conf
 }
 
+// StartupError is raised by NewConfig when the config file cannot be loaded
+type StartupError struct {
+	Path string
+	Err  error
+}
+
+// Error formats the error as a banner suitable for printing to stderr
+func (e *StartupError) Error() string {
+	return fmt.Sprintf("FATAL: Configuration error\n  File: %s\n  Error: %v\n  Hint: run --validate-config to check syntax\n", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying read error
+func (e *StartupError) Unwrap() error {
+	return e.Err
+}
+
+// EnvPrefix is the prefix of the environment variables overriding config keys, see EnableEnvOverride
+const EnvPrefix = "APP"
+
 func getConfig(path string) *viper.Viper {
 	conf := viper.New()
 	conf.SetConfigFile(path)
 	if err := conf.ReadInConfig(); err != nil {
-		panic(fmt.Errorf("failed to read config file %s: %w", path, err))
+		panic(&StartupError{Path: path, Err: err})
 	}
+	EnableEnvOverride(conf, EnvPrefix)
 	return conf
 }
+
+// EnableEnvOverride lets environment variables take precedence over the config file for every key read
+// with Get or IsSet. Dots become underscores, e.g. with prefix APP, APP_DB_MYSQL_HOST overrides db.mysql.host.
+// Keys read through UnmarshalKey of a parent section are not overridden, only direct lookups are.
+func EnableEnvOverride(conf *viper.Viper, prefix string) {
+	conf.SetEnvPrefix(prefix)
+	conf.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	conf.AutomaticEnv()
+}
+
+// resolveConfigPath expands the @/ alias relative to the binary's directory, or APP_CONFIG_DIR when set.
+// "@/<subdir>/file.yml" resolves to <base>/<subdir>/file.yml and the "@/file.yml" shorthand to
+// <base>/configs/file.yml. Paths without the alias are returned unchanged.
+func resolveConfigPath(path string) string {
+	if !strings.HasPrefix(path, "@/") {
+		return path
+	}
+
+	rel := strings.TrimPrefix(path, "@/")
+	if filepath.Dir(rel) == "." {
+		rel = filepath.Join("configs", rel)
+	}
+
+	if baseDir := os.Getenv("APP_CONFIG_DIR"); baseDir != "" {
+		return filepath.Join(baseDir, rel)
+	}
+
+	if executable, err := os.Executable(); err == nil {
+		candidate := filepath.Join(filepath.Dir(executable), rel)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+
+	// Fall back to the working directory, e.g. for go run which builds into a temp dir
+	return rel
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveConfigPath(t *testing.T) {
+	configDir := t.TempDir()
+
+	testCases := []struct {
+		name      string
+		path      string
+		configDir string
+		expected  string
+	}{
+		{"relative path", "configs/local.yml", "", "configs/local.yml"},
+		{"absolute path", "/etc/scaffold/local.yml", "", "/etc/scaffold/local.yml"},
+		{"alias shorthand falls back to working directory", "@/local.yml", "", filepath.Join("configs", "local.yml")},
+		{"alias subdir falls back to working directory", "@/deploy/prod.yml", "", filepath.Join("deploy", "prod.yml")},
+		{"env override with subdir", "@/configs/docker.yml", configDir, filepath.Join(configDir, "configs", "docker.yml")},
+		{"env override with shorthand", "@/docker.yml", configDir, filepath.Join(configDir, "configs", "docker.yml")},
+		{"env override ignores non-alias paths", "configs/local.yml", configDir, "configs/local.yml"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("APP_CONFIG_DIR", tc.configDir)
+
+			resolved := resolveConfigPath(tc.path)
+			if resolved != tc.expected {
+				t.Errorf("Expected path '%s', got '%s'", tc.expected, resolved)
+			}
+		})
+	}
+}
+
+func TestResolveConfigPathExecutableDir(t *testing.T) {
+	t.Setenv("APP_CONFIG_DIR", "")
+
+	executable, err := os.Executable()
+	if err != nil {
+		t.Skipf("Cannot determine executable path: %v", err)
+	}
+
+	// Place a config next to the test binary, mirroring /app/server + /app/configs/local.yml
+	subdir := filepath.Join(filepath.Dir(executable), "resolve-test-configs")
+	if err := os.MkdirAll(subdir, 0o755); err != nil {
+		t.Skipf("Cannot write next to the test binary: %v", err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(subdir) })
+
+	configFile := filepath.Join(subdir, "local.yml")
+	if err := os.WriteFile(configFile, []byte("env: test\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	resolved := resolveConfigPath("@/resolve-test-configs/local.yml")
+	if resolved != configFile {
+		t.Errorf("Expected path '%s', got '%s'", configFile, resolved)
+	}
+}
+
+func TestStartupErrorMessage(t *testing.T) {
+	cause := errors.New("yaml: line 3: did not find expected key")
+	err := &StartupError{Path: "configs/broken.yml", Err: cause}
+
+	expected := "FATAL: Configuration error\n  File: configs/broken.yml\n  Error: yaml: line 3: did not find expected key\n  Hint: run --validate-config to check syntax\n"
+	if err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+	if !errors.Is(err, cause) {
+		t.Error("Expected StartupError to unwrap to the read error")
+	}
+}
+
+func TestGetConfigPanicsWithStartupError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.yml")
+
+	defer func() {
+		startupErr, ok := recover().(*StartupError)
+		if !ok {
+			t.Fatal("Expected getConfig to panic with *StartupError")
+		}
+		if startupErr.Path != path {
+			t.Errorf("Expected path %s, got %s", path, startupErr.Path)
+		}
+		if !strings.Contains(startupErr.Error(), "Hint: run --validate-config") {
+			t.Errorf("Expected hint in error, got %q", startupErr.Error())
+		}
+	}()
+
+	getConfig(path)
+}
+
+func TestGetConfigEnvOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	content := "db:\n  mysql:\n    host: 127.0.0.1\n    port: 3306\nlog:\n  level: debug\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	t.Setenv("APP_DB_MYSQL_HOST", "db.internal")
+	t.Setenv("APP_LOG_LEVEL", "warn")
+
+	conf := getConfig(path)
+	if host := conf.GetString("db.mysql.host"); host != "db.internal" {
+		t.Errorf("Expected APP_DB_MYSQL_HOST to override db.mysql.host, got %q", host)
+	}
+	if level := conf.GetString("log.level"); level != "warn" {
+		t.Errorf("Expected APP_LOG_LEVEL to override log.level, got %q", level)
+	}
+	if port := conf.GetInt("db.mysql.port"); port != 3306 {
+		t.Errorf("Expected keys without env vars to keep the file value, got %d", port)
+	}
+}
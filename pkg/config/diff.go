@@ -0,0 +1,111 @@
+package config
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Config change types reported by Diff.
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// ConfigChange describes a single key that differs between two configs.
+type ConfigChange struct {
+	Key    string
+	Before interface{}
+	After  interface{}
+	Type   string
+}
+
+// Diff compares the settings of two viper instances and returns the changed keys sorted by key.
+func Diff(before, after *viper.Viper) []ConfigChange {
+	beforeValues := make(map[string]interface{})
+	afterValues := make(map[string]interface{})
+	flattenSettings(before.AllSettings(), "", beforeValues)
+	flattenSettings(after.AllSettings(), "", afterValues)
+
+	var changes []ConfigChange
+	for key, beforeVal := range beforeValues {
+		afterVal, ok := afterValues[key]
+		switch {
+		case !ok:
+			changes = append(changes, ConfigChange{Key: key, Before: beforeVal, Type: ChangeRemoved})
+		case !reflect.DeepEqual(beforeVal, afterVal):
+			changes = append(changes, ConfigChange{Key: key, Before: beforeVal, After: afterVal, Type: ChangeChanged})
+		}
+	}
+	for key, afterVal := range afterValues {
+		if _, ok := beforeValues[key]; !ok {
+			changes = append(changes, ConfigChange{Key: key, After: afterVal, Type: ChangeAdded})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
+
+// RedactChanges returns a copy of changes with the values of sensitive keys replaced by RedactedValue.
+// A change is redacted when any segment of its dotted key, or the key itself, is in redactKeys.
+func RedactChanges(changes []ConfigChange, redactKeys []string) []ConfigChange {
+	redact := redactKeySet(redactKeys)
+	redacted := make([]ConfigChange, len(changes))
+	for i, change := range changes {
+		if isRedactedPath(change.Key, redact) {
+			if change.Before != nil {
+				change.Before = RedactedValue
+			}
+			if change.After != nil {
+				change.After = RedactedValue
+			}
+		}
+		redacted[i] = change
+	}
+	return redacted
+}
+
+// redactKeySet returns the lower-cased redactKeys for isRedactedPath
+func redactKeySet(redactKeys []string) map[string]bool {
+	redact := make(map[string]bool, len(redactKeys))
+	for _, key := range redactKeys {
+		redact[strings.ToLower(key)] = true
+	}
+	return redact
+}
+
+// isRedactedPath reports whether the dotted path or one of its segments is in redact
+func isRedactedPath(path string, redact map[string]bool) bool {
+	path = strings.ToLower(path)
+	if redact[path] {
+		return true
+	}
+	for _, segment := range strings.Split(path, ".") {
+		if redact[segment] {
+			return true
+		}
+	}
+	return false
+}
+
+// flattenSettings recursively walks nested settings, storing leaf values under dotted keys.
+func flattenSettings(settings map[string]interface{}, prefix string, out map[string]interface{}) {
+	for key, value := range settings {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			flattenSettings(nested, path, out)
+			continue
+		}
+		out[path] = value
+	}
+}
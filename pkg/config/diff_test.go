@@ -0,0 +1,95 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestDiff(t *testing.T) {
+	testCases := []struct {
+		name     string
+		before   map[string]interface{}
+		after    map[string]interface{}
+		expected []ConfigChange
+	}{
+		{
+			name:     "no changes",
+			before:   map[string]interface{}{"env": "local"},
+			after:    map[string]interface{}{"env": "local"},
+			expected: nil,
+		},
+		{
+			name:   "flat added removed and changed",
+			before: map[string]interface{}{"env": "local", "debug": true},
+			after:  map[string]interface{}{"env": "production", "port": 8000},
+			expected: []ConfigChange{
+				{Key: "debug", Before: true, Type: ChangeRemoved},
+				{Key: "env", Before: "local", After: "production", Type: ChangeChanged},
+				{Key: "port", After: 8000, Type: ChangeAdded},
+			},
+		},
+		{
+			name:   "nested keys",
+			before: map[string]interface{}{"log.level": "info", "db.mysql.host": "localhost", "db.mysql.user": "root"},
+			after:  map[string]interface{}{"log.level": "debug", "db.mysql.host": "localhost", "db.mysql.port": "3306"},
+			expected: []ConfigChange{
+				{Key: "db.mysql.port", After: "3306", Type: ChangeAdded},
+				{Key: "db.mysql.user", Before: "root", Type: ChangeRemoved},
+				{Key: "log.level", Before: "info", After: "debug", Type: ChangeChanged},
+			},
+		},
+		{
+			name:   "section replaced by value",
+			before: map[string]interface{}{"cache.redis.addr": "127.0.0.1:6379"},
+			after:  map[string]interface{}{"cache": "disabled"},
+			expected: []ConfigChange{
+				{Key: "cache", After: "disabled", Type: ChangeAdded},
+				{Key: "cache.redis.addr", Before: "127.0.0.1:6379", Type: ChangeRemoved},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			before := viper.New()
+			for k, v := range tc.before {
+				before.Set(k, v)
+			}
+			after := viper.New()
+			for k, v := range tc.after {
+				after.Set(k, v)
+			}
+
+			changes := Diff(before, after)
+			if !reflect.DeepEqual(changes, tc.expected) {
+				t.Errorf("Expected changes %+v, got %+v", tc.expected, changes)
+			}
+		})
+	}
+}
+
+func TestRedactChanges(t *testing.T) {
+	changes := []ConfigChange{
+		{Key: "db.mysql.host", Before: "localhost", After: "db.internal", Type: ChangeChanged},
+		{Key: "db.mysql.password", Before: "old", After: "new", Type: ChangeChanged},
+		{Key: "server.jwt.secret", After: "added", Type: ChangeAdded},
+		{Key: "security.token.value", Before: "removed", Type: ChangeRemoved},
+	}
+
+	expected := []ConfigChange{
+		{Key: "db.mysql.host", Before: "localhost", After: "db.internal", Type: ChangeChanged},
+		{Key: "db.mysql.password", Before: RedactedValue, After: RedactedValue, Type: ChangeChanged},
+		{Key: "server.jwt.secret", After: RedactedValue, Type: ChangeAdded},
+		{Key: "security.token.value", Before: RedactedValue, Type: ChangeRemoved},
+	}
+
+	redacted := RedactChanges(changes, []string{"password", "secret", "token"})
+	if !reflect.DeepEqual(redacted, expected) {
+		t.Errorf("Expected changes %+v, got %+v", expected, redacted)
+	}
+	if changes[1].Before != "old" {
+		t.Error("Expected the original changes to be left untouched")
+	}
+}
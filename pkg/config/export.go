@@ -0,0 +1,65 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/viper"
+)
+
+// RedactedValue replaces the value of sensitive keys in exported config.
+const RedactedValue = "<redacted>"
+
+// DefaultRedactKeys lists the config keys that hold credentials in the bundled configs.
+var DefaultRedactKeys = []string{"password", "password_file", "key", "app_key", "app_security", "secret", "token"}
+
+// ExportJSON returns the loaded config as indented JSON with sensitive keys redacted.
+func ExportJSON(v *viper.Viper, redactKeys []string) ([]byte, error) {
+	return json.MarshalIndent(exportSettings(v, redactKeys), "", "  ")
+}
+
+// ExportTOML returns the loaded config as TOML with sensitive keys redacted.
+func ExportTOML(v *viper.Viper, redactKeys []string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(exportSettings(v, redactKeys)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// exportSettings returns viper's merged nested settings with sensitive keys redacted.
+func exportSettings(v *viper.Viper, redactKeys []string) map[string]interface{} {
+	redact := make(map[string]bool, len(redactKeys))
+	for _, key := range redactKeys {
+		redact[strings.ToLower(key)] = true
+	}
+	return redactSettings(v.AllSettings(), "", redact)
+}
+
+// redactSettings copies settings, replacing values whose key or full dotted path is in redact.
+// Nil values are dropped since they cannot be represented in TOML.
+func redactSettings(settings map[string]interface{}, prefix string, redact map[string]bool) map[string]interface{} {
+	out := make(map[string]interface{}, len(settings))
+	for key, value := range settings {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		switch {
+		case value == nil:
+			continue
+		case redact[strings.ToLower(key)] || redact[strings.ToLower(path)]:
+			out[key] = RedactedValue
+		default:
+			if nested, ok := value.(map[string]interface{}); ok {
+				out[key] = redactSettings(nested, path, redact)
+			} else {
+				out[key] = value
+			}
+		}
+	}
+	return out
+}
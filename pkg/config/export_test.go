@@ -0,0 +1,104 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/viper"
+)
+
+func createExportTestConfig() *viper.Viper {
+	v := viper.New()
+	v.Set("app.name", "scaffold")
+	v.Set("http.port", 8000)
+	v.Set("db.mysql.host", "127.0.0.1")
+	v.Set("db.mysql.password", "secret")
+	v.Set("security.jwt.key", "jwt-key")
+	v.Set("server.middleware.cors", true)
+	return v
+}
+
+func TestExportJSON(t *testing.T) {
+	data, err := ExportJSON(createExportTestConfig(), []string{"password"})
+	if err != nil {
+		t.Fatalf("ExportJSON() returned error: %v", err)
+	}
+
+	var exported map[string]interface{}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("Failed to parse exported JSON: %v", err)
+	}
+
+	mysql := exported["db"].(map[string]interface{})["mysql"].(map[string]interface{})
+	if mysql["host"] != "127.0.0.1" {
+		t.Errorf("Expected host '127.0.0.1', got '%v'", mysql["host"])
+	}
+	if mysql["password"] != RedactedValue {
+		t.Errorf("Expected password to be redacted, got '%v'", mysql["password"])
+	}
+}
+
+func TestExportTOMLRoundTrip(t *testing.T) {
+	v := createExportTestConfig()
+
+	data, err := ExportTOML(v, nil)
+	if err != nil {
+		t.Fatalf("ExportTOML() returned error: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if _, err := toml.Decode(string(data), &decoded); err != nil {
+		t.Fatalf("Failed to decode exported TOML: %v", err)
+	}
+
+	roundTrip := viper.New()
+	if err := roundTrip.MergeConfigMap(decoded); err != nil {
+		t.Fatalf("Failed to load decoded TOML: %v", err)
+	}
+
+	for _, key := range v.AllKeys() {
+		if roundTrip.GetString(key) != v.GetString(key) {
+			t.Errorf("Key %s: expected '%s', got '%s'", key, v.GetString(key), roundTrip.GetString(key))
+		}
+	}
+}
+
+func TestExportRedaction(t *testing.T) {
+	testCases := []struct {
+		name       string
+		redactKeys []string
+		key        string
+		redacted   bool
+	}{
+		{"leaf key match", []string{"password"}, "db.mysql.password", true},
+		{"case insensitive match", []string{"PASSWORD"}, "db.mysql.password", true},
+		{"full path match", []string{"security.jwt.key"}, "security.jwt.key", true},
+		{"nested section match", []string{"jwt"}, "security.jwt", true},
+		{"unrelated key", []string{"password"}, "db.mysql.host", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := ExportTOML(createExportTestConfig(), tc.redactKeys)
+			if err != nil {
+				t.Fatalf("ExportTOML() returned error: %v", err)
+			}
+
+			var decoded map[string]interface{}
+			if _, err := toml.Decode(string(data), &decoded); err != nil {
+				t.Fatalf("Failed to decode exported TOML: %v", err)
+			}
+
+			exported := viper.New()
+			if err := exported.MergeConfigMap(decoded); err != nil {
+				t.Fatalf("Failed to load decoded TOML: %v", err)
+			}
+
+			isRedacted := exported.GetString(tc.key) == RedactedValue
+			if isRedacted != tc.redacted {
+				t.Errorf("Expected %s redacted=%v, got value '%s'", tc.key, tc.redacted, exported.GetString(tc.key))
+			}
+		})
+	}
+}
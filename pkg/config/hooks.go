@@ -0,0 +1,121 @@
+package config
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// hookRegistry tracks change hooks and the last seen values for a single viper instance.
+type hookRegistry struct {
+	mu     sync.Mutex
+	v      *viper.Viper
+	values map[string]interface{}
+	hooks  map[string]map[int]func(oldVal, newVal interface{})
+	nextID int
+
+	// listeners receive every config file event, see WatchConfig
+	listeners []func(fsnotify.Event)
+	watching  bool
+}
+
+var (
+	registriesMu sync.Mutex
+	registries   = make(map[*viper.Viper]*hookRegistry)
+)
+
+// OnChange registers fn to be called whenever the value of key changes in the watched config file.
+// The first hook registered for v takes over v.OnConfigChange. Hooks only fire once the file is watched
+// with WatchConfig, so registering them does not start a watcher on its own.
+func OnChange(v *viper.Viper, key string, fn func(oldVal, newVal interface{})) (cancel func()) {
+	reg := registryFor(v)
+
+	reg.mu.Lock()
+	if _, ok := reg.values[key]; !ok {
+		reg.values[key] = v.Get(key)
+	}
+	if reg.hooks[key] == nil {
+		reg.hooks[key] = make(map[int]func(oldVal, newVal interface{}))
+	}
+	id := reg.nextID
+	reg.nextID++
+	reg.hooks[key][id] = fn
+	reg.mu.Unlock()
+
+	return func() {
+		reg.mu.Lock()
+		defer reg.mu.Unlock()
+		delete(reg.hooks[key], id)
+	}
+}
+
+// registryFor returns the hook registry for v, creating it on first use.
+func registryFor(v *viper.Viper) *hookRegistry {
+	registriesMu.Lock()
+	defer registriesMu.Unlock()
+
+	if reg, ok := registries[v]; ok {
+		return reg
+	}
+
+	reg := &hookRegistry{
+		v:      v,
+		values: make(map[string]interface{}),
+		hooks:  make(map[string]map[int]func(oldVal, newVal interface{})),
+	}
+	registries[v] = reg
+
+	v.OnConfigChange(reg.handle)
+
+	return reg
+}
+
+// watch starts watching the config file of v, once per registry.
+func (r *hookRegistry) watch() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.watching {
+		return
+	}
+	r.watching = true
+	r.v.WatchConfig()
+}
+
+// handle passes the event to the listeners, then compares each watched key against its last seen value
+// and fires the hooks for changed keys.
+func (r *hookRegistry) handle(e fsnotify.Event) {
+	r.mu.Lock()
+	listeners := append([]func(fsnotify.Event){}, r.listeners...)
+	r.mu.Unlock()
+	for _, listener := range listeners {
+		listener(e)
+	}
+
+	type call struct {
+		fn             func(oldVal, newVal interface{})
+		oldVal, newVal interface{}
+	}
+	var calls []call
+
+	r.mu.Lock()
+	for key, hooks := range r.hooks {
+		oldVal := r.values[key]
+		newVal := r.v.Get(key)
+		if reflect.DeepEqual(oldVal, newVal) {
+			continue
+		}
+		r.values[key] = newVal
+
+		for _, fn := range hooks {
+			calls = append(calls, call{fn: fn, oldVal: oldVal, newVal: newVal})
+		}
+	}
+	r.mu.Unlock()
+
+	// Run hooks outside the lock so they can register or cancel other hooks
+	for _, c := range calls {
+		c.fn(c.oldVal, c.newVal)
+	}
+}
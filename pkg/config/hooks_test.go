@@ -0,0 +1,230 @@
+package config
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+func writeConfigFile(t *testing.T, path, level string) {
+	t.Helper()
+	content := "log:\n  level: \"" + level + "\"\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+}
+
+func TestOnChangeFiresHooks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	writeConfigFile(t, path, "info")
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+
+	type change struct {
+		oldVal, newVal interface{}
+	}
+	first := make(chan change, 1)
+	second := make(chan change, 1)
+	cancelled := make(chan change, 1)
+
+	cancelFirst := OnChange(v, "log.level", func(oldVal, newVal interface{}) {
+		first <- change{oldVal, newVal}
+	})
+	defer cancelFirst()
+	cancelSecond := OnChange(v, "log.level", func(oldVal, newVal interface{}) {
+		second <- change{oldVal, newVal}
+	})
+	defer cancelSecond()
+	cancel := OnChange(v, "log.level", func(oldVal, newVal interface{}) {
+		cancelled <- change{oldVal, newVal}
+	})
+	cancel()
+
+	WatchConfig(v, nil, log.NewConsoleLoggerWithWriter(log.InfoLevel, io.Discard, false))
+	writeConfigFile(t, path, "debug")
+
+	for name, ch := range map[string]chan change{"first": first, "second": second} {
+		select {
+		case got := <-ch:
+			if got.oldVal != "info" || got.newVal != "debug" {
+				t.Errorf("Expected %s hook to receive info -> debug, got %v -> %v", name, got.oldVal, got.newVal)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for %s hook", name)
+		}
+	}
+
+	select {
+	case got := <-cancelled:
+		t.Errorf("Cancelled hook should not fire, got %v -> %v", got.oldVal, got.newVal)
+	default:
+	}
+}
+
+func TestOnChangeIgnoresUnchangedKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	writeConfigFile(t, path, "info")
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+
+	fired := make(chan struct{}, 1)
+	cancel := OnChange(v, "log.level", func(oldVal, newVal interface{}) {
+		fired <- struct{}{}
+	})
+	defer cancel()
+
+	reg := registryFor(v)
+	reg.handle(fsnotify.Event{Name: path, Op: fsnotify.Write})
+
+	select {
+	case <-fired:
+		t.Error("Hook should not fire when the value is unchanged")
+	default:
+	}
+}
+
+func TestOnChangeDoesNotWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	writeConfigFile(t, path, "info")
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+
+	cancel := OnChange(v, "log.level", func(oldVal, newVal interface{}) {})
+	defer cancel()
+
+	if registryFor(v).watching {
+		t.Error("Expected OnChange not to start the file watcher")
+	}
+
+	WatchConfig(v, nil, log.NewConsoleLoggerWithWriter(log.InfoLevel, io.Discard, false))
+	if !registryFor(v).watching {
+		t.Error("Expected WatchConfig to start the file watcher")
+	}
+}
+
+func TestWatchConfigCallsOnChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	writeConfigFile(t, path, "info")
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+
+	events := make(chan fsnotify.Event, 10)
+	WatchConfig(v, func(e fsnotify.Event) {
+		events <- e
+	}, log.NewConsoleLoggerWithWriter(log.InfoLevel, io.Discard, false))
+
+	writeConfigFile(t, path, "debug")
+
+	select {
+	case <-events:
+		if level := v.GetString("log.level"); level != "debug" {
+			t.Errorf("Expected the reloaded log.level 'debug', got %q", level)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected onChange to be called within 2 seconds")
+	}
+}
+
+func TestWatchConfigLogsChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte("log:\n  level: info\ndb:\n  mysql:\n    password: old-secret\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+
+	var buf bytes.Buffer
+	var mu sync.Mutex
+	reloaded := make(chan struct{}, 10)
+	WatchConfig(v, func(fsnotify.Event) {
+		reloaded <- struct{}{}
+	}, log.NewConsoleLoggerWithWriter(log.InfoLevel, &lockedWriter{mu: &mu, w: &buf}, false))
+
+	if err := os.WriteFile(path, []byte("log:\n  level: debug\ndb:\n  mysql:\n    password: new-secret\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	select {
+	case <-reloaded:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected onChange to be called within 2 seconds")
+	}
+
+	mu.Lock()
+	output := buf.String()
+	mu.Unlock()
+	for _, expected := range []string{"Config key changed", "log.level", "db.mysql.password", log.RedactedValue} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in the audit log, got:\n%s", expected, output)
+		}
+	}
+	if strings.Contains(output, "old-secret") || strings.Contains(output, "new-secret") {
+		t.Errorf("Expected the password to be redacted, got:\n%s", output)
+	}
+	if strings.Contains(output, RedactedValue) {
+		t.Errorf("Expected the logger's redaction marker only, got:\n%s", output)
+	}
+}
+
+func TestLogConfigChangesRedactsWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	changes := []ConfigChange{
+		{Key: "log.level", Before: "info", After: "debug", Type: ChangeChanged},
+		{Key: "server.jwt.secret", After: "added-secret", Type: ChangeAdded},
+	}
+
+	logConfigChanges(changes, []string{"secret"}, log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 audit entries, got %d:\n%s", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], `"old":"info"`) || !strings.Contains(lines[0], `"new":"debug"`) {
+		t.Errorf("Expected log.level to be logged in clear, got: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], `"old":""`) || !strings.Contains(lines[1], `"new":"`+log.RedactedValue+`"`) {
+		t.Errorf("Expected only the added secret value to be redacted, got: %s", lines[1])
+	}
+}
+
+// lockedWriter serializes writes from the watcher goroutine with reads in the test
+type lockedWriter struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
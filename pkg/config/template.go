@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/viper"
+)
+
+// Render executes templateText as a Go template with the config's settings as data,
+// e.g. "{{.db.mysql.user}}@{{.db.mysql.host}}". Referencing a missing key is an error.
+func Render(v *viper.Viper, templateText string) (string, error) {
+	tmpl, err := template.New("config").Option("missingkey=error").Parse(templateText)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse config template: %w", err)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, v.AllSettings()); err != nil {
+		return "", fmt.Errorf("failed to render config template: %w", err)
+	}
+	return sb.String(), nil
+}
+
+// RenderKey reads the value of key as a template and renders it against the config.
+func RenderKey(v *viper.Viper, key string) (string, error) {
+	if !v.IsSet(key) {
+		return "", fmt.Errorf("config key %s is not set", key)
+	}
+
+	rendered, err := Render(v, v.GetString(key))
+	if err != nil {
+		return "", fmt.Errorf("config key %s: %w", key, err)
+	}
+	return rendered, nil
+}
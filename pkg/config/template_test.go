@@ -0,0 +1,72 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func createTemplateTestConfig() *viper.Viper {
+	v := viper.New()
+	v.Set("app.name", "scaffold")
+	v.Set("db.mysql.user", "app")
+	v.Set("db.mysql.password", "secret")
+	v.Set("db.mysql.host", "localhost")
+	v.Set("db.mysql.port", 3306)
+	v.Set("db.mysql.database", "scaffold")
+	v.Set("db.mysql.dsn", "{{.db.mysql.user}}:{{.db.mysql.password}}@tcp({{.db.mysql.host}}:{{.db.mysql.port}})/{{.db.mysql.database}}")
+	return v
+}
+
+func TestRender(t *testing.T) {
+	v := createTemplateTestConfig()
+
+	testCases := []struct {
+		name      string
+		template  string
+		expected  string
+		expectErr bool
+	}{
+		{"plain text", "no placeholders", "no placeholders", false},
+		{"simple interpolation", "app={{.app.name}}", "app=scaffold", false},
+		{"nested map access", "{{.db.mysql.host}}:{{.db.mysql.port}}", "localhost:3306", false},
+		{"nested map with index", `{{index .db.mysql "user"}}`, "app", false},
+		{"missing key", "{{.db.mysql.nope}}", "", true},
+		{"missing section", "{{.cache.redis.host}}", "", true},
+		{"invalid template", "{{.app.name", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Render(v, tc.template)
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("Expected error, got result %q", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestRenderKey(t *testing.T) {
+	v := createTemplateTestConfig()
+
+	dsn, err := RenderKey(v, "db.mysql.dsn")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if expected := "app:secret@tcp(localhost:3306)/scaffold"; dsn != expected {
+		t.Errorf("Expected %q, got %q", expected, dsn)
+	}
+
+	if _, err := RenderKey(v, "db.mysql.missing"); err == nil {
+		t.Error("Expected error for unset key, got nil")
+	}
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// DefaultSchemaFile is the JSON Schema looked up next to the config file by --validate-config
+const DefaultSchemaFile = "schema.json"
+
+// ValidationError lists the keys of a config that do not match its JSON Schema
+type ValidationError struct {
+	Problems []string // one "key: description" entry per violation
+}
+
+// Error lists each problem on its own line
+func (e *ValidationError) Error() string {
+	return "config does not match the schema:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// ValidateConfig checks the settings of conf against the JSON Schema at schemaPath.
+// It returns a *ValidationError naming each offending key when the config does not match.
+func ValidateConfig(conf *viper.Viper, schemaPath string) error {
+	document, err := json.Marshal(conf.AllSettings())
+	if err != nil {
+		return fmt.Errorf("failed to encode config as JSON: %w", err)
+	}
+
+	absPath, err := filepath.Abs(schemaPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve schema path %s: %w", schemaPath, err)
+	}
+	schema := gojsonschema.NewReferenceLoader("file://" + filepath.ToSlash(absPath))
+
+	result, err := gojsonschema.Validate(schema, gojsonschema.NewBytesLoader(document))
+	if err != nil {
+		return fmt.Errorf("failed to validate config against %s: %w", schemaPath, err)
+	}
+	if result.Valid() {
+		return nil
+	}
+
+	problems := make([]string, 0, len(result.Errors()))
+	for _, resultErr := range result.Errors() {
+		key := resultErr.Field()
+		if key == gojsonschema.STRING_ROOT_SCHEMA_PROPERTY {
+			key = "config"
+		}
+		description := resultErr.Description()
+		if resultErr.Type() == "pattern" {
+			// The raw regular expression is not helpful, e.g. for durations such as "30 seconds"
+			description = fmt.Sprintf("invalid format %q", fmt.Sprint(resultErr.Value()))
+		}
+		problems = append(problems, key+": "+description)
+	}
+	return &ValidationError{Problems: problems}
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const schemaPath = "../../configs/schema.json"
+
+func TestValidateConfig(t *testing.T) {
+	if err := ValidateConfig(getConfig("testdata/valid.yml"), schemaPath); err != nil {
+		t.Errorf("Expected valid.yml to match the schema, got %v", err)
+	}
+}
+
+func TestValidateConfigReportsEachKey(t *testing.T) {
+	err := ValidateConfig(getConfig("testdata/invalid.yml"), schemaPath)
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+
+	message := err.Error()
+	for _, key := range []string{
+		"config: http is required",
+		`server.shutdown_timeout: invalid format "30 seconds"`,
+		"server.middleware.recover",
+		"db.driver",
+		"db.mysql.port",
+		"log.level",
+		"log.loggers.console: driver is required",
+	} {
+		if !strings.Contains(message, key) {
+			t.Errorf("Expected a problem for %q, got:\n%s", key, message)
+		}
+	}
+	if strings.Contains(message, "(root)") {
+		t.Errorf("Expected the root to be reported as config, got:\n%s", message)
+	}
+}
+
+func TestValidateShippedConfigs(t *testing.T) {
+	paths, err := filepath.Glob("../../configs/*.yml")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("Failed to find shipped configs: %v", err)
+	}
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			if err := ValidateConfig(getConfig(path), schemaPath); err != nil {
+				t.Errorf("Expected %s to match the schema, got %v", path, err)
+			}
+		})
+	}
+}
+
+func TestValidateConfigMissingSchema(t *testing.T) {
+	err := ValidateConfig(getConfig("testdata/valid.yml"), filepath.Join(t.TempDir(), "schema.json"))
+	if err == nil {
+		t.Fatal("Expected an error for a missing schema")
+	}
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		t.Errorf("Expected a load error rather than a validation error, got %v", err)
+	}
+}
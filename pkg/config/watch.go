@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// WatchConfig reloads conf whenever its config file changes and calls onChange after each reload.
+// Every changed key is logged at info level as an audit trail, with the values of the keys in
+// config_audit.redact_keys (DefaultRedactKeys when unset) redacted.
+// It starts the file watcher shared with OnChange, whose hooks only fire once WatchConfig has been called.
+// Settings read once at startup, such as the server port, still require a restart.
+func WatchConfig(conf *viper.Viper, onChange func(e fsnotify.Event), logger log.Logger) {
+	previous := snapshot(conf)
+	reg := registryFor(conf)
+
+	reg.mu.Lock()
+	reg.listeners = append(reg.listeners, func(e fsnotify.Event) {
+		logger.Info("Config file reloaded", log.String("file", e.Name), log.String("op", e.Op.String()))
+		current := snapshot(conf)
+		logConfigChanges(Diff(previous, current), auditRedactKeys(current), logger)
+		previous = current
+
+		if onChange != nil {
+			onChange(e)
+		}
+	})
+	reg.mu.Unlock()
+	reg.watch()
+
+	logger.Info("Watching config file for changes", log.String("file", conf.ConfigFileUsed()))
+}
+
+// WatchEnabled reports whether APP_WATCH_CONFIG is "true", which makes the server call WatchConfig
+func WatchEnabled() bool {
+	return os.Getenv("APP_WATCH_CONFIG") == "true"
+}
+
+// snapshot copies the current settings of conf, so they can be diffed after the next reload
+func snapshot(conf *viper.Viper) *viper.Viper {
+	copied := viper.New()
+	_ = copied.MergeConfigMap(conf.AllSettings())
+	return copied
+}
+
+// auditRedactKeys returns config_audit.redact_keys, or DefaultRedactKeys when unset
+func auditRedactKeys(conf *viper.Viper) []string {
+	if conf.IsSet("config_audit.redact_keys") {
+		return conf.GetStringSlice("config_audit.redact_keys")
+	}
+	return DefaultRedactKeys
+}
+
+// logConfigChanges logs each change, with the values of sensitive keys redacted by the logger
+// (see log.Logger.WithRedactedKeys) so the audit trail uses the same marker as the rest of the log
+func logConfigChanges(changes []ConfigChange, redactKeys []string, logger log.Logger) {
+	redact := redactKeySet(redactKeys)
+	for _, change := range changes {
+		changeLogger := logger
+		if isRedactedPath(change.Key, redact) {
+			changeLogger = logger.WithRedactedKeys(redactedValueFields(change)...)
+		}
+		changeLogger.Info("Config key changed",
+			log.String("key", change.Key),
+			log.String("type", change.Type),
+			log.String("old", formatValue(change.Before)),
+			log.String("new", formatValue(change.After)),
+		)
+	}
+}
+
+// redactedValueFields returns the log fields holding the values of change, leaving the empty
+// value of an added or removed key unredacted
+func redactedValueFields(change ConfigChange) []string {
+	var fields []string
+	if change.Before != nil {
+		fields = append(fields, "old")
+	}
+	if change.After != nil {
+		fields = append(fields, "new")
+	}
+	return fields
+}
+
+// formatValue renders a config value for the audit log, empty for added or removed keys
+func formatValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
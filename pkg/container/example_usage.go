@@ -46,7 +46,10 @@ func (s *FiberServer) SetupBusinessRoutes(
 
 // In main.go - stays clean regardless of number of services
 database := db.MustConnect(conf, logger)
-container := container.NewTypedContainer(conf, logger, database)
+container, err := container.NewTypedContainer(conf, logger, database)
+if err != nil {
+    logger.Fatal("Failed to create dependency container", log.Error(err))
+}
 
 server.RunWithCustomSetup(conf, logger, func(s *server.FiberServer) {
     s.SetupBusinessRoutesWithContainer(container)
@@ -0,0 +1,58 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/MayukhSobo/scaffold/pkg/health"
+)
+
+// remoteCheckTTL is how long the results of the checks dialing remote services are reused between probes
+const remoteCheckTTL = 10 * time.Second
+
+// HealthChecks returns the registry of the dependency checks, run by the server's /readyz.
+// Critical dependencies are added with Register, those the service degrades without with RegisterOptional.
+func (c *TypedContainer) HealthChecks() *health.Registry {
+	c.healthChecksOnce.Do(func() {
+		c.healthChecks = health.NewRegistry(health.DefaultTimeout, c.logger)
+	})
+	return c.healthChecks
+}
+
+// registerDefaultHealthChecks registers checks for the configured infrastructure
+func (c *TypedContainer) registerDefaultHealthChecks() {
+	checks := c.HealthChecks()
+	if c.database != nil {
+		checks.Register("database", health.NewDatabaseCheck(c.database))
+	}
+
+	if c.kafkaProducer != nil {
+		if brokers := c.config.GetStringSlice("messaging.kafka.brokers"); len(brokers) > 0 {
+			checks.RegisterOptional("kafka", health.Cached(dialCheck(brokers[0]), remoteCheckTTL))
+		}
+	}
+
+	// Datadog log intake is best effort, losing it only degrades the service
+	for name := range c.config.GetStringMap("log.loggers") {
+		key := "log.loggers." + name
+		if c.config.GetString(key+".driver") != "datadog" || !c.config.GetBool(key+".enabled") {
+			continue
+		}
+		address := net.JoinHostPort(c.config.GetString(key+".host"), c.config.GetString(key+".port"))
+		checks.RegisterOptional(name, health.Cached(dialCheck(address), remoteCheckTTL))
+	}
+}
+
+// dialCheck returns a check that opens and closes a TCP connection to address
+func dialCheck(address string) health.Check {
+	return func(ctx context.Context) error {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return fmt.Errorf("failed to connect to %s: %w", address, err)
+		}
+		return conn.Close()
+	}
+}
@@ -0,0 +1,76 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/MayukhSobo/scaffold/pkg/health"
+)
+
+func TestTypedContainerHealthChecks(t *testing.T) {
+	failing := func(ctx context.Context) error { return errors.New("connection refused") }
+	passing := func(ctx context.Context) error { return nil }
+
+	testCases := []struct {
+		name           string
+		database       health.Check
+		datadog        health.Check
+		expectedStatus string
+	}{
+		{"all healthy", passing, passing, health.StatusReady},
+		{"optional component failing", passing, failing, health.StatusReady},
+		{"critical component failing", failing, passing, health.StatusNotReady},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			container := &TypedContainer{logger: createTestLogger()}
+			container.HealthChecks().Register("database", tc.database)
+			container.HealthChecks().RegisterOptional("datadog", tc.datadog)
+
+			report := container.HealthChecks().Run(context.Background())
+			if report.Status != tc.expectedStatus {
+				t.Errorf("Expected status %s, got %s", tc.expectedStatus, report.Status)
+			}
+			if len(report.Checks) != 2 {
+				t.Errorf("Expected 2 checks, got %v", report.Checks)
+			}
+		})
+	}
+}
+
+func TestRegisterDefaultHealthChecksDatadog(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	conf := createTestConfig()
+	conf.Set("log.loggers.datadog.driver", "datadog")
+	conf.Set("log.loggers.datadog.enabled", true)
+	conf.Set("log.loggers.datadog.host", host)
+	conf.Set("log.loggers.datadog.port", port)
+	conf.Set("log.loggers.console.driver", "console")
+	conf.Set("log.loggers.console.enabled", true)
+
+	container := &TypedContainer{config: conf, logger: createTestLogger()}
+	container.registerDefaultHealthChecks()
+
+	report := container.HealthChecks().Run(context.Background())
+	if len(report.Checks) != 1 {
+		t.Fatalf("Expected only the datadog check, got %v", report.Checks)
+	}
+	if result := report.Checks["datadog"]; result != health.CheckOK {
+		t.Errorf("Expected datadog check ok, got %s", result)
+	}
+
+	// The result is reused between probes, the intake is not dialled again
+	listener.Close()
+	if report := container.HealthChecks().Run(context.Background()); report.Checks["datadog"] != health.CheckOK {
+		t.Errorf("Expected the cached datadog result, got %s", report.Checks["datadog"])
+	}
+}
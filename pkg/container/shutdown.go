@@ -0,0 +1,95 @@
+package container
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Shutdown priorities used by TypedContainer.Close and the server runner, higher runs first
+const (
+	ShutdownPriorityServer    = 100 // Stop accepting requests and drain the in-flight ones
+	ShutdownPriorityResources = 75  // Closers added with RegisterCloser, eg: the Kafka producer
+	ShutdownPriorityDatabase  = 50
+	ShutdownPriorityLogger    = 10 // Flush buffered log entries last so the other hooks can still log
+)
+
+// shutdownHook is a teardown function registered with a ShutdownRegistry
+type shutdownHook struct {
+	name     string
+	priority int
+	seq      int
+	fn       func(ctx context.Context) error
+}
+
+// shutdownQueue is a min-heap of hooks whose root is the hook to run next: the highest priority,
+// and for equal priorities the most recently registered one
+type shutdownQueue []*shutdownHook
+
+func (q shutdownQueue) Len() int { return len(q) }
+
+func (q shutdownQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq > q[j].seq
+}
+
+func (q shutdownQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *shutdownQueue) Push(x any) { *q = append(*q, x.(*shutdownHook)) }
+
+func (q *shutdownQueue) Pop() any {
+	old := *q
+	hook := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return hook
+}
+
+// ShutdownRegistry runs teardown hooks in priority order, higher priorities first and hooks of equal
+// priority in reverse registration order. The zero value is ready to use.
+type ShutdownRegistry struct {
+	mu    sync.Mutex
+	hooks shutdownQueue
+	seq   int
+}
+
+// NewShutdownRegistry creates an empty shutdown registry
+func NewShutdownRegistry() *ShutdownRegistry {
+	return &ShutdownRegistry{}
+}
+
+// Register adds a hook to run on Shutdown
+func (r *ShutdownRegistry) Register(name string, priority int, fn func(ctx context.Context) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	heap.Push(&r.hooks, &shutdownHook{name: name, priority: priority, seq: r.seq, fn: fn})
+	r.seq++
+}
+
+// Len returns the number of hooks waiting to run
+func (r *ShutdownRegistry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.hooks.Len()
+}
+
+// Shutdown runs every registered hook once, passing ctx so the hooks share its deadline, and returns
+// the errors of the hooks that failed, prefixed with their name. A failing hook does not stop the others.
+func (r *ShutdownRegistry) Shutdown(ctx context.Context) []error {
+	var errs []error
+	for {
+		r.mu.Lock()
+		if r.hooks.Len() == 0 {
+			r.mu.Unlock()
+			return errs
+		}
+		hook := heap.Pop(&r.hooks).(*shutdownHook)
+		r.mu.Unlock()
+
+		if err := hook.fn(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("shutdown hook %s: %w", hook.name, err))
+		}
+	}
+}
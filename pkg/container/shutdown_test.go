@@ -0,0 +1,109 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestShutdownRegistryOrder(t *testing.T) {
+	registry := NewShutdownRegistry()
+
+	var order []string
+	record := func(name string) func(context.Context) error {
+		return func(context.Context) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+	registry.Register("logger", ShutdownPriorityLogger, record("logger"))
+	registry.Register("fiber", ShutdownPriorityServer, record("fiber"))
+	registry.Register("database", ShutdownPriorityDatabase, record("database"))
+	registry.Register("kafka", ShutdownPriorityResources, record("kafka"))
+	registry.Register("cache", ShutdownPriorityResources, record("cache"))
+
+	if errs := registry.Shutdown(context.Background()); len(errs) != 0 {
+		t.Fatalf("Expected no errors, got %v", errs)
+	}
+
+	expected := []string{"fiber", "cache", "kafka", "database", "logger"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("Expected order %v, got %v", expected, order)
+	}
+	if registry.Len() != 0 {
+		t.Errorf("Expected the hooks to run once, %d left", registry.Len())
+	}
+}
+
+func TestShutdownRegistryTimeoutPropagation(t *testing.T) {
+	registry := NewShutdownRegistry()
+
+	var laterCtxErr error
+	registry.Register("slow", ShutdownPriorityServer, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	registry.Register("later", ShutdownPriorityDatabase, func(ctx context.Context) error {
+		laterCtxErr = ctx.Err()
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	errs := registry.Shutdown(ctx)
+
+	if len(errs) != 1 || !errors.Is(errs[0], context.DeadlineExceeded) {
+		t.Errorf("Expected the slow hook to return the deadline error, got %v", errs)
+	}
+	if !errors.Is(laterCtxErr, context.DeadlineExceeded) {
+		t.Errorf("Expected later hooks to receive the expired context, got %v", laterCtxErr)
+	}
+}
+
+func TestShutdownRegistryCollectsErrors(t *testing.T) {
+	registry := NewShutdownRegistry()
+
+	failure := errors.New("flush failed")
+	ran := 0
+	registry.Register("first", 3, func(context.Context) error { ran++; return nil })
+	registry.Register("failing", 2, func(context.Context) error { ran++; return failure })
+	registry.Register("last", 1, func(context.Context) error { ran++; return nil })
+
+	errs := registry.Shutdown(context.Background())
+	if ran != 3 {
+		t.Errorf("Expected every hook to run, got %d", ran)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], failure) {
+		t.Fatalf("Expected the failing hook's error, got %v", errs)
+	}
+	if errs[0].Error() != "shutdown hook failing: flush failed" {
+		t.Errorf("Expected the error to name the hook, got '%s'", errs[0].Error())
+	}
+}
+
+func TestTypedContainerCloseRunsShutdownRegistry(t *testing.T) {
+	logger := &closeCountingLogger{Logger: createTestLogger()}
+	container := &TypedContainer{logger: logger}
+
+	var order []string
+	container.RegisterCloser(func() error { order = append(order, "closer"); return nil })
+	container.ShutdownRegistry().Register("fiber", ShutdownPriorityServer, func(context.Context) error {
+		if logger.closed != 0 {
+			t.Error("Expected the logger to be open during the server shutdown")
+		}
+		order = append(order, "fiber")
+		return nil
+	})
+
+	if err := container.Close(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(order, []string{"fiber", "closer"}) {
+		t.Errorf("Expected the server hook before the closers, got %v", order)
+	}
+	if logger.closed != 1 {
+		t.Errorf("Expected the logger to be closed last, got %d closes", logger.closed)
+	}
+}
@@ -1,64 +1,274 @@
 package container
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
 
 	"github.com/spf13/viper"
 
+	"github.com/MayukhSobo/scaffold/internal/repository/products"
 	"github.com/MayukhSobo/scaffold/internal/repository/users"
 	"github.com/MayukhSobo/scaffold/internal/service"
+	"github.com/MayukhSobo/scaffold/pkg/cache"
+	"github.com/MayukhSobo/scaffold/pkg/db"
+	"github.com/MayukhSobo/scaffold/pkg/events"
+	"github.com/MayukhSobo/scaffold/pkg/health"
 	"github.com/MayukhSobo/scaffold/pkg/log"
+	"github.com/MayukhSobo/scaffold/pkg/messaging"
 )
 
 // TypedContainer provides type-safe dependency injection
 // This version uses specific interfaces for better type safety
 type TypedContainer struct {
 	// Infrastructure
-	config   *viper.Viper
-	logger   log.Logger
-	database *sql.DB
+	config        *viper.Viper
+	logger        log.Logger
+	database      *sql.DB
+	dbBreaker     *db.CircuitBreakerDB // nil unless db.circuit_breaker.enabled is set, see initializeCircuitBreaker
+	kafkaProducer *messaging.KafkaProducer
+	cache         cache.Cache // nil unless service.cache.enabled is set, see initializeCache
+	eventBus      events.Bus
+	auditService  *service.AuditService // nil unless audit.enabled is set, see initializeAuditLog
+	closers       []func() error
+	shutdown      ShutdownRegistry
+	closeOnce     sync.Once
+	closeErr      error
+
+	healthChecks     *health.Registry // see HealthChecks
+	healthChecksOnce sync.Once
+
+	// lazyInit defers creating repositories and services to their first getter call, see WithLazyInit
+	lazyInit              bool
+	userRepositoryOnce    sync.Once
+	productRepositoryOnce sync.Once
+	userServiceOnce       sync.Once
+	productServiceOnce    sync.Once
 
 	// Repositories - Type-safe versions
-	userRepository users.Querier
+	userRepository    users.Querier
+	productRepository products.Querier
 	// Add more repositories as interfaces are defined
-	// productRepository products.Querier
 	// orderRepository   orders.Querier
 
 	// Services - Type-safe versions
-	userService service.UserService
+	userService    service.UserService
+	productService service.ProductService
 	// Add more services as interfaces are defined
-	// productService service.ProductService
 	// orderService   service.OrderService
 }
 
+// ErrNilDatabase is returned by NewTypedContainer when no database connection is given
+var ErrNilDatabase = errors.New("container requires a database connection, got nil (use NewTypedContainerWithoutDB when no database is needed)")
+
+// Option configures NewTypedContainer
+type Option func(*TypedContainer)
+
+// WithLazyInit creates repositories and services on the first call to their getter instead of in
+// NewTypedContainer, so tests and CLI tools that never use them do not need a database connection.
+// The database may then be nil as long as no repository or service getter is called.
+func WithLazyInit() Option {
+	return func(c *TypedContainer) {
+		c.lazyInit = true
+	}
+}
+
 // NewTypedContainer creates a new type-safe dependency container
-func NewTypedContainer(config *viper.Viper, logger log.Logger, database *sql.DB) *TypedContainer {
+func NewTypedContainer(config *viper.Viper, logger log.Logger, database *sql.DB, opts ...Option) (*TypedContainer, error) {
 	container := &TypedContainer{
 		config:   config,
 		logger:   logger,
 		database: database,
 	}
+	for _, opt := range opts {
+		opt(container)
+	}
+
+	if container.lazyInit {
+		container.initializeInfrastructure()
+		return container, nil
+	}
 
 	// Initialize all dependencies
-	container.initializeDependencies()
+	if err := container.initializeDependencies(); err != nil {
+		return nil, err
+	}
+
+	return container, nil
+}
+
+// NewTypedContainerWithoutDB creates a container without a database, e.g. for tests and tools
+// that only need config, logging and messaging. Repository-backed services are not usable.
+func NewTypedContainerWithoutDB(config *viper.Viper, logger log.Logger) *TypedContainer {
+	return NewTypedContainerWithRepositories(config, logger, &AllRepositories{})
+}
+
+// NewTypedContainerWithRepositories creates a container whose services use the given repositories
+// instead of ones backed by a database, e.g. mocks in tests
+func NewTypedContainerWithRepositories(config *viper.Viper, logger log.Logger, repositories *AllRepositories) *TypedContainer {
+	container := &TypedContainer{
+		config:            config,
+		logger:            logger,
+		userRepository:    repositories.User,
+		productRepository: repositories.Product,
+	}
+
+	container.initializeServices()
 
 	return container
 }
 
 // initializeDependencies creates all repository and service instances
-func (c *TypedContainer) initializeDependencies() {
+func (c *TypedContainer) initializeDependencies() error {
+	if c.database == nil {
+		return ErrNilDatabase
+	}
+
 	// Initialize repositories
-	c.userRepository = users.New(c.database)
+	c.userRepository = users.New(c.repositoryDB())
+	c.productRepository = products.New(c.repositoryDB())
+	// c.orderRepository = orders.New(c.database)
+
+	c.initializeServices()
+	return nil
+}
+
+// repositoryDB returns the connection the repositories query, guarded by the circuit breaker when there is one
+func (c *TypedContainer) repositoryDB() users.DBTX {
+	if c.dbBreaker != nil {
+		return c.dbBreaker
+	}
+	return c.database
+}
+
+// initializeCircuitBreaker wraps the database with the circuit breaker configured by db.circuit_breaker
+func (c *TypedContainer) initializeCircuitBreaker() {
+	if c.database == nil {
+		return
+	}
+	breaker, err := db.NewCircuitBreakerDBFromConfig(c.database, c.config)
+	if err != nil {
+		c.logger.Error("Failed to create database circuit breaker, queries are not guarded", log.Error(err))
+		return
+	}
+	c.dbBreaker = breaker
+}
+
+// initializeServices creates the infrastructure clients and services on top of the repositories
+func (c *TypedContainer) initializeServices() {
+	c.initializeInfrastructure()
 
 	// Initialize base service
-	baseService := service.NewService(c.logger)
+	baseService := c.newBaseService()
 
 	// Initialize services with their dependencies
-	c.userService = service.NewUserService(baseService, c.userRepository)
+	c.userService = c.withUserCache(baseService, service.NewUserService(baseService, c.userRepository, service.NewTokenConfig(c.config), c.userServiceOptions()...))
+	c.productService = service.NewProductService(baseService, c.productRepository)
+
+	// Future services can be added here
+	// c.orderService = service.NewOrderService(baseService, c.orderRepository)
+}
+
+// newBaseService creates the base service embedded by the services, publishing on the container's event bus
+func (c *TypedContainer) newBaseService() *service.Service {
+	return service.NewService(c.logger).WithEventBus(c.eventBus)
+}
 
-	// Future repositories and services can be added here
-	// c.productRepository = products.New(c.database)
-	// c.productService = service.NewProductService(baseService, c.productRepository)
+// userServiceOptions runs the user service's grouped queries in transactions on the container's database, if any
+func (c *TypedContainer) userServiceOptions() []service.UserServiceOption {
+	if c.database == nil {
+		return nil
+	}
+	return []service.UserServiceOption{service.WithTransactions(service.NewTxRunner(c.database))}
+}
+
+// withUserCache wraps userService with the container's cache, caching lookups for service.cache.user_ttl
+func (c *TypedContainer) withUserCache(baseService *service.Service, userService service.UserService) service.UserService {
+	if c.cache == nil {
+		return userService
+	}
+	return service.NewCachedUserService(baseService, userService, c.cache, c.config.GetDuration("service.cache.user_ttl"))
+}
+
+// initializeCache creates the cache of cache.driver, "memory" or "redis", when service.cache.enabled is set.
+// The memory cache is used when Redis cannot be reached.
+func (c *TypedContainer) initializeCache() {
+	if !c.config.GetBool("service.cache.enabled") {
+		return
+	}
+
+	switch driver := c.config.GetString("cache.driver"); driver {
+	case "redis":
+		redisCache, err := cache.NewRedisCache(c.config, c.logger)
+		if err != nil {
+			c.logger.Error("Failed to create Redis cache, using the memory cache", log.Error(err))
+			break
+		}
+		rc := redisCache.(*cache.RedisCache)
+		c.cache = rc
+		c.RegisterCloser(rc.Close)
+		c.HealthChecks().RegisterOptional("redis", rc.HealthCheck)
+		return
+	case "", "memory":
+	default:
+		c.logger.Warn("Unknown cache driver, using the memory cache", log.String("driver", driver))
+	}
+	c.cache = cache.NewMemoryCache(0)
+}
+
+// initializeInfrastructure creates the infrastructure clients and registers the default health checks
+func (c *TypedContainer) initializeInfrastructure() {
+	// Initialize the Kafka producer when brokers are configured
+	if c.config.IsSet("messaging.kafka.brokers") {
+		producer, err := messaging.NewKafkaProducer(c.config, c.logger)
+		if err != nil {
+			c.logger.Error("Failed to create Kafka producer", log.Error(err))
+		} else {
+			c.kafkaProducer = producer
+			c.RegisterCloser(producer.Close)
+		}
+	}
+
+	c.initializeCircuitBreaker()
+	c.initializeEventBus()
+	c.initializeCache()
+	c.registerDefaultHealthChecks()
+}
+
+// initializeEventBus creates the domain event bus, an AsyncBus queueing events.buffer_size events when
+// events.async is set and a synchronous MemoryBus otherwise, and subscribes the audit log to it
+func (c *TypedContainer) initializeEventBus() {
+	var asyncBus *events.AsyncBus
+	if c.config.GetBool("events.async") {
+		asyncBus = events.NewAsyncBus(c.config.GetInt("events.buffer_size"), c.logger)
+		c.eventBus = asyncBus
+	} else {
+		c.eventBus = events.NewMemoryBus()
+	}
+
+	// Closers run in reverse registration order, so the audit log is flushed after the bus has drained
+	c.initializeAuditLog()
+	if asyncBus != nil {
+		c.RegisterCloser(asyncBus.Close)
+	}
+}
+
+// initializeAuditLog persists the domain events to the audit_log table when audit.enabled is set
+func (c *TypedContainer) initializeAuditLog() {
+	if !c.config.GetBool("audit.enabled") {
+		return
+	}
+	if c.database == nil {
+		c.logger.Warn("Audit log requires a database, audit.enabled is ignored")
+		return
+	}
+
+	c.auditService = service.NewAuditService(c.database, c.logger, c.config.GetDuration("audit.flush_interval"))
+	c.auditService.Subscribe(c.eventBus)
+	c.RegisterCloser(c.auditService.Close)
 }
 
 // Infrastructure getters
@@ -74,30 +284,126 @@ func (c *TypedContainer) GetDatabase() *sql.DB {
 	return c.database
 }
 
-// Repository getters
+// GetCircuitBreaker returns the circuit breaker guarding the repositories, nil unless db.circuit_breaker.enabled is set
+func (c *TypedContainer) GetCircuitBreaker() *db.CircuitBreakerDB {
+	return c.dbBreaker
+}
+
+// GetKafkaProducer returns the Kafka producer, or nil when messaging.kafka is not configured
+func (c *TypedContainer) GetKafkaProducer() *messaging.KafkaProducer {
+	return c.kafkaProducer
+}
+
+// GetEventBus returns the bus the services publish their domain events on
+func (c *TypedContainer) GetEventBus() events.Bus {
+	return c.eventBus
+}
+
+// GetAuditService returns the service writing the audit log, nil unless audit.enabled is set
+func (c *TypedContainer) GetAuditService() *service.AuditService {
+	return c.auditService
+}
+
+// GetCache returns the cache selected by cache.driver, nil unless service.cache.enabled is set
+func (c *TypedContainer) GetCache() cache.Cache {
+	return c.cache
+}
+
+// RegisterCloser adds a function to run when the container is closed
+func (c *TypedContainer) RegisterCloser(closer func() error) {
+	c.closers = append(c.closers, closer)
+}
+
+// ShutdownRegistry returns the registry run by Close, hooks registered here run alongside the
+// container's own resources according to their priority
+func (c *TypedContainer) ShutdownRegistry() *ShutdownRegistry {
+	return &c.shutdown
+}
+
+// Close runs the shutdown registry: hooks such as the server shutdown first, then the registered closers
+// in reverse registration order, the database, and last the logger when it implements io.Closer, flushing
+// buffered log entries. It gives up waiting when ctx is done and returns the context error, the remaining
+// hooks still run in the background. Calls after the first return the first result.
+func (c *TypedContainer) Close(ctx context.Context) error {
+	c.closeOnce.Do(func() {
+		c.closeErr = c.close(ctx)
+	})
+	return c.closeErr
+}
+
+// close registers the container resources with the shutdown registry and runs it until it finishes or ctx is done
+func (c *TypedContainer) close(ctx context.Context) error {
+	for i, closer := range c.closers {
+		c.shutdown.Register(fmt.Sprintf("closer-%d", i), ShutdownPriorityResources, func(context.Context) error {
+			return closer()
+		})
+	}
+	c.closers = nil
+	if c.database != nil {
+		c.shutdown.Register("database", ShutdownPriorityDatabase, func(context.Context) error {
+			return c.database.Close()
+		})
+	}
+	if closer, ok := c.logger.(io.Closer); ok {
+		c.shutdown.Register("logger", ShutdownPriorityLogger, func(context.Context) error {
+			return closer.Close()
+		})
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- errors.Join(c.shutdown.Shutdown(ctx)...)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("container close interrupted: %w", ctx.Err())
+	}
+}
+
+// Repository getters, with WithLazyInit the repository is created on the first call
 func (c *TypedContainer) GetUserRepository() users.Querier {
+	if c.lazyInit {
+		c.userRepositoryOnce.Do(func() { c.userRepository = users.New(c.repositoryDB()) })
+	}
 	return c.userRepository
 }
 
-// Service getters
+func (c *TypedContainer) GetProductRepository() products.Querier {
+	if c.lazyInit {
+		c.productRepositoryOnce.Do(func() { c.productRepository = products.New(c.repositoryDB()) })
+	}
+	return c.productRepository
+}
+
+// Service getters, with WithLazyInit the service and its repository are created on the first call
 func (c *TypedContainer) GetUserService() service.UserService {
+	if c.lazyInit {
+		c.userServiceOnce.Do(func() {
+			baseService := c.newBaseService()
+			c.userService = c.withUserCache(baseService, service.NewUserService(baseService, c.GetUserRepository(), service.NewTokenConfig(c.config), c.userServiceOptions()...))
+		})
+	}
 	return c.userService
 }
 
-// Future repository getters (example templates)
-// func (c *TypedContainer) GetProductRepository() products.Querier {
-//     return c.productRepository
-// }
+func (c *TypedContainer) GetProductService() service.ProductService {
+	if c.lazyInit {
+		c.productServiceOnce.Do(func() {
+			c.productService = service.NewProductService(c.newBaseService(), c.GetProductRepository())
+		})
+	}
+	return c.productService
+}
 
+// Future repository getters (example templates)
 // func (c *TypedContainer) GetOrderRepository() orders.Querier {
 //     return c.orderRepository
 // }
 
 // Future service getters (example templates)
-// func (c *TypedContainer) GetProductService() service.ProductService {
-//     return c.productService
-// }
-
 // func (c *TypedContainer) GetOrderService() service.OrderService {
 //     return c.orderService
 // }
@@ -105,8 +411,8 @@ func (c *TypedContainer) GetUserService() service.UserService {
 // GetAllServices returns a struct containing all services for easy access
 func (c *TypedContainer) GetAllServices() *AllServices {
 	return &AllServices{
-		User: c.userService,
-		// Product: c.productService,
+		User:    c.GetUserService(),
+		Product: c.GetProductService(),
 		// Order:   c.orderService,
 	}
 }
@@ -114,8 +420,8 @@ func (c *TypedContainer) GetAllServices() *AllServices {
 // AllServices provides a single struct containing all services
 // This makes it easy to pass all services to routes/controllers
 type AllServices struct {
-	User service.UserService
-	// Product service.ProductService
+	User    service.UserService
+	Product service.ProductService
 	// Order   service.OrderService
 	// Email   service.EmailService
 	// Auth    service.AuthService
@@ -124,8 +430,8 @@ type AllServices struct {
 // GetAllRepositories returns a struct containing all repositories for easy access
 func (c *TypedContainer) GetAllRepositories() *AllRepositories {
 	return &AllRepositories{
-		User: c.userRepository,
-		// Product: c.productRepository,
+		User:    c.GetUserRepository(),
+		Product: c.GetProductRepository(),
 		// Order:   c.orderRepository,
 	}
 }
@@ -133,7 +439,7 @@ func (c *TypedContainer) GetAllRepositories() *AllRepositories {
 // AllRepositories provides a single struct containing all repositories
 // This can be useful for testing or advanced scenarios
 type AllRepositories struct {
-	User users.Querier
-	// Product products.Querier
+	User    users.Querier
+	Product products.Querier
 	// Order   orders.Querier
 }
@@ -3,12 +3,20 @@ package container
 import (
 	"bytes"
 	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
+	_ "modernc.org/sqlite"
 
 	"github.com/MayukhSobo/scaffold/internal/repository/users"
 	"github.com/MayukhSobo/scaffold/internal/service"
+	"github.com/MayukhSobo/scaffold/pkg/db"
+	"github.com/MayukhSobo/scaffold/pkg/events"
 	"github.com/MayukhSobo/scaffold/pkg/log"
 )
 
@@ -47,6 +55,67 @@ func TestNewTypedContainer(t *testing.T) {
 	}
 }
 
+func TestNewTypedContainerNilDatabase(t *testing.T) {
+	container, err := NewTypedContainer(createTestConfig(), createTestLogger(), nil)
+	if !errors.Is(err, ErrNilDatabase) {
+		t.Fatalf("Expected ErrNilDatabase, got %v", err)
+	}
+	if container != nil {
+		t.Error("Expected no container when the database is nil")
+	}
+	if !strings.Contains(err.Error(), "NewTypedContainerWithoutDB") {
+		t.Errorf("Expected error to point to NewTypedContainerWithoutDB, got %q", err.Error())
+	}
+}
+
+func TestNewTypedContainerWithLazyInit(t *testing.T) {
+	container, err := NewTypedContainer(createTestConfig(), createTestLogger(), nil, WithLazyInit())
+	if err != nil {
+		t.Fatalf("Expected no error without a database in lazy mode, got %v", err)
+	}
+	if container.userRepository != nil || container.userService != nil {
+		t.Error("Expected repositories and services to be created on first use")
+	}
+	if container.GetConfig() == nil || container.GetLogger() == nil {
+		t.Error("Expected infrastructure getters to work without a database")
+	}
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open sqlite database: %v", err)
+	}
+	defer db.Close()
+
+	container, err = NewTypedContainer(createTestConfig(), createTestLogger(), db, WithLazyInit())
+	if err != nil {
+		t.Fatalf("Failed to create container: %v", err)
+	}
+	repo := container.GetUserRepository()
+	if repo == nil {
+		t.Fatal("Expected the user repository to be created on first use")
+	}
+	if container.GetUserRepository() != repo {
+		t.Error("Expected the same user repository on every call")
+	}
+	if container.GetUserService() == nil || container.GetAllServices().Product == nil {
+		t.Error("Expected services to be created on first use")
+	}
+}
+
+func TestNewTypedContainerWithoutDB(t *testing.T) {
+	container := NewTypedContainerWithoutDB(createTestConfig(), createTestLogger())
+
+	if container.GetDatabase() != nil {
+		t.Error("Expected no database")
+	}
+	if container.GetUserService() == nil || container.GetProductService() == nil {
+		t.Error("Expected services to be initialized")
+	}
+	if _, ok := container.HealthChecks().Run(context.Background()).Checks["database"]; ok {
+		t.Error("Expected no database health check without a database")
+	}
+}
+
 func TestTypedContainerGetters(t *testing.T) {
 	conf := createTestConfig()
 	logger := createTestLogger()
@@ -177,6 +246,26 @@ func (m *mockUserRepository) GetPendingVerificationUsers(ctx context.Context) ([
 	return []users.User{{ID: 2, Username: "pending"}}, nil
 }
 
+func (m *mockUserRepository) CreateUser(ctx context.Context, arg users.CreateUserParams) (sql.Result, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockUserRepository) CreateRefreshToken(ctx context.Context, arg users.CreateRefreshTokenParams) error {
+	return nil
+}
+
+func (m *mockUserRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (users.RefreshToken, error) {
+	return users.RefreshToken{}, sql.ErrNoRows
+}
+
+func (m *mockUserRepository) RevokeRefreshToken(ctx context.Context, id uint64) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockUserRepository) RevokeUserRefreshTokens(ctx context.Context, userID uint64) error {
+	return nil
+}
+
 func TestContainerWithMockDependencies(t *testing.T) {
 	// This demonstrates how the container can work with mock dependencies for testing
 	conf := createTestConfig()
@@ -210,6 +299,152 @@ func TestContainerWithMockDependencies(t *testing.T) {
 	}
 }
 
+func TestTypedContainerUserCache(t *testing.T) {
+	tests := []struct {
+		name         string
+		enabled      bool
+		driver       string
+		expectCached bool
+	}{
+		{name: "memory cache", enabled: true, driver: "memory", expectCached: true},
+		{name: "default driver", enabled: true, expectCached: true},
+		{name: "unreachable redis", enabled: true, driver: "redis", expectCached: true},
+		{name: "cache disabled", enabled: false, driver: "memory", expectCached: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := createTestConfig()
+			conf.Set("service.cache.enabled", tt.enabled)
+			conf.Set("service.cache.user_ttl", "30s")
+			conf.Set("cache.driver", tt.driver)
+			conf.Set("cache.redis.addr", "127.0.0.1:1") // Nothing listens, Redis falls back to memory
+
+			container := NewTypedContainerWithRepositories(conf, createTestLogger(), &AllRepositories{User: &mockUserRepository{}})
+			_, cached := container.GetUserService().(*service.CachedUserService)
+			if cached != tt.expectCached {
+				t.Errorf("Expected cached user service %v, got %v", tt.expectCached, cached)
+			}
+			if (container.GetCache() != nil) != tt.enabled {
+				t.Errorf("Expected a cache %v, got %v", tt.enabled, container.GetCache())
+			}
+		})
+	}
+}
+
+func TestTypedContainerCircuitBreaker(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		conn, err := sql.Open("sqlite", ":memory:")
+		if err != nil {
+			t.Fatalf("Failed to open database: %v", err)
+		}
+		defer conn.Close()
+
+		conf := createTestConfig()
+		conf.Set("db.circuit_breaker.enabled", enabled)
+		container, err := NewTypedContainer(conf, createTestLogger(), conn)
+		if err != nil {
+			t.Fatalf("Failed to create container: %v", err)
+		}
+
+		if (container.GetCircuitBreaker() != nil) != enabled {
+			t.Errorf("Expected a circuit breaker %v, got %v", enabled, container.GetCircuitBreaker())
+		}
+		if _, guarded := container.repositoryDB().(*db.CircuitBreakerDB); guarded != enabled {
+			t.Errorf("Expected the repositories to use the circuit breaker %v, got %v", enabled, guarded)
+		}
+	}
+}
+
+func TestTypedContainerEventBus(t *testing.T) {
+	tests := []struct {
+		name        string
+		async       bool
+		expectAsync bool
+	}{
+		{name: "synchronous by default", async: false, expectAsync: false},
+		{name: "async", async: true, expectAsync: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := createTestConfig()
+			conf.Set("events.async", tt.async)
+
+			container := NewTypedContainerWithRepositories(conf, createTestLogger(), &AllRepositories{User: &mockUserRepository{}})
+			defer func() { _ = container.Close(context.Background()) }()
+
+			_, async := container.GetEventBus().(*events.AsyncBus)
+			if async != tt.expectAsync {
+				t.Errorf("Expected async bus %v, got %v", tt.expectAsync, async)
+			}
+		})
+	}
+}
+
+func TestTypedContainerAuditLog(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "audit.db")
+	db, err := sql.Open("sqlite", dbFile)
+	if err != nil {
+		t.Fatalf("Failed to open sqlite database: %v", err)
+	}
+	if _, err := db.Exec(`CREATE TABLE audit_log (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		event_type TEXT NOT NULL,
+		actor_id INTEGER NULL,
+		payload TEXT NOT NULL,
+		created_at TIMESTAMP NOT NULL
+	)`); err != nil {
+		t.Fatalf("Failed to create audit_log table: %v", err)
+	}
+
+	conf := createTestConfig()
+	conf.Set("audit.enabled", true)
+	conf.Set("audit.flush_interval", "1h")
+	conf.Set("events.async", true)
+	container, err := NewTypedContainer(conf, createTestLogger(), db)
+	if err != nil {
+		t.Fatalf("Failed to create container: %v", err)
+	}
+	if container.GetAuditService() == nil {
+		t.Fatal("Expected the audit service to be created")
+	}
+
+	for i := 0; i < 3; i++ {
+		event := events.Event{Type: service.EventUserCreated, Payload: service.UserCreated{UserID: uint64(i + 1)}}
+		if err := container.GetEventBus().Publish(context.Background(), event); err != nil {
+			t.Fatalf("Publish() returned error: %v", err)
+		}
+	}
+	// Closing drains the async bus before the audit log flushes its buffered records
+	if err := container.Close(context.Background()); err != nil {
+		t.Fatalf("Close() returned error: %v", err)
+	}
+
+	db, err = sql.Open("sqlite", dbFile)
+	if err != nil {
+		t.Fatalf("Failed to reopen sqlite database: %v", err)
+	}
+	defer db.Close()
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM audit_log WHERE event_type = ?", service.EventUserCreated).Scan(&count); err != nil {
+		t.Fatalf("Failed to count audit records: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 audit records, got %d", count)
+	}
+}
+
+func TestTypedContainerAuditLogWithoutDB(t *testing.T) {
+	conf := createTestConfig()
+	conf.Set("audit.enabled", true)
+
+	container := NewTypedContainerWithoutDB(conf, createTestLogger())
+	if container.GetAuditService() != nil {
+		t.Error("Expected no audit service without a database")
+	}
+}
+
 // Example test showing how container makes testing easier
 func TestContainerDrivenHandler(t *testing.T) {
 	// Setup container with mocks
@@ -221,7 +456,7 @@ func TestContainerDrivenHandler(t *testing.T) {
 
 	// Create service with mocked dependencies
 	baseService := service.NewService(container.GetLogger())
-	container.userService = service.NewUserService(baseService, container.GetUserRepository())
+	container.userService = service.NewUserService(baseService, container.GetUserRepository(), service.NewTokenConfig(container.GetConfig()))
 
 	// Test that services work through container
 	userService := container.GetUserService()
@@ -235,3 +470,88 @@ func TestContainerDrivenHandler(t *testing.T) {
 		t.Error("All services should include user service")
 	}
 }
+
+func TestTypedContainerKafkaProducer(t *testing.T) {
+	// Without brokers no producer is created
+	container := NewTypedContainerWithoutDB(createTestConfig(), createTestLogger())
+	if container.GetKafkaProducer() != nil {
+		t.Error("Expected no Kafka producer without messaging.kafka.brokers")
+	}
+
+	conf := createTestConfig()
+	conf.Set("messaging.kafka.brokers", []string{"localhost:9092"})
+	container = NewTypedContainerWithoutDB(conf, createTestLogger())
+	if container.GetKafkaProducer() == nil {
+		t.Fatal("Expected a Kafka producer when brokers are configured")
+	}
+	if len(container.closers) != 1 {
+		t.Errorf("Expected the producer to register a closer, got %d", len(container.closers))
+	}
+	if err := container.Close(context.Background()); err != nil {
+		t.Errorf("Expected no error closing the container, got %v", err)
+	}
+}
+
+func TestTypedContainerCloseOrder(t *testing.T) {
+	container := &TypedContainer{}
+
+	var order []int
+	closeErr := errors.New("close failed")
+	container.RegisterCloser(func() error { order = append(order, 1); return nil })
+	container.RegisterCloser(func() error { order = append(order, 2); return closeErr })
+
+	if err := container.Close(context.Background()); !errors.Is(err, closeErr) {
+		t.Errorf("Expected close error to be returned, got %v", err)
+	}
+	if len(order) != 2 || order[0] != 2 || order[1] != 1 {
+		t.Errorf("Expected closers to run in reverse order, got %v", order)
+	}
+}
+
+// closeCountingLogger is a logger that records how often it is closed
+type closeCountingLogger struct {
+	log.Logger
+	closed int
+}
+
+func (l *closeCountingLogger) Close() error {
+	l.closed++
+	return nil
+}
+
+func TestTypedContainerCloseIdempotent(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open sqlite database: %v", err)
+	}
+	logger := &closeCountingLogger{Logger: createTestLogger()}
+	container := &TypedContainer{logger: logger, database: db}
+
+	closed := 0
+	container.RegisterCloser(func() error { closed++; return nil })
+
+	for i := 0; i < 2; i++ {
+		if err := container.Close(context.Background()); err != nil {
+			t.Fatalf("Expected no error on close %d, got %v", i+1, err)
+		}
+	}
+	if closed != 1 || logger.closed != 1 {
+		t.Errorf("Expected the closer and logger to be closed once, got %d and %d", closed, logger.closed)
+	}
+	if err := db.Ping(); err == nil {
+		t.Error("Expected the database to be closed")
+	}
+}
+
+func TestTypedContainerCloseDeadline(t *testing.T) {
+	container := &TypedContainer{logger: createTestLogger()}
+	release := make(chan struct{})
+	defer close(release)
+	container.RegisterCloser(func() error { <-release; return nil })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := container.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the deadline to interrupt a slow closer, got %v", err)
+	}
+}
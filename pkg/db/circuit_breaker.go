@@ -0,0 +1,231 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/spf13/viper"
+)
+
+// ErrCircuitOpen is returned without querying the database while the circuit breaker is open
+var ErrCircuitOpen = errors.New("database circuit breaker is open")
+
+// Circuit breaker states reported by CircuitBreakerDB.State
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+// CircuitBreakerConfig holds the circuit breaker configuration
+type CircuitBreakerConfig struct {
+	Threshold    int           `mapstructure:"threshold"`     // consecutive failures that open the circuit
+	ResetTimeout time.Duration `mapstructure:"reset_timeout"` // time the circuit stays open before a trial query is let through
+}
+
+// circuitBreakerTarget is the subset of *sql.DB guarded by the circuit breaker, the sqlc DBTX interface and PingContext
+type circuitBreakerTarget interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	PingContext(ctx context.Context) error
+}
+
+// CircuitBreakerDB wraps a database so that, once Threshold consecutive queries have failed, queries fail fast
+// with ErrCircuitOpen instead of waiting on an unreachable server. After ResetTimeout a single trial query is
+// let through (half-open); its success closes the circuit and its failure opens it again.
+// Only connection and timeout errors count as failures, see isCircuitFailure. It implements the DBTX
+// interface of the sqlc generated repositories.
+type CircuitBreakerDB struct {
+	target circuitBreakerTarget
+	config CircuitBreakerConfig
+	now    func() time.Time
+
+	mu                  sync.Mutex
+	state               string
+	consecutiveFailures int
+	openedAt            time.Time
+	trialInFlight       bool
+}
+
+// NewCircuitBreakerDB wraps d with a circuit breaker
+func NewCircuitBreakerDB(d *sql.DB, config CircuitBreakerConfig) *CircuitBreakerDB {
+	return newCircuitBreakerDB(d, config)
+}
+
+// NewCircuitBreakerDBFromConfig wraps d with a circuit breaker configured by db.circuit_breaker,
+// or returns nil when db.circuit_breaker.enabled is not set
+func NewCircuitBreakerDBFromConfig(d *sql.DB, conf *viper.Viper) (*CircuitBreakerDB, error) {
+	if !conf.GetBool("db.circuit_breaker.enabled") {
+		return nil, nil
+	}
+	var config CircuitBreakerConfig
+	if err := conf.UnmarshalKey("db.circuit_breaker", &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal db.circuit_breaker config: %w", err)
+	}
+	return NewCircuitBreakerDB(d, config), nil
+}
+
+// newCircuitBreakerDB wraps any circuitBreakerTarget, so tests can use a mock
+func newCircuitBreakerDB(target circuitBreakerTarget, config CircuitBreakerConfig) *CircuitBreakerDB {
+	return &CircuitBreakerDB{
+		target: target,
+		config: applyCircuitBreakerDefaults(config),
+		now:    time.Now,
+		state:  CircuitClosed,
+	}
+}
+
+// applyCircuitBreakerDefaults fills in missing circuit breaker configuration values
+func applyCircuitBreakerDefaults(config CircuitBreakerConfig) CircuitBreakerConfig {
+	if config.Threshold <= 0 {
+		config.Threshold = 5
+	}
+	if config.ResetTimeout <= 0 {
+		config.ResetTimeout = 30 * time.Second
+	}
+	return config
+}
+
+// ExecContext executes a query without returning rows, see sql.DB.ExecContext
+func (cb *CircuitBreakerDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if err := cb.allow(); err != nil {
+		return nil, err
+	}
+	result, err := cb.target.ExecContext(ctx, query, args...)
+	cb.record(err)
+	return result, err
+}
+
+// QueryContext executes a query that returns rows, see sql.DB.QueryContext
+func (cb *CircuitBreakerDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if err := cb.allow(); err != nil {
+		return nil, err
+	}
+	rows, err := cb.target.QueryContext(ctx, query, args...)
+	cb.record(err)
+	return rows, err
+}
+
+// PrepareContext creates a prepared statement, see sql.DB.PrepareContext
+func (cb *CircuitBreakerDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	if err := cb.allow(); err != nil {
+		return nil, err
+	}
+	stmt, err := cb.target.PrepareContext(ctx, query)
+	cb.record(err)
+	return stmt, err
+}
+
+// QueryRowContext executes a query that returns at most one row, see sql.DB.QueryRowContext.
+// A *sql.Row cannot carry ErrCircuitOpen, so while the circuit is open the query is sent with a cancelled
+// context instead: database/sql fails it before taking a connection and Scan returns context.Canceled.
+func (cb *CircuitBreakerDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	if err := cb.allow(); err != nil {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		return cb.target.QueryRowContext(cancelled, query, args...)
+	}
+	row := cb.target.QueryRowContext(ctx, query, args...)
+	cb.record(row.Err())
+	return row
+}
+
+// PingContext verifies the database is reachable, a successful ping in half-open state closes the circuit
+func (cb *CircuitBreakerDB) PingContext(ctx context.Context) error {
+	if err := cb.allow(); err != nil {
+		return err
+	}
+	err := cb.target.PingContext(ctx)
+	cb.record(err)
+	return err
+}
+
+// State returns "closed", "open" or "half-open"
+func (cb *CircuitBreakerDB) State() string {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state == CircuitOpen && cb.resetTimeoutElapsed() {
+		return CircuitHalfOpen
+	}
+	return cb.state
+}
+
+// ConsecutiveFailures returns the number of failed queries since the last successful one
+func (cb *CircuitBreakerDB) ConsecutiveFailures() int {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.consecutiveFailures
+}
+
+// allow returns ErrCircuitOpen unless a query may be sent to the database
+func (cb *CircuitBreakerDB) allow() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case CircuitOpen:
+		if !cb.resetTimeoutElapsed() {
+			return ErrCircuitOpen
+		}
+		cb.state = CircuitHalfOpen
+		cb.trialInFlight = true
+		return nil
+	case CircuitHalfOpen:
+		// Only the trial query is let through until its outcome is known
+		if cb.trialInFlight {
+			return ErrCircuitOpen
+		}
+		cb.trialInFlight = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// record updates the breaker with the outcome of a query
+func (cb *CircuitBreakerDB) record(err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.trialInFlight = false
+	if !isCircuitFailure(err) {
+		cb.consecutiveFailures = 0
+		cb.state = CircuitClosed
+		return
+	}
+
+	cb.consecutiveFailures++
+	if cb.state == CircuitHalfOpen || cb.consecutiveFailures >= cb.config.Threshold {
+		cb.state = CircuitOpen
+		cb.openedAt = cb.now()
+	}
+}
+
+// resetTimeoutElapsed reports whether the open circuit may let a trial query through, cb.mu must be held
+func (cb *CircuitBreakerDB) resetTimeoutElapsed() bool {
+	return cb.now().Sub(cb.openedAt) >= cb.config.ResetTimeout
+}
+
+// isCircuitFailure reports whether err indicates an unreachable or unresponsive database: broken connections,
+// network errors and timeouts. Query errors such as missing rows or constraint violations say nothing about
+// the server, and neither do queries cancelled by the caller.
+func isCircuitFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.As(err, &netErr)
+}
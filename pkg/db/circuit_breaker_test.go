@@ -0,0 +1,206 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/spf13/viper"
+)
+
+// mockCircuitTarget implements circuitBreakerTarget, every call goes through exec
+type mockCircuitTarget struct {
+	exec  func() error
+	calls int
+}
+
+func (m *mockCircuitTarget) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	m.calls++
+	return nil, m.exec()
+}
+
+func (m *mockCircuitTarget) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	m.calls++
+	return nil, m.exec()
+}
+
+func (m *mockCircuitTarget) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	m.calls++
+	return nil, m.exec()
+}
+
+func (m *mockCircuitTarget) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	m.calls++
+	return &sql.Row{}
+}
+
+func (m *mockCircuitTarget) PingContext(ctx context.Context) error {
+	m.calls++
+	return m.exec()
+}
+
+func TestCircuitBreakerDB(t *testing.T) {
+	errUnreachable := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	failing := true
+	target := &mockCircuitTarget{exec: func() error {
+		if failing {
+			return errUnreachable
+		}
+		return nil
+	}}
+
+	now := time.Now()
+	cb := newCircuitBreakerDB(target, CircuitBreakerConfig{Threshold: 3, ResetTimeout: time.Minute})
+	cb.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := cb.ExecContext(ctx, "UPDATE users SET name = ?", "a"); !errors.Is(err, errUnreachable) {
+			t.Fatalf("Expected the database error on attempt %d, got %v", i+1, err)
+		}
+	}
+	if state := cb.State(); state != CircuitOpen {
+		t.Fatalf("Expected state %q after %d failures, got %q", CircuitOpen, cb.ConsecutiveFailures(), state)
+	}
+
+	// Open: fail fast without reaching the database
+	if _, err := cb.ExecContext(ctx, "UPDATE users SET name = ?", "a"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if target.calls != 3 {
+		t.Errorf("Expected 3 database calls, got %d", target.calls)
+	}
+
+	// Half-open: a failed trial opens the circuit again
+	now = now.Add(time.Minute)
+	if state := cb.State(); state != CircuitHalfOpen {
+		t.Fatalf("Expected state %q after the reset timeout, got %q", CircuitHalfOpen, state)
+	}
+	if _, err := cb.ExecContext(ctx, "UPDATE users SET name = ?", "a"); !errors.Is(err, errUnreachable) {
+		t.Fatalf("Expected the trial to reach the database, got %v", err)
+	}
+	if state := cb.State(); state != CircuitOpen {
+		t.Fatalf("Expected state %q after a failed trial, got %q", CircuitOpen, state)
+	}
+
+	// Half-open: a successful trial closes the circuit
+	now = now.Add(time.Minute)
+	failing = false
+	if _, err := cb.ExecContext(ctx, "UPDATE users SET name = ?", "a"); err != nil {
+		t.Fatalf("Expected the trial to succeed, got %v", err)
+	}
+	if state := cb.State(); state != CircuitClosed {
+		t.Errorf("Expected state %q after a successful trial, got %q", CircuitClosed, state)
+	}
+	if failures := cb.ConsecutiveFailures(); failures != 0 {
+		t.Errorf("Expected the failure count to reset, got %d", failures)
+	}
+}
+
+func TestCircuitBreakerDBIgnoresNoRows(t *testing.T) {
+	target := &mockCircuitTarget{exec: func() error { return sql.ErrNoRows }}
+	cb := newCircuitBreakerDB(target, CircuitBreakerConfig{Threshold: 1})
+
+	for i := 0; i < 3; i++ {
+		_, _ = cb.QueryContext(context.Background(), "SELECT 1")
+	}
+	if state := cb.State(); state != CircuitClosed {
+		t.Errorf("Expected state %q, got %q", CircuitClosed, state)
+	}
+}
+
+func TestApplyCircuitBreakerDefaults(t *testing.T) {
+	config := applyCircuitBreakerDefaults(CircuitBreakerConfig{})
+	if config.Threshold != 5 {
+		t.Errorf("Expected default threshold 5, got %d", config.Threshold)
+	}
+	if config.ResetTimeout != 30*time.Second {
+		t.Errorf("Expected default reset timeout 30s, got %s", config.ResetTimeout)
+	}
+}
+
+func TestIsCircuitFailure(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"no error", nil, false},
+		{"no rows", sql.ErrNoRows, false},
+		{"cancelled by the caller", context.Canceled, false},
+		{"duplicate key", &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}, false},
+		{"generic query error", errors.New("syntax error"), false},
+		{"bad connection", fmt.Errorf("query: %w", driver.ErrBadConn), true},
+		{"connection done", sql.ErrConnDone, true},
+		{"invalid mysql connection", mysql.ErrInvalidConn, true},
+		{"timeout", context.DeadlineExceeded, true},
+		{"network error", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isCircuitFailure(tt.err); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerDBIgnoresConstraintErrors(t *testing.T) {
+	target := &mockCircuitTarget{exec: func() error { return &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"} }}
+	cb := newCircuitBreakerDB(target, CircuitBreakerConfig{Threshold: 1})
+
+	for i := 0; i < 3; i++ {
+		_, _ = cb.ExecContext(context.Background(), "INSERT INTO users (email) VALUES (?)", "a@example.com")
+	}
+	if state := cb.State(); state != CircuitClosed {
+		t.Errorf("Expected state %q, got %q", CircuitClosed, state)
+	}
+}
+
+func TestCircuitBreakerDBQueryRow(t *testing.T) {
+	conn, err := sql.Open(sqliteDriverName, sqliteMemoryFile)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer conn.Close()
+
+	cb := NewCircuitBreakerDB(conn, CircuitBreakerConfig{Threshold: 1, ResetTimeout: time.Minute})
+	ctx := context.Background()
+
+	var value int
+	if err := cb.QueryRowContext(ctx, "SELECT 1").Scan(&value); err != nil || value != 1 {
+		t.Fatalf("Expected 1, got %d (%v)", value, err)
+	}
+
+	// Open the circuit as if the server had become unreachable
+	cb.record(driver.ErrBadConn)
+	if err := cb.QueryRowContext(ctx, "SELECT 1").Scan(&value); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the row to fail with context.Canceled while open, got %v", err)
+	}
+}
+
+func TestNewCircuitBreakerDBFromConfig(t *testing.T) {
+	conf := viper.New()
+	cb, err := NewCircuitBreakerDBFromConfig(&sql.DB{}, conf)
+	if err != nil || cb != nil {
+		t.Fatalf("Expected no circuit breaker when disabled, got %v (%v)", cb, err)
+	}
+
+	conf.Set("db.circuit_breaker.enabled", true)
+	conf.Set("db.circuit_breaker.threshold", 3)
+	conf.Set("db.circuit_breaker.reset_timeout", "10s")
+	cb, err = NewCircuitBreakerDBFromConfig(&sql.DB{}, conf)
+	if err != nil {
+		t.Fatalf("Failed to create circuit breaker: %v", err)
+	}
+	if cb.config.Threshold != 3 || cb.config.ResetTimeout != 10*time.Second {
+		t.Errorf("Expected threshold 3 and reset timeout 10s, got %d and %s", cb.config.Threshold, cb.config.ResetTimeout)
+	}
+}
@@ -1,13 +1,16 @@
 package db
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"database/sql"
 	"encoding/base64"
 	"fmt"
-	"regexp"
+	"os"
+	"strings"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 	"github.com/spf13/viper"
 
 	"github.com/MayukhSobo/scaffold/pkg/log"
@@ -15,36 +18,74 @@ import (
 
 // Config holds database configuration
 type Config struct {
-	Host            string        `mapstructure:"host"`
-	Port            string        `mapstructure:"port"`
-	User            string        `mapstructure:"user"`
-	Password        string        `mapstructure:"password"`
-	Name            string        `mapstructure:"name"`
-	MaxOpenConns    int           `mapstructure:"max_open_conns"`
-	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
-	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
-	ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time"`
-	RetryAttempts   int           `mapstructure:"retry_attempts"`
-	RetryDelay      time.Duration `mapstructure:"retry_delay"`
+	Driver            string        `mapstructure:"-"` // set by the parser, "sqlite" skips configureConnectionPool
+	Host              string        `mapstructure:"host"`
+	Port              string        `mapstructure:"port"`
+	User              string        `mapstructure:"user"`
+	Password          string        `mapstructure:"password"`
+	PasswordEncoding  string        `mapstructure:"password_encoding"` // "plain" (default) or "base64"
+	Name              string        `mapstructure:"name"`
+	MaxOpenConns      int           `mapstructure:"max_open_conns"`
+	MaxIdleConns      int           `mapstructure:"max_idle_conns"`
+	ConnMaxLifetime   time.Duration `mapstructure:"conn_max_lifetime"`
+	ConnMaxIdleTime   time.Duration `mapstructure:"conn_max_idle_time"`
+	RetryAttempts     int           `mapstructure:"retry_attempts"`
+	RetryDelay        time.Duration `mapstructure:"retry_delay"`
+	TLS               TLSConfig     `mapstructure:"tls"`
+	SSLMode           string        `mapstructure:"sslmode"`            // PostgreSQL only, see buildPostgresDSN
+	WarmupConnections int           `mapstructure:"warmup_connections"` // idle connections opened at startup, see WarmupConnections
+	File              string        `mapstructure:"file"`               // SQLite only, see NewSQLiteConnection
 }
 
-// NewConnection creates a new database connection using the provided configuration
+// TLSConfig holds the TLS settings for the database connection
+type TLSConfig struct {
+	SkipVerify bool   `mapstructure:"skip_verify"` // encrypts without verifying the certificate, on by default
+	CAFile     string `mapstructure:"ca_file"`
+	CertFile   string `mapstructure:"cert_file"`
+	KeyFile    string `mapstructure:"key_file"`
+}
+
+// customTLSConfigName is the name the custom TLS config is registered under with the MySQL driver
+const customTLSConfigName = "custom"
+
+// NewConnection creates a new database connection for the driver selected by db.driver ("mysql", "postgres" or "sqlite")
 func NewConnection(conf *viper.Viper, logger log.Logger) (*sql.DB, error) {
+	switch driver := conf.GetString("db.driver"); driver {
+	case "", "mysql":
+		return NewMySQLConnection(conf, logger)
+	case "postgres":
+		return NewPostgresConnection(conf, logger)
+	case sqliteDriverName:
+		return NewSQLiteConnection(conf, logger)
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q, expected mysql, postgres or sqlite", driver)
+	}
+}
+
+// NewMySQLConnection creates a new MySQL connection from the db.mysql config keys
+func NewMySQLConnection(conf *viper.Viper, logger log.Logger) (*sql.DB, error) {
 	config, err := parseConfig(conf)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse database config: %w", err)
 	}
 
+	if config.TLS.useCustom() {
+		if err := registerTLSConfig(config.TLS); err != nil {
+			return nil, fmt.Errorf("failed to register database TLS config: %w", err)
+		}
+	}
+
 	dsn := buildDSN(config)
 	logger.Info("Connecting to database", log.String("host", config.Host), log.String("database", config.Name))
 
-	db, err := connectWithRetry(dsn, config, logger)
+	db, err := connectWithRetry("mysql", dsn, config, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database after %d attempts: %w", config.RetryAttempts, err)
 	}
 
 	// Configure connection pool
 	configureConnectionPool(db, config)
+	warmupPool(db, config, logger)
 
 	logger.Info("Database connection established successfully")
 	return db, nil
@@ -65,6 +106,9 @@ func parseConfig(conf *viper.Viper) (*Config, error) {
 		ConnMaxIdleTime: 5 * time.Minute,
 		RetryAttempts:   5,
 		RetryDelay:      2 * time.Second,
+		TLS: TLSConfig{
+			SkipVerify: true, // Backwards compatible default, disabled in the production profile
+		},
 	}
 
 	// Extract database configuration from db.mysql section
@@ -72,8 +116,6 @@ func parseConfig(conf *viper.Viper) (*Config, error) {
 		if err := conf.UnmarshalKey("db.mysql", config); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal db.mysql config: %w", err)
 		}
-		// Decode base64 password if needed
-		config.Password = decodeIfBase64(config.Password)
 	}
 
 	// Override with individual keys if they exist
@@ -87,19 +129,26 @@ func parseConfig(conf *viper.Viper) (*Config, error) {
 		config.User = conf.GetString("db.mysql.user")
 	}
 	if conf.IsSet("db.mysql.password") {
-		config.Password = decodeIfBase64(conf.GetString("db.mysql.password"))
+		config.Password = conf.GetString("db.mysql.password")
 	}
 	if conf.IsSet("db.mysql.database") {
 		config.Name = conf.GetString("db.mysql.database")
 	}
 
+	// Password file (e.g. a mounted Kubernetes secret) takes precedence over db.mysql.password
+	if conf.IsSet("db.mysql.password_file") {
+		password, err := readPasswordFile(conf.GetString("db.mysql.password_file"))
+		if err != nil {
+			return nil, err
+		}
+		config.Password = password
+	}
+
 	// Also support legacy "database" key for backwards compatibility
 	if conf.IsSet("database") {
 		if err := conf.UnmarshalKey("database", config); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal database config: %w", err)
 		}
-		// Decode base64 password if needed
-		config.Password = decodeIfBase64(config.Password)
 	}
 
 	// Legacy database config overrides
@@ -113,33 +162,100 @@ func parseConfig(conf *viper.Viper) (*Config, error) {
 		config.User = conf.GetString("database.user")
 	}
 	if conf.IsSet("database.password") {
-		config.Password = decodeIfBase64(conf.GetString("database.password"))
+		config.Password = conf.GetString("database.password")
 	}
 	if conf.IsSet("database.name") {
 		config.Name = conf.GetString("database.name")
 	}
 
+	password, err := decodePassword(config.Password, config.PasswordEncoding)
+	if err != nil {
+		return nil, err
+	}
+	config.Password = password
+
 	return config, nil
 }
 
+// readPasswordFile reads a password from the given file, trimming surrounding whitespace
+func readPasswordFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("password_file %s not found", path)
+		}
+		return "", fmt.Errorf("failed to read password_file %s: %w", path, err)
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
 // buildDSN constructs the MySQL DSN string
 func buildDSN(config *Config) string {
-	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&charset=utf8mb4&collation=utf8mb4_unicode_ci&tls=skip-verify&allowNativePasswords=true",
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&charset=utf8mb4&collation=utf8mb4_unicode_ci&allowNativePasswords=true",
 		config.User,
 		config.Password,
 		config.Host,
 		config.Port,
 		config.Name,
 	)
+
+	// Custom certificates take precedence over skip-verify; without either the server certificate is verified
+	switch {
+	case config.TLS.useCustom():
+		dsn += "&tls=" + customTLSConfigName
+	case config.TLS.SkipVerify:
+		dsn += "&tls=skip-verify"
+	default:
+		dsn += "&tls=true"
+	}
+
+	return dsn
+}
+
+// useCustom reports whether certificate files are configured for the connection
+func (t TLSConfig) useCustom() bool {
+	return t.CAFile != "" && t.CertFile != ""
+}
+
+// registerTLSConfig loads the configured certificates and registers them with the MySQL driver
+func registerTLSConfig(config TLSConfig) error {
+	caCert, err := os.ReadFile(config.CAFile)
+	if err != nil {
+		return fmt.Errorf("failed to read CA file %s: %w", config.CAFile, err)
+	}
+
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(caCert) {
+		return fmt.Errorf("failed to parse CA certificates from %s", config.CAFile)
+	}
+
+	// The key may live alongside the certificate in the same PEM file
+	keyFile := config.KeyFile
+	if keyFile == "" {
+		keyFile = config.CertFile
+	}
+
+	clientCert, err := tls.LoadX509KeyPair(config.CertFile, keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load client certificate %s: %w", config.CertFile, err)
+	}
+
+	return mysql.RegisterTLSConfig(customTLSConfigName, &tls.Config{
+		RootCAs:            rootCAs,
+		Certificates:       []tls.Certificate{clientCert},
+		InsecureSkipVerify: config.SkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	})
 }
 
 // connectWithRetry attempts to connect to the database with retry logic
-func connectWithRetry(dsn string, config *Config, logger log.Logger) (*sql.DB, error) {
+func connectWithRetry(driverName, dsn string, config *Config, logger log.Logger) (*sql.DB, error) {
 	var db *sql.DB
 	var err error
 
 	for i := 0; i < config.RetryAttempts; i++ {
-		db, err = sql.Open("mysql", dsn)
+		db, err = sql.Open(driverName, dsn)
 		if err != nil {
 			logger.Warn("Failed to open database connection",
 				log.Error(err),
@@ -172,8 +288,12 @@ func connectWithRetry(dsn string, config *Config, logger log.Logger) (*sql.DB, e
 	return nil, err
 }
 
-// configureConnectionPool sets up the database connection pool parameters
+// configureConnectionPool sets up the database connection pool parameters.
+// SQLite keeps the database/sql defaults since its connections are not network sockets.
 func configureConnectionPool(db *sql.DB, config *Config) {
+	if config.Driver == sqliteDriverName {
+		return
+	}
 	db.SetMaxOpenConns(config.MaxOpenConns)
 	db.SetMaxIdleConns(config.MaxIdleConns)
 	db.SetConnMaxLifetime(config.ConnMaxLifetime)
@@ -191,23 +311,18 @@ func MustConnect(conf *viper.Viper, logger log.Logger) *sql.DB {
 	return db
 }
 
-// decodeIfBase64 decodes the password if it looks like base64 encoded data
-func decodeIfBase64(value string) string {
-	if value == "" {
-		return value
-	}
-
-	// Simple check if it looks like base64 (alphanumeric + / + =)
-	base64Pattern := regexp.MustCompile(`^[A-Za-z0-9+/]+=*$`)
-	if !base64Pattern.MatchString(value) || len(value) <= 8 {
-		return value
-	}
-
-	// Try to decode, if it fails, use original value
-	decoded, err := base64.StdEncoding.DecodeString(value)
-	if err != nil {
-		return value
+// decodePassword decodes the password according to the password_encoding key, "plain" or "base64"
+func decodePassword(value, encoding string) (string, error) {
+	switch encoding {
+	case "", "plain":
+		return value, nil
+	case "base64":
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode base64 password: %w", err)
+		}
+		return string(decoded), nil
+	default:
+		return "", fmt.Errorf("unsupported password_encoding %q, expected plain or base64", encoding)
 	}
-
-	return string(decoded)
 }
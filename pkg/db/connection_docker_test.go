@@ -127,9 +127,10 @@ func TestBuildDSNDockerEnvironment(t *testing.T) {
 		User:     "scaffold",
 		Password: "bXlfc2VjdXJlX3Bhc3N3b3JkXzEyMw==",
 		Name:     "user",
+		TLS:      TLSConfig{SkipVerify: true},
 	}
 
-	expectedDSN := "scaffold:bXlfc2VjdXJlX3Bhc3N3b3JkXzEyMw==@tcp(mysql:3306)/user?parseTime=true&charset=utf8mb4&collation=utf8mb4_unicode_ci"
+	expectedDSN := "scaffold:bXlfc2VjdXJlX3Bhc3N3b3JkXzEyMw==@tcp(mysql:3306)/user?parseTime=true&charset=utf8mb4&collation=utf8mb4_unicode_ci&allowNativePasswords=true&tls=skip-verify"
 	actualDSN := buildDSN(config)
 
 	if actualDSN != expectedDSN {
@@ -97,9 +97,10 @@ func TestBuildDSNLocalEnvironment(t *testing.T) {
 		User:     "scaffold",
 		Password: "my_secure_password_123",
 		Name:     "user",
+		TLS:      TLSConfig{SkipVerify: true},
 	}
 
-	expectedDSN := "scaffold:my_secure_password_123@tcp(127.0.0.1:3306)/user?parseTime=true&charset=utf8mb4&collation=utf8mb4_unicode_ci"
+	expectedDSN := "scaffold:my_secure_password_123@tcp(127.0.0.1:3306)/user?parseTime=true&charset=utf8mb4&collation=utf8mb4_unicode_ci&allowNativePasswords=true&tls=skip-verify"
 	actualDSN := buildDSN(config)
 
 	if actualDSN != expectedDSN {
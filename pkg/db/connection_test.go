@@ -2,6 +2,8 @@ package db
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -57,7 +59,7 @@ func TestBuildDSNWithEmptyPassword(t *testing.T) {
 		Name:     "user",
 	}
 
-	expectedDSN := "root:@tcp(localhost:3306)/user?parseTime=true&charset=utf8mb4&collation=utf8mb4_unicode_ci"
+	expectedDSN := "root:@tcp(localhost:3306)/user?parseTime=true&charset=utf8mb4&collation=utf8mb4_unicode_ci&allowNativePasswords=true&tls=true"
 	actualDSN := buildDSN(config)
 
 	if actualDSN != expectedDSN {
@@ -65,6 +67,102 @@ func TestBuildDSNWithEmptyPassword(t *testing.T) {
 	}
 }
 
+func TestBuildDSNTLS(t *testing.T) {
+	base := "scaffold:secret@tcp(localhost:3306)/user?parseTime=true&charset=utf8mb4&collation=utf8mb4_unicode_ci&allowNativePasswords=true"
+
+	tests := []struct {
+		name     string
+		tls      TLSConfig
+		expected string
+	}{
+		{
+			name:     "verification enabled",
+			tls:      TLSConfig{},
+			expected: base + "&tls=true",
+		},
+		{
+			name:     "skip verify",
+			tls:      TLSConfig{SkipVerify: true},
+			expected: base + "&tls=skip-verify",
+		},
+		{
+			name:     "custom certificates",
+			tls:      TLSConfig{CAFile: "ca.pem", CertFile: "client.pem"},
+			expected: base + "&tls=custom",
+		},
+		{
+			name:     "custom certificates take precedence over skip verify",
+			tls:      TLSConfig{SkipVerify: true, CAFile: "ca.pem", CertFile: "client.pem"},
+			expected: base + "&tls=custom",
+		},
+		{
+			name:     "CA file without cert file",
+			tls:      TLSConfig{SkipVerify: true, CAFile: "ca.pem"},
+			expected: base + "&tls=skip-verify",
+		},
+		{
+			name:     "cert file without CA file",
+			tls:      TLSConfig{CertFile: "client.pem"},
+			expected: base + "&tls=true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{
+				Host:     "localhost",
+				Port:     "3306",
+				User:     "scaffold",
+				Password: "secret",
+				Name:     "user",
+				TLS:      tt.tls,
+			}
+
+			actualDSN := buildDSN(config)
+			if actualDSN != tt.expected {
+				t.Errorf("Expected DSN '%s', got '%s'", tt.expected, actualDSN)
+			}
+		})
+	}
+}
+
+func TestParseConfigTLS(t *testing.T) {
+	conf := viper.New()
+
+	config, err := parseConfig(conf)
+	if err != nil {
+		t.Fatalf("Failed to parse config with defaults: %v", err)
+	}
+	if !config.TLS.SkipVerify {
+		t.Error("Expected skip_verify to default to true")
+	}
+
+	conf.Set("db.mysql.tls.skip_verify", false)
+	conf.Set("db.mysql.tls.ca_file", "/etc/ssl/ca.pem")
+	conf.Set("db.mysql.tls.cert_file", "/etc/ssl/client.pem")
+
+	config, err = parseConfig(conf)
+	if err != nil {
+		t.Fatalf("Failed to parse TLS config: %v", err)
+	}
+	if config.TLS.SkipVerify {
+		t.Error("Expected skip_verify to be false")
+	}
+	if config.TLS.CAFile != "/etc/ssl/ca.pem" {
+		t.Errorf("Expected ca_file '/etc/ssl/ca.pem', got '%s'", config.TLS.CAFile)
+	}
+	if config.TLS.CertFile != "/etc/ssl/client.pem" {
+		t.Errorf("Expected cert_file '/etc/ssl/client.pem', got '%s'", config.TLS.CertFile)
+	}
+}
+
+func TestRegisterTLSConfigMissingFiles(t *testing.T) {
+	err := registerTLSConfig(TLSConfig{CAFile: "/nonexistent/ca.pem", CertFile: "/nonexistent/client.pem"})
+	if err == nil {
+		t.Error("Expected error for missing certificate files, got nil")
+	}
+}
+
 func TestParseConfigWithStructuredConfig(t *testing.T) {
 	conf := viper.New()
 
@@ -136,6 +234,51 @@ func TestParseConfigLegacySupport(t *testing.T) {
 	}
 }
 
+func TestParseConfigPasswordFile(t *testing.T) {
+	passwordFile := filepath.Join(t.TempDir(), "db-password")
+	if err := os.WriteFile(passwordFile, []byte("file_password_123\n"), 0600); err != nil {
+		t.Fatalf("Failed to write password file: %v", err)
+	}
+
+	conf := viper.New()
+	conf.Set("db.mysql.host", "127.0.0.1")
+	conf.Set("db.mysql.user", "scaffold")
+	conf.Set("db.mysql.database", "user")
+	conf.Set("db.mysql.password", "inline_password")
+	conf.Set("db.mysql.password_file", passwordFile)
+
+	config, err := parseConfig(conf)
+	if err != nil {
+		t.Fatalf("Failed to parse config with password file: %v", err)
+	}
+
+	if config.Password != "file_password_123" {
+		t.Errorf("Expected password from file 'file_password_123', got '%s'", config.Password)
+	}
+
+	expectedDSN := "scaffold:file_password_123@tcp(127.0.0.1:3306)/user?parseTime=true&charset=utf8mb4&collation=utf8mb4_unicode_ci&allowNativePasswords=true&tls=skip-verify"
+	if actualDSN := buildDSN(config); actualDSN != expectedDSN {
+		t.Errorf("Expected DSN '%s', got '%s'", expectedDSN, actualDSN)
+	}
+}
+
+func TestParseConfigPasswordFileMissing(t *testing.T) {
+	missingFile := filepath.Join(t.TempDir(), "missing")
+
+	conf := viper.New()
+	conf.Set("db.mysql.password_file", missingFile)
+
+	_, err := parseConfig(conf)
+	if err == nil {
+		t.Fatal("Expected error for missing password file, got nil")
+	}
+
+	expected := "password_file " + missingFile + " not found"
+	if err.Error() != expected {
+		t.Errorf("Expected error '%s', got '%s'", expected, err.Error())
+	}
+}
+
 func createTestLogger() log.Logger {
 	var buf bytes.Buffer
 	return log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false)
@@ -156,3 +299,88 @@ func TestNewConnectionInvalidConfig(t *testing.T) {
 		t.Error("Expected connection to fail with invalid host, but it succeeded")
 	}
 }
+
+func TestDecodePassword(t *testing.T) {
+	testCases := []struct {
+		name        string
+		value       string
+		encoding    string
+		expected    string
+		expectError bool
+	}{
+		{name: "empty", value: "", encoding: "base64", expected: ""},
+		{name: "base64 encoded password", value: "bXlfc2VjdXJlX3Bhc3N3b3JkXzEyMw==", encoding: "base64", expected: "my_secure_password_123"},
+		{name: "base64 password kept without encoding", value: "bXlfc2VjdXJlX3Bhc3N3b3JkXzEyMw==", encoding: "", expected: "bXlfc2VjdXJlX3Bhc3N3b3JkXzEyMw=="},
+		{name: "short base64 decoded", value: "c2Vh", encoding: "base64", expected: "sea"},
+		{name: "short base64 kept as plain", value: "c2Vh", encoding: "plain", expected: "c2Vh"},
+		{name: "plain-text valid base64 kept as plain", value: "Password1234", encoding: "plain", expected: "Password1234"},
+		{name: "padded base64 kept as plain", value: "YWRtaW4=", encoding: "plain", expected: "YWRtaW4="},
+		{name: "padded base64 decoded", value: "YWRtaW4=", encoding: "base64", expected: "admin"},
+		{name: "invalid base64", value: "secretpassword1", encoding: "base64", expectError: true},
+		{name: "unknown encoding", value: "secret", encoding: "hex", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := decodePassword(tc.value, tc.encoding)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected an error, got '%s'", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to decode password: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseConfigPasswordEncoding(t *testing.T) {
+	testCases := []struct {
+		name     string
+		encoding string
+		expected string
+	}{
+		{"no encoding keeps plain text", "", "c2Vh"},
+		{"plain keeps plain text", "plain", "c2Vh"},
+		{"base64 decodes", "base64", "sea"},
+	}
+
+	parsers := map[string]func(*viper.Viper) (*Config, error){
+		"mysql":    parseConfig,
+		"postgres": parsePostgresConfig,
+	}
+
+	for driver, parse := range parsers {
+		for _, tc := range testCases {
+			t.Run(driver+" "+tc.name, func(t *testing.T) {
+				conf := viper.New()
+				conf.Set("db."+driver+".password", "c2Vh")
+				if tc.encoding != "" {
+					conf.Set("db."+driver+".password_encoding", tc.encoding)
+				}
+
+				config, err := parse(conf)
+				if err != nil {
+					t.Fatalf("Failed to parse config: %v", err)
+				}
+				if config.Password != tc.expected {
+					t.Errorf("Expected password '%s', got '%s'", tc.expected, config.Password)
+				}
+			})
+		}
+
+		t.Run(driver+" invalid encoding", func(t *testing.T) {
+			conf := viper.New()
+			conf.Set("db."+driver+".password", "secret")
+			conf.Set("db."+driver+".password_encoding", "rot13")
+			if _, err := parse(conf); err == nil {
+				t.Error("Expected an error for an unsupported password_encoding")
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pressly/goose/v3"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// DefaultMigrationsDir is the directory holding the goose SQL migrations
+const DefaultMigrationsDir = "migrations"
+
+// Migrator applies goose migrations, sharing the goose_db_version table with the goose CLI
+type Migrator struct {
+	provider *goose.Provider
+	logger   log.Logger
+}
+
+// MigrationStatus describes one migration and whether it has been applied
+type MigrationStatus struct {
+	Version   int64
+	Path      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// NewMigrator creates a migrator for a MySQL database using the migrations in dir
+func NewMigrator(d *sql.DB, dir string, logger log.Logger) (*Migrator, error) {
+	return newMigrator(d, goose.DialectMySQL, dir, logger)
+}
+
+// newMigrator creates a migrator for the given goose dialect
+func newMigrator(d *sql.DB, dialect goose.Dialect, dir string, logger log.Logger) (*Migrator, error) {
+	provider, err := goose.NewProvider(dialect, d, os.DirFS(dir))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations from %s: %w", dir, err)
+	}
+
+	return &Migrator{
+		provider: provider,
+		logger:   logger,
+	}, nil
+}
+
+// Up applies all pending migrations
+func (m *Migrator) Up(ctx context.Context) error {
+	results, err := m.provider.Up(ctx)
+	for _, result := range results {
+		m.logResult(result)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	if len(results) == 0 {
+		m.logger.Info("No pending migrations")
+	}
+	return nil
+}
+
+// Down rolls back the most recently applied migration
+func (m *Migrator) Down(ctx context.Context) error {
+	result, err := m.provider.Down(ctx)
+	if result != nil {
+		m.logResult(result)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to roll back migration: %w", err)
+	}
+	return nil
+}
+
+// Status returns every known migration in version order
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	statuses, err := m.provider.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migration status: %w", err)
+	}
+
+	result := make([]MigrationStatus, len(statuses))
+	for i, status := range statuses {
+		result[i] = MigrationStatus{
+			Version:   status.Source.Version,
+			Path:      status.Source.Path,
+			Applied:   status.State == goose.StateApplied,
+			AppliedAt: status.AppliedAt,
+		}
+	}
+	return result, nil
+}
+
+// logResult logs the outcome of a single migration
+func (m *Migrator) logResult(result *goose.MigrationResult) {
+	fields := []log.Field{
+		log.Int64("version", result.Source.Version),
+		log.String("direction", result.Direction),
+		log.Duration("duration", result.Duration),
+	}
+	if result.Error != nil {
+		m.logger.Error("Migration failed", append(fields, log.Error(result.Error))...)
+		return
+	}
+	m.logger.Info("Migration applied", fields...)
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/pressly/goose/v3"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+func createMigratorTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	database, err := sql.Open(sqliteDriverName, ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open SQLite database: %v", err)
+	}
+	database.SetMaxOpenConns(1) // Each connection gets its own in-memory database
+	t.Cleanup(func() { _ = database.Close() })
+	return database
+}
+
+func TestMigratorUpDownStatus(t *testing.T) {
+	database := createMigratorTestDB(t)
+	logger := log.NewConsoleLoggerWithWriter(log.InfoLevel, &bytes.Buffer{}, false)
+
+	migrator, err := newMigrator(database, goose.DialectSQLite3, "testdata/migrations", logger)
+	if err != nil {
+		t.Fatalf("Failed to create migrator: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Up() returned error: %v", err)
+	}
+	if _, err := database.Exec("INSERT INTO widgets (name, price_cents) VALUES ('gear', 100)"); err != nil {
+		t.Errorf("Expected migrated schema to accept inserts: %v", err)
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() returned error: %v", err)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("Expected 2 migrations, got %d", len(statuses))
+	}
+	for _, status := range statuses {
+		if !status.Applied {
+			t.Errorf("Expected migration %d to be applied", status.Version)
+		}
+	}
+
+	// Running Up again is a no-op
+	if err := migrator.Up(ctx); err != nil {
+		t.Errorf("Expected second Up() to succeed, got %v", err)
+	}
+
+	if err := migrator.Down(ctx); err != nil {
+		t.Fatalf("Down() returned error: %v", err)
+	}
+	statuses, err = migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() returned error: %v", err)
+	}
+	if !statuses[0].Applied || statuses[1].Applied {
+		t.Errorf("Expected only the first migration to remain applied, got %+v", statuses)
+	}
+}
+
+func TestNewMigratorMissingDir(t *testing.T) {
+	database := createMigratorTestDB(t)
+	logger := log.NewConsoleLoggerWithWriter(log.InfoLevel, &bytes.Buffer{}, false)
+
+	if _, err := newMigrator(database, goose.DialectSQLite3, "testdata/does-not-exist", logger); err == nil {
+		t.Error("Expected error for a missing migrations directory")
+	}
+}
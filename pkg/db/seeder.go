@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// identifierPattern restricts table and column names used in generated statements
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Seeder loads fixture data into the database for development and tests
+type Seeder struct {
+	db     *sql.DB
+	logger log.Logger
+}
+
+// seedTable holds the rows for one table, in file order
+type seedTable struct {
+	name string
+	rows []map[string]interface{}
+}
+
+// NewSeeder creates a new database seeder
+func NewSeeder(d *sql.DB, logger log.Logger) *Seeder {
+	return &Seeder{
+		db:     d,
+		logger: logger,
+	}
+}
+
+// LoadFromFile inserts the rows of a JSON or YAML file shaped as {table: [{column: value}]}.
+// Tables are seeded in the order they appear in the file, inside a single transaction.
+func (s *Seeder) LoadFromFile(ctx context.Context, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read seed file %s: %w", path, err)
+	}
+
+	tables, err := parseSeedFile(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse seed file %s: %w", path, err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin seed transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, table := range tables {
+		if err := insertRows(ctx, tx, table); err != nil {
+			return err
+		}
+		s.logger.Info("Seeded table", log.String("table", table.name), log.Int("rows", len(table.rows)))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit seed transaction: %w", err)
+	}
+	return nil
+}
+
+// Truncate deletes all rows from the given tables. Tables are listed in dependency
+// order (parents first) and cleared in reverse so child rows go first.
+func (s *Seeder) Truncate(ctx context.Context, tables ...string) error {
+	for i := len(tables) - 1; i >= 0; i-- {
+		table := tables[i]
+		if !identifierPattern.MatchString(table) {
+			return fmt.Errorf("invalid table name %q", table)
+		}
+		if _, err := s.db.ExecContext(ctx, "DELETE FROM "+table); err != nil {
+			return fmt.Errorf("failed to truncate table %s: %w", table, err)
+		}
+		s.logger.Info("Truncated table", log.String("table", table))
+	}
+	return nil
+}
+
+// parseSeedFile decodes the seed document, keeping the table order of the file.
+// JSON is valid YAML, so both formats go through the YAML decoder.
+func parseSeedFile(content []byte) ([]seedTable, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("expected a mapping of table names to rows")
+	}
+
+	tables := make([]seedTable, 0, len(root.Content)/2)
+	for i := 0; i < len(root.Content); i += 2 {
+		table := seedTable{name: root.Content[i].Value}
+		if err := root.Content[i+1].Decode(&table.rows); err != nil {
+			return nil, fmt.Errorf("table %s: %w", table.name, err)
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+// insertRows inserts all rows of a table, reusing a prepared statement per column set
+func insertRows(ctx context.Context, tx *sql.Tx, table seedTable) error {
+	if !identifierPattern.MatchString(table.name) {
+		return fmt.Errorf("invalid table name %q", table.name)
+	}
+
+	statements := make(map[string]*sql.Stmt)
+	defer func() {
+		for _, stmt := range statements {
+			_ = stmt.Close()
+		}
+	}()
+
+	for i, row := range table.rows {
+		columns := make([]string, 0, len(row))
+		for column := range row {
+			if !identifierPattern.MatchString(column) {
+				return fmt.Errorf("table %s: invalid column name %q", table.name, column)
+			}
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+
+		key := strings.Join(columns, ",")
+		stmt, ok := statements[key]
+		if !ok {
+			placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+			query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table.name, strings.Join(columns, ", "), placeholders)
+
+			var err error
+			stmt, err = tx.PrepareContext(ctx, query)
+			if err != nil {
+				return fmt.Errorf("table %s: failed to prepare insert: %w", table.name, err)
+			}
+			statements[key] = stmt
+		}
+
+		args := make([]interface{}, len(columns))
+		for j, column := range columns {
+			args[j] = row[column]
+		}
+		if _, err := stmt.ExecContext(ctx, args...); err != nil {
+			return fmt.Errorf("table %s: failed to insert row %d: %w", table.name, i+1, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const seederTestSchema = `
+CREATE TABLE users (
+    id INTEGER PRIMARY KEY,
+    username TEXT NOT NULL,
+    email TEXT NOT NULL
+);
+CREATE TABLE products (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    price_cents INTEGER NOT NULL DEFAULT 0,
+    owner_id INTEGER NOT NULL REFERENCES users (id)
+);`
+
+func createSeederTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	database, err := sql.Open(sqliteDriverName, "file::memory:?_pragma=foreign_keys(1)")
+	if err != nil {
+		t.Fatalf("Failed to open SQLite database: %v", err)
+	}
+	database.SetMaxOpenConns(1) // Each connection gets its own in-memory database
+	t.Cleanup(func() { _ = database.Close() })
+
+	if _, err := database.Exec(seederTestSchema); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+	return database
+}
+
+func countRows(t *testing.T, database *sql.DB, table string) int {
+	t.Helper()
+	var count int
+	if err := database.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count); err != nil {
+		t.Fatalf("Failed to count %s rows: %v", table, err)
+	}
+	return count
+}
+
+func TestSeederLoadFromFile(t *testing.T) {
+	testCases := []struct {
+		file             string
+		expectedUsers    int
+		expectedProducts int
+	}{
+		{"testdata/seed.yml", 2, 3},
+		{"testdata/seed.json", 1, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.file, func(t *testing.T) {
+			database := createSeederTestDB(t)
+			seeder := NewSeeder(database, createTestLogger())
+
+			if err := seeder.LoadFromFile(context.Background(), tc.file); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if got := countRows(t, database, "users"); got != tc.expectedUsers {
+				t.Errorf("Expected %d users, got %d", tc.expectedUsers, got)
+			}
+			if got := countRows(t, database, "products"); got != tc.expectedProducts {
+				t.Errorf("Expected %d products, got %d", tc.expectedProducts, got)
+			}
+		})
+	}
+}
+
+func TestSeederTruncate(t *testing.T) {
+	database := createSeederTestDB(t)
+	seeder := NewSeeder(database, createTestLogger())
+	ctx := context.Background()
+
+	if err := seeder.LoadFromFile(ctx, "testdata/seed.yml"); err != nil {
+		t.Fatalf("Failed to seed: %v", err)
+	}
+
+	// Parents first; products must be cleared before users because of the foreign key
+	if err := seeder.Truncate(ctx, "users", "products"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, table := range []string{"users", "products"} {
+		if got := countRows(t, database, table); got != 0 {
+			t.Errorf("Expected 0 %s rows after truncate, got %d", table, got)
+		}
+	}
+}
+
+func TestSeederErrors(t *testing.T) {
+	database := createSeederTestDB(t)
+	seeder := NewSeeder(database, createTestLogger())
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	testCases := []struct {
+		name    string
+		content string
+	}{
+		{"invalid table name", "users; DROP TABLE users:\n  - id: 1\n"},
+		{"invalid column name", "users:\n  - \"id) VALUES (1); --\": 1\n"},
+		{"not a mapping", "- users\n"},
+		{"unknown column", "users:\n  - nickname: jd\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, "seed.yml")
+			if err := os.WriteFile(path, []byte(tc.content), 0o600); err != nil {
+				t.Fatalf("Failed to write seed file: %v", err)
+			}
+			if err := seeder.LoadFromFile(ctx, path); err == nil {
+				t.Error("Expected an error, got nil")
+			}
+		})
+	}
+
+	// A failed seed must not leave partial data behind
+	if got := countRows(t, database, "users"); got != 0 {
+		t.Errorf("Expected no users after failed seeds, got %d", got)
+	}
+
+	if err := seeder.LoadFromFile(ctx, filepath.Join(dir, "missing.yml")); err == nil {
+		t.Error("Expected error for missing file, got nil")
+	}
+	if err := seeder.Truncate(ctx, "users; --"); err == nil {
+		t.Error("Expected error for invalid table name, got nil")
+	}
+}
@@ -0,0 +1,54 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/spf13/viper"
+	_ "modernc.org/sqlite" // Registers the CGO-free sqlite driver
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+const (
+	// sqliteDriverName is the database/sql driver name registered by modernc.org/sqlite
+	sqliteDriverName = "sqlite"
+	// sqliteMemoryFile opens a private in-memory database
+	sqliteMemoryFile = ":memory:"
+)
+
+// NewSQLiteConnection creates a new SQLite connection from the db.sqlite config keys,
+// for tests and embedded use. db.sqlite.file defaults to an in-memory database.
+func NewSQLiteConnection(conf *viper.Viper, logger log.Logger) (*sql.DB, error) {
+	config := parseSQLiteConfig(conf)
+
+	logger.Info("Connecting to database", log.String("driver", sqliteDriverName), log.String("file", config.File))
+
+	db, err := connectWithRetry(sqliteDriverName, config.File, config, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database %s: %w", config.File, err)
+	}
+
+	configureConnectionPool(db, config)
+
+	// Every connection to :memory: opens a separate empty database, so the pool must keep exactly one
+	if config.File == sqliteMemoryFile {
+		db.SetMaxOpenConns(1)
+	}
+
+	logger.Info("Database connection established successfully")
+	return db, nil
+}
+
+// parseSQLiteConfig extracts database configuration from the db.sqlite section
+func parseSQLiteConfig(conf *viper.Viper) *Config {
+	config := &Config{
+		Driver:        sqliteDriverName,
+		File:          sqliteMemoryFile,
+		RetryAttempts: 1,
+	}
+	if file := conf.GetString("db.sqlite.file"); file != "" {
+		config.File = file
+	}
+	return config
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+func TestNewConnectionSQLite(t *testing.T) {
+	testCases := []struct {
+		name string
+		file string
+	}{
+		{"in-memory by default", ""},
+		{"database file", filepath.Join(t.TempDir(), "scaffold.db")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conf := viper.New()
+			conf.Set("db.driver", "sqlite")
+			if tc.file != "" {
+				conf.Set("db.sqlite.file", tc.file)
+			}
+
+			db, err := NewConnection(conf, log.NewConsoleLoggerWithWriter(log.InfoLevel, &bytes.Buffer{}, false))
+			if err != nil {
+				t.Fatalf("Failed to connect: %v", err)
+			}
+			defer db.Close()
+
+			if _, err := db.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL)"); err != nil {
+				t.Fatalf("Failed to create table: %v", err)
+			}
+			if _, err := db.Exec("INSERT INTO users (name) VALUES (?)", "alice"); err != nil {
+				t.Fatalf("Failed to insert row: %v", err)
+			}
+
+			var name string
+			if err := db.QueryRow("SELECT name FROM users WHERE id = 1").Scan(&name); err != nil {
+				t.Fatalf("Failed to query row: %v", err)
+			}
+			if name != "alice" {
+				t.Errorf("Expected 'alice', got '%s'", name)
+			}
+		})
+	}
+}
+
+func TestConfigureConnectionPoolSkipsSQLite(t *testing.T) {
+	db, err := NewSQLiteConnection(viper.New(), log.NewConsoleLoggerWithWriter(log.InfoLevel, &bytes.Buffer{}, false))
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer db.Close()
+
+	configureConnectionPool(db, &Config{Driver: sqliteDriverName, MaxOpenConns: 25})
+	if max := db.Stats().MaxOpenConnections; max != 1 {
+		t.Errorf("Expected the in-memory database to keep a single connection, got %d", max)
+	}
+}
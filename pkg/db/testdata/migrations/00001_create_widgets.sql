@@ -0,0 +1,8 @@
+-- +goose Up
+CREATE TABLE widgets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL
+);
+
+-- +goose Down
+DROP TABLE widgets;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE widgets ADD COLUMN price_cents INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE widgets DROP COLUMN price_cents;
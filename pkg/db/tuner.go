@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+const (
+	// poolTunerStep is the number of connections added or removed per adjustment
+	poolTunerStep = 5
+	// poolTunerLowUtilization is the utilization below which the pool is shrunk
+	poolTunerLowUtilization = 0.3
+)
+
+// PoolTunerConfig holds the connection pool auto-tuning configuration
+type PoolTunerConfig struct {
+	MinConns          int
+	MaxConns          int
+	TargetUtilization float64
+	AdjustInterval    time.Duration
+}
+
+// tunablePool is the subset of *sql.DB used by the pool tuner
+type tunablePool interface {
+	Stats() sql.DBStats
+	SetMaxOpenConns(n int)
+}
+
+// StartPoolTuner periodically adjusts MaxOpenConns based on pool utilization.
+// The tuner runs in the background until ctx is cancelled.
+func StartPoolTuner(ctx context.Context, d *sql.DB, config PoolTunerConfig, logger log.Logger) {
+	config = applyPoolTunerDefaults(config)
+	go runPoolTuner(ctx, d, config, logger)
+}
+
+// applyPoolTunerDefaults fills in missing tuner configuration values
+func applyPoolTunerDefaults(config PoolTunerConfig) PoolTunerConfig {
+	if config.MinConns <= 0 {
+		config.MinConns = 5
+	}
+	if config.MaxConns < config.MinConns {
+		config.MaxConns = config.MinConns
+	}
+	if config.TargetUtilization <= 0 || config.TargetUtilization > 1 {
+		config.TargetUtilization = 0.8
+	}
+	if config.AdjustInterval <= 0 {
+		config.AdjustInterval = 30 * time.Second
+	}
+	return config
+}
+
+// runPoolTuner drives adjustPoolSize on every tick
+func runPoolTuner(ctx context.Context, pool tunablePool, config PoolTunerConfig, logger log.Logger) {
+	ticker := time.NewTicker(config.AdjustInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			adjustPoolSize(pool, config, logger)
+		}
+	}
+}
+
+// adjustPoolSize scales MaxOpenConns up or down by poolTunerStep and returns the new limit
+func adjustPoolSize(pool tunablePool, config PoolTunerConfig, logger log.Logger) int {
+	stats := pool.Stats()
+
+	current := stats.MaxOpenConnections
+	if current <= 0 {
+		// Zero means unlimited, treat it as the configured ceiling
+		current = config.MaxConns
+	}
+
+	if stats.OpenConnections == 0 {
+		return current
+	}
+
+	utilization := float64(stats.InUse) / float64(stats.OpenConnections)
+
+	next := current
+	switch {
+	case utilization > config.TargetUtilization:
+		next = min(current+poolTunerStep, config.MaxConns)
+	case utilization < poolTunerLowUtilization:
+		next = max(current-poolTunerStep, config.MinConns)
+	}
+
+	if next != current {
+		pool.SetMaxOpenConns(next)
+		logger.Info("Adjusted database connection pool size",
+			log.Int("previous_max_open_conns", current),
+			log.Int("max_open_conns", next),
+			log.Float64("utilization", utilization),
+		)
+	}
+
+	return next
+}
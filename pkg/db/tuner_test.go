@@ -0,0 +1,125 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockPool implements tunablePool with configurable stats
+type mockPool struct {
+	mu    sync.Mutex
+	stats sql.DBStats
+}
+
+func (m *mockPool) Stats() sql.DBStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stats
+}
+
+func (m *mockPool) SetMaxOpenConns(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stats.MaxOpenConnections = n
+}
+
+func createTestTunerConfig() PoolTunerConfig {
+	return PoolTunerConfig{
+		MinConns:          10,
+		MaxConns:          30,
+		TargetUtilization: 0.8,
+		AdjustInterval:    10 * time.Millisecond,
+	}
+}
+
+func TestAdjustPoolSize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		stats    sql.DBStats
+		expected int
+	}{
+		{
+			name:     "scale up on high utilization",
+			stats:    sql.DBStats{MaxOpenConnections: 20, OpenConnections: 20, InUse: 18},
+			expected: 25,
+		},
+		{
+			name:     "scale up capped at max",
+			stats:    sql.DBStats{MaxOpenConnections: 28, OpenConnections: 28, InUse: 28},
+			expected: 30,
+		},
+		{
+			name:     "scale down on low utilization",
+			stats:    sql.DBStats{MaxOpenConnections: 20, OpenConnections: 20, InUse: 2},
+			expected: 15,
+		},
+		{
+			name:     "scale down capped at min",
+			stats:    sql.DBStats{MaxOpenConnections: 12, OpenConnections: 12, InUse: 1},
+			expected: 10,
+		},
+		{
+			name:     "no change within target",
+			stats:    sql.DBStats{MaxOpenConnections: 20, OpenConnections: 20, InUse: 10},
+			expected: 20,
+		},
+		{
+			name:     "no change without open connections",
+			stats:    sql.DBStats{MaxOpenConnections: 20},
+			expected: 20,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := &mockPool{stats: tc.stats}
+
+			result := adjustPoolSize(pool, createTestTunerConfig(), createTestLogger())
+			if result != tc.expected {
+				t.Errorf("Expected max open conns %d, got %d", tc.expected, result)
+			}
+
+			if pool.Stats().MaxOpenConnections != tc.expected {
+				t.Errorf("Expected pool MaxOpenConnections %d, got %d", tc.expected, pool.Stats().MaxOpenConnections)
+			}
+		})
+	}
+}
+
+func TestRunPoolTunerScalesUp(t *testing.T) {
+	pool := &mockPool{stats: sql.DBStats{MaxOpenConnections: 10, OpenConnections: 10, InUse: 10}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go runPoolTuner(ctx, pool, createTestTunerConfig(), createTestLogger())
+
+	deadline := time.After(2 * time.Second)
+	for pool.Stats().MaxOpenConnections < 30 {
+		select {
+		case <-deadline:
+			t.Fatalf("Expected tuner to scale up to 30, got %d", pool.Stats().MaxOpenConnections)
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+}
+
+func TestApplyPoolTunerDefaults(t *testing.T) {
+	config := applyPoolTunerDefaults(PoolTunerConfig{})
+
+	if config.MinConns != 5 {
+		t.Errorf("Expected default MinConns 5, got %d", config.MinConns)
+	}
+	if config.MaxConns != 5 {
+		t.Errorf("Expected MaxConns to be raised to MinConns 5, got %d", config.MaxConns)
+	}
+	if config.TargetUtilization != 0.8 {
+		t.Errorf("Expected default TargetUtilization 0.8, got %v", config.TargetUtilization)
+	}
+	if config.AdjustInterval != 30*time.Second {
+		t.Errorf("Expected default AdjustInterval 30s, got %v", config.AdjustInterval)
+	}
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// warmupTimeout bounds how long opening the warm-up connections may take
+const warmupTimeout = 10 * time.Second
+
+// WarmupConnections opens count connections concurrently and returns them to the pool,
+// so the first requests find idle connections. The pool keeps at most MaxIdleConns of them.
+// The connections that could be opened stay in the pool even when an error is returned.
+func WarmupConnections(db *sql.DB, count int, logger log.Logger) error {
+	if count <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), warmupTimeout)
+	defer cancel()
+
+	var (
+		mu    sync.Mutex
+		conns []*sql.Conn
+		errs  []error
+		wg    sync.WaitGroup
+	)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			conn, err := db.Conn(ctx)
+			if err == nil {
+				err = conn.PingContext(ctx)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				if conn != nil {
+					conn.Close()
+				}
+				return
+			}
+			conns = append(conns, conn)
+		}()
+	}
+	// Every connection is held until all are open, otherwise the pool would hand out the same one again
+	wg.Wait()
+
+	for _, conn := range conns {
+		conn.Close()
+	}
+
+	logger.Info("Database connection pool warmed up",
+		log.Int("connections", len(conns)),
+		log.Int("requested", count),
+	)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to open %d of %d warm-up connections: %w", len(errs), count, errors.Join(errs...))
+	}
+	return nil
+}
+
+// warmupPool warms up the configured number of connections, capped at MaxIdleConns.
+// Failures are logged since the pool still works without idle connections.
+func warmupPool(db *sql.DB, config *Config, logger log.Logger) {
+	count := min(config.WarmupConnections, config.MaxIdleConns)
+	if err := WarmupConnections(db, count, logger); err != nil {
+		logger.Warn("Database connection pool warm-up incomplete", log.Error(err))
+	}
+}
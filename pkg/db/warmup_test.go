@@ -0,0 +1,84 @@
+package db
+
+import (
+	"bytes"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+func TestWarmupConnections(t *testing.T) {
+	const warmupCount = 4
+
+	db, err := sql.Open(sqliteDriverName, ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open sqlite database: %v", err)
+	}
+	defer db.Close()
+	db.SetMaxIdleConns(5)
+
+	if err := WarmupConnections(db, warmupCount, log.NewConsoleLogger(log.InfoLevel)); err != nil {
+		t.Fatalf("Failed to warm up connections: %v", err)
+	}
+
+	stats := db.Stats()
+	if stats.Idle < warmupCount {
+		t.Errorf("Expected at least %d idle connections, got %d", warmupCount, stats.Idle)
+	}
+	if stats.InUse != 0 {
+		t.Errorf("Expected warm-up connections to be released, got %d in use", stats.InUse)
+	}
+}
+
+func TestWarmupPoolCapsAtMaxIdleConns(t *testing.T) {
+	db, err := sql.Open(sqliteDriverName, ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open sqlite database: %v", err)
+	}
+	defer db.Close()
+
+	config := &Config{MaxIdleConns: 2, WarmupConnections: 10}
+	configureConnectionPool(db, config)
+	warmupPool(db, config, log.NewConsoleLogger(log.InfoLevel))
+
+	if idle := db.Stats().Idle; idle != 2 {
+		t.Errorf("Expected warm-up capped at 2 idle connections, got %d", idle)
+	}
+}
+
+func TestWarmupPoolFailureIsLogged(t *testing.T) {
+	db, err := sql.Open(sqliteDriverName, ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open sqlite database: %v", err)
+	}
+	db.Close()
+
+	var buf bytes.Buffer
+	logger := log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false)
+
+	if err := WarmupConnections(db, 3, logger); err == nil || !strings.Contains(err.Error(), "3 of 3") {
+		t.Errorf("Expected all warm-up connections to fail, got %v", err)
+	}
+
+	warmupPool(db, &Config{MaxIdleConns: 5, WarmupConnections: 3}, logger)
+	if !strings.Contains(buf.String(), "warm-up incomplete") {
+		t.Errorf("Expected a warning for the failed warm-up, got: %s", buf.String())
+	}
+}
+
+func TestParseConfigWarmupConnections(t *testing.T) {
+	conf := viper.New()
+	conf.Set("db.mysql.warmup_connections", 3)
+
+	config, err := parseConfig(conf)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	if config.WarmupConnections != 3 {
+		t.Errorf("Expected warmup_connections 3, got %d", config.WarmupConnections)
+	}
+}
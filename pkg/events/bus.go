@@ -0,0 +1,197 @@
+// Package events provides the in-process bus services publish domain events on.
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// AllEvents subscribes a handler to every event type
+const AllEvents = "*"
+
+// DefaultBufferSize is the number of events an AsyncBus queues when NewAsyncBus is given no size
+const DefaultBufferSize = 1024
+
+// Bus errors
+var (
+	ErrBusFull   = errors.New("event bus buffer is full")
+	ErrBusClosed = errors.New("event bus is closed")
+)
+
+// Event is something that happened in the domain, eg: a user was created
+type Event struct {
+	Type       string
+	Payload    interface{}
+	OccurredAt time.Time
+}
+
+// EventHandler handles a published event
+type EventHandler func(ctx context.Context, event Event) error
+
+// Bus delivers published events to the handlers subscribed to their type
+type Bus interface {
+	Publish(ctx context.Context, event Event) error
+	Subscribe(eventType string, handler EventHandler)
+}
+
+// DomainEvent is embedded in event payloads to attribute them to the user who caused them
+type DomainEvent struct {
+	ActorID uint64 `json:"actor_id,omitempty"` // 0 for events not caused by an authenticated user
+}
+
+// Actor returns the ID of the user who caused the event
+func (e DomainEvent) Actor() uint64 {
+	return e.ActorID
+}
+
+// Attributed is implemented by payloads embedding DomainEvent
+type Attributed interface {
+	Actor() uint64
+}
+
+// MemoryBus calls the subscribed handlers synchronously, in subscription order, before Publish returns
+type MemoryBus struct {
+	mu       sync.RWMutex
+	handlers map[string][]EventHandler
+}
+
+// NewMemoryBus creates a synchronous in-process bus
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{handlers: make(map[string][]EventHandler)}
+}
+
+// Subscribe calls handler for every published event of eventType, or of any type for AllEvents
+func (b *MemoryBus) Subscribe(eventType string, handler EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Publish calls the handlers subscribed to the event type, then those subscribed to AllEvents.
+// Every handler runs even when an earlier one fails; their errors are joined.
+// OccurredAt is set to the current time when it is zero.
+func (b *MemoryBus) Publish(ctx context.Context, event Event) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	b.mu.RLock()
+	handlers := append(append([]EventHandler{}, b.handlers[event.Type]...), b.handlers[AllEvents]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("handle %s event: %w", event.Type, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// AsyncBus queues published events in a buffered channel and calls the handlers from a background goroutine,
+// so publishing never waits for them. Handler errors are logged, and a panicking handler is recovered and
+// logged without stopping the delivery of the other handlers and events.
+type AsyncBus struct {
+	bus    *MemoryBus
+	logger log.Logger
+	queue  chan queuedEvent
+	done   chan struct{}
+
+	mu     sync.RWMutex // guards closed against Publish sending on the closed queue
+	closed bool
+}
+
+// queuedEvent is an event waiting for its handlers, with the context it was published with
+type queuedEvent struct {
+	ctx   context.Context
+	event Event
+}
+
+// NewAsyncBus creates a bus queueing up to bufferSize events, DefaultBufferSize when it is 0
+func NewAsyncBus(bufferSize int, logger log.Logger) *AsyncBus {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	b := &AsyncBus{
+		bus:    NewMemoryBus(),
+		logger: logger,
+		queue:  make(chan queuedEvent, bufferSize),
+		done:   make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// Subscribe calls handler for every published event of eventType, or of any type for AllEvents
+func (b *AsyncBus) Subscribe(eventType string, handler EventHandler) {
+	b.bus.Subscribe(eventType, b.recovered(handler))
+}
+
+// recovered wraps handler so a panic is logged with the event type and returned as an error
+// instead of killing the delivery goroutine, which would leave Close waiting forever
+func (b *AsyncBus) recovered(handler EventHandler) EventHandler {
+	return func(ctx context.Context, event Event) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				b.logger.Error("Event handler panicked",
+					log.String("event_type", event.Type),
+					log.String("panic", fmt.Sprint(r)),
+					log.String("stack", string(debug.Stack())),
+				)
+				err = fmt.Errorf("handler panicked: %v", r)
+			}
+		}()
+		return handler(ctx, event)
+	}
+}
+
+// Publish queues the event without waiting for its handlers. It returns ErrBusFull when the buffer is full
+// and ErrBusClosed after Close. The handlers get ctx without its cancellation, as the caller, eg: a request,
+// is usually done by the time they run.
+func (b *AsyncBus) Publish(ctx context.Context, event Event) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return ErrBusClosed
+	}
+
+	select {
+	case b.queue <- queuedEvent{ctx: context.WithoutCancel(ctx), event: event}:
+		return nil
+	default:
+		return ErrBusFull
+	}
+}
+
+// Close stops accepting events and waits until the queued ones are handled
+func (b *AsyncBus) Close() error {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.queue)
+	}
+	b.mu.Unlock()
+
+	<-b.done
+	return nil
+}
+
+// run delivers the queued events until the queue is closed and drained
+func (b *AsyncBus) run() {
+	defer close(b.done)
+	for queued := range b.queue {
+		if err := b.bus.Publish(queued.ctx, queued.event); err != nil {
+			b.logger.Error("Failed to handle event", log.String("event_type", queued.event.Type), log.Error(err))
+		}
+	}
+}
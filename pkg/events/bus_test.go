@@ -0,0 +1,205 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+func TestMemoryBusPublish(t *testing.T) {
+	tests := []struct {
+		name         string
+		subscribe    string
+		publish      []string
+		expectCalled int32
+	}{
+		{name: "called once per publish", subscribe: "user.created", publish: []string{"user.created"}, expectCalled: 1},
+		{name: "called for every publish", subscribe: "user.created", publish: []string{"user.created", "user.created"}, expectCalled: 2},
+		{name: "other types ignored", subscribe: "user.created", publish: []string{"user.deleted"}, expectCalled: 0},
+		{name: "all events", subscribe: AllEvents, publish: []string{"user.created", "user.deleted"}, expectCalled: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := NewMemoryBus()
+			var called atomic.Int32
+			bus.Subscribe(tt.subscribe, func(ctx context.Context, event Event) error {
+				called.Add(1)
+				if event.OccurredAt.IsZero() {
+					t.Error("Expected OccurredAt to be set")
+				}
+				return nil
+			})
+
+			for _, eventType := range tt.publish {
+				if err := bus.Publish(context.Background(), Event{Type: eventType}); err != nil {
+					t.Fatalf("Publish() returned error: %v", err)
+				}
+			}
+			if got := called.Load(); got != tt.expectCalled {
+				t.Errorf("Expected handler to be called %d times, got %d", tt.expectCalled, got)
+			}
+		})
+	}
+}
+
+func TestMemoryBusHandlerErrors(t *testing.T) {
+	bus := NewMemoryBus()
+	errFirst := errors.New("first failed")
+	var secondCalled bool
+	bus.Subscribe("user.created", func(context.Context, Event) error { return errFirst })
+	bus.Subscribe("user.created", func(context.Context, Event) error {
+		secondCalled = true
+		return nil
+	})
+
+	err := bus.Publish(context.Background(), Event{Type: "user.created"})
+	if !errors.Is(err, errFirst) {
+		t.Errorf("Expected the handler error, got %v", err)
+	}
+	if !secondCalled {
+		t.Error("Expected the second handler to run after the first failed")
+	}
+}
+
+func TestAsyncBusDoesNotBlock(t *testing.T) {
+	bus := NewAsyncBus(2, log.NewConsoleLoggerWithWriter(log.InfoLevel, &bytes.Buffer{}, false))
+
+	started := make(chan struct{}, 3)
+	release := make(chan struct{})
+	var called atomic.Int32
+	bus.Subscribe("user.created", func(context.Context, Event) error {
+		started <- struct{}{}
+		<-release
+		called.Add(1)
+		return nil
+	})
+
+	// The first event is taken by the blocked handler, the next two fill the buffer
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := bus.Publish(context.Background(), Event{Type: "user.created"}); err != nil {
+			t.Fatalf("Publish() returned error: %v", err)
+		}
+		if i == 0 {
+			// Wait for the worker to take the first event off the queue
+			<-started
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected Publish not to wait for the handler, took %v", elapsed)
+	}
+	if err := bus.Publish(context.Background(), Event{Type: "user.created"}); !errors.Is(err, ErrBusFull) {
+		t.Errorf("Expected %v, got %v", ErrBusFull, err)
+	}
+	if got := called.Load(); got != 0 {
+		t.Errorf("Expected no handled events while the handler is blocked, got %d", got)
+	}
+
+	close(release)
+	if err := bus.Close(); err != nil {
+		t.Fatalf("Close() returned error: %v", err)
+	}
+	if got := called.Load(); got != 3 {
+		t.Errorf("Expected the 3 queued events to be handled once each, got %d", got)
+	}
+	if err := bus.Publish(context.Background(), Event{Type: "user.created"}); !errors.Is(err, ErrBusClosed) {
+		t.Errorf("Expected %v, got %v", ErrBusClosed, err)
+	}
+}
+
+func TestAsyncBusHandlerContext(t *testing.T) {
+	bus := NewAsyncBus(0, log.NewConsoleLoggerWithWriter(log.InfoLevel, &bytes.Buffer{}, false))
+	defer func() { _ = bus.Close() }()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	bus.Subscribe(AllEvents, func(ctx context.Context, event Event) error {
+		defer wg.Done()
+		if ctx.Err() != nil {
+			t.Errorf("Expected the handler context not to be canceled, got %v", ctx.Err())
+		}
+		if event.Payload != "payload" {
+			t.Errorf("Expected payload %q, got %v", "payload", event.Payload)
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := bus.Publish(ctx, Event{Type: "user.created", Payload: "payload"}); err != nil {
+		t.Fatalf("Publish() returned error: %v", err)
+	}
+	cancel()
+	wg.Wait()
+}
+
+func TestAsyncBusLogsHandlerErrors(t *testing.T) {
+	var buf bytes.Buffer
+	bus := NewAsyncBus(1, log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false))
+	bus.Subscribe("user.created", func(context.Context, Event) error { return errors.New("boom") })
+
+	if err := bus.Publish(context.Background(), Event{Type: "user.created"}); err != nil {
+		t.Fatalf("Publish() returned error: %v", err)
+	}
+	_ = bus.Close()
+
+	if !bytes.Contains(buf.Bytes(), []byte(`"event_type":"user.created"`)) || !bytes.Contains(buf.Bytes(), []byte("boom")) {
+		t.Errorf("Expected the handler error to be logged, got %s", buf.String())
+	}
+}
+
+func TestAsyncBusRecoversHandlerPanics(t *testing.T) {
+	var buf bytes.Buffer
+	bus := NewAsyncBus(2, log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false))
+	var handled atomic.Int32
+	bus.Subscribe("user.created", func(context.Context, Event) error { panic("boom") })
+	bus.Subscribe(AllEvents, func(context.Context, Event) error {
+		handled.Add(1)
+		return nil
+	})
+
+	for i := 0; i < 2; i++ {
+		if err := bus.Publish(context.Background(), Event{Type: "user.created"}); err != nil {
+			t.Fatalf("Publish() returned error: %v", err)
+		}
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		_ = bus.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected Close to return after a handler panicked")
+	}
+
+	if got := handled.Load(); got != 2 {
+		t.Errorf("Expected the other handler to receive 2 events, got %d", got)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("Event handler panicked")) || !bytes.Contains(buf.Bytes(), []byte(`"event_type":"user.created"`)) {
+		t.Errorf("Expected the panic to be logged with the event type, got %s", buf.String())
+	}
+}
+
+func TestDomainEventActor(t *testing.T) {
+	payload := struct {
+		DomainEvent
+		UserID uint64
+	}{DomainEvent: DomainEvent{ActorID: 7}, UserID: 42}
+
+	attributed, ok := interface{}(payload).(Attributed)
+	if !ok {
+		t.Fatal("Expected a payload embedding DomainEvent to implement Attributed")
+	}
+	if attributed.Actor() != 7 {
+		t.Errorf("Expected actor 7, got %d", attributed.Actor())
+	}
+}
@@ -0,0 +1,185 @@
+// Package health runs named readiness checks and aggregates their results.
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// Aggregate statuses of a Report
+const (
+	StatusReady    = "ready"
+	StatusNotReady = "not_ready"
+)
+
+// Per-check results. Errors are only logged, as they can reveal hosts and credentials of the dependencies.
+const (
+	CheckOK      = "ok"
+	CheckTimeout = "timeout"
+	CheckFail    = "fail"
+)
+
+// DefaultTimeout bounds each check when the registry is created without a timeout
+const DefaultTimeout = 2 * time.Second
+
+// Check reports whether a readiness condition holds, it should return once ctx is done
+type Check func(ctx context.Context) error
+
+// Report is the aggregated result of the registered checks
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// Ready reports whether every check passed
+func (r Report) Ready() bool {
+	return r.Status == StatusReady
+}
+
+// Registry holds named checks. It is safe for concurrent use, checks can be added while Run is serving requests.
+type Registry struct {
+	mu      sync.RWMutex
+	checks  map[string]registeredCheck
+	timeout time.Duration
+	logger  log.Logger
+}
+
+// registeredCheck is a check with whether its failure leaves the report ready
+type registeredCheck struct {
+	check    Check
+	optional bool
+}
+
+// NewRegistry creates an empty registry that gives each check timeout to finish, DefaultTimeout when 0,
+// and logs the errors of failing checks to logger
+func NewRegistry(timeout time.Duration, logger log.Logger) *Registry {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Registry{checks: make(map[string]registeredCheck), timeout: timeout, logger: logger}
+}
+
+// Register adds a check, replacing any check registered under the same name
+func (r *Registry) Register(name string, check func(ctx context.Context) error) {
+	r.register(name, registeredCheck{check: check})
+}
+
+// RegisterOptional adds a check whose failure is reported without making the report not ready,
+// for dependencies the service degrades without, such as log intakes
+func (r *Registry) RegisterOptional(name string, check func(ctx context.Context) error) {
+	r.register(name, registeredCheck{check: check, optional: true})
+}
+
+func (r *Registry) register(name string, check registeredCheck) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks[name] = check
+}
+
+// Run calls every check concurrently, each with its own timeout, and aggregates the results.
+// The status is not_ready when any check other than an optional one fails or times out.
+func (r *Registry) Run(ctx context.Context) Report {
+	r.mu.RLock()
+	checks := make(map[string]registeredCheck, len(r.checks))
+	for name, check := range r.checks {
+		checks[name] = check
+	}
+	r.mu.RUnlock()
+
+	report := Report{Status: StatusReady, Checks: make(map[string]string, len(checks))}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check registeredCheck) {
+			defer wg.Done()
+			result := r.run(ctx, name, check.check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if result != CheckOK && !check.optional {
+				report.Status = StatusNotReady
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	return report
+}
+
+// run calls check with the per-check timeout and returns its result, without waiting for checks that ignore ctx
+func (r *Registry) run(ctx context.Context, name string, check Check) string {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- check(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	if err == nil {
+		return CheckOK
+	}
+	r.logger.Warn("Health check failed", log.String("check", name), log.Error(err))
+	if errors.Is(err, context.DeadlineExceeded) {
+		return CheckTimeout
+	}
+	return CheckFail
+}
+
+// Cached returns a check that runs check at most once per ttl and otherwise returns its last result,
+// so frequent probes do not dial slow or remote dependencies every time
+func Cached(check Check, ttl time.Duration) Check {
+	var mu sync.Mutex
+	var lastErr error
+	var checkedAt time.Time
+	return func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if !checkedAt.IsZero() && time.Since(checkedAt) < ttl {
+			return lastErr
+		}
+		lastErr = check(ctx)
+		checkedAt = time.Now()
+		return lastErr
+	}
+}
+
+// NewDatabaseCheck returns a check that pings db
+func NewDatabaseCheck(db *sql.DB) Check {
+	return db.PingContext
+}
+
+// NewHTTPCheck returns a check that GETs url and fails on connection errors and non-2xx responses
+func NewHTTPCheck(url string) Check {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,216 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+func TestRegistryRun(t *testing.T) {
+	testCases := []struct {
+		name           string
+		checks         map[string]Check
+		optional       map[string]Check
+		expectedStatus string
+		expectedChecks map[string]string
+	}{
+		{
+			name:           "no checks",
+			expectedStatus: StatusReady,
+			expectedChecks: map[string]string{},
+		},
+		{
+			name: "all passing",
+			checks: map[string]Check{
+				"db":    func(context.Context) error { return nil },
+				"queue": func(context.Context) error { return nil },
+			},
+			expectedStatus: StatusReady,
+			expectedChecks: map[string]string{"db": CheckOK, "queue": CheckOK},
+		},
+		{
+			name: "single failing check",
+			checks: map[string]Check{
+				"db":    func(context.Context) error { return nil },
+				"queue": func(context.Context) error { return errors.New("broker unreachable") },
+			},
+			expectedStatus: StatusNotReady,
+			expectedChecks: map[string]string{"db": CheckOK, "queue": CheckFail},
+		},
+		{
+			name:   "failing optional check",
+			checks: map[string]Check{"db": func(context.Context) error { return nil }},
+			optional: map[string]Check{
+				"datadog": func(context.Context) error { return errors.New("connection refused") },
+			},
+			expectedStatus: StatusReady,
+			expectedChecks: map[string]string{"db": CheckOK, "datadog": CheckFail},
+		},
+		{
+			name: "check exceeding the timeout",
+			checks: map[string]Check{
+				"db": func(ctx context.Context) error { <-ctx.Done(); return ctx.Err() },
+			},
+			expectedStatus: StatusNotReady,
+			expectedChecks: map[string]string{"db": CheckTimeout},
+		},
+		{
+			name: "check ignoring the context",
+			checks: map[string]Check{
+				"db": func(context.Context) error { time.Sleep(200 * time.Millisecond); return nil },
+			},
+			expectedStatus: StatusNotReady,
+			expectedChecks: map[string]string{"db": CheckTimeout},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry := NewRegistry(20*time.Millisecond, newTestLogger(&bytes.Buffer{}))
+			for name, check := range tc.checks {
+				registry.Register(name, check)
+			}
+			for name, check := range tc.optional {
+				registry.RegisterOptional(name, check)
+			}
+
+			report := registry.Run(context.Background())
+			if report.Status != tc.expectedStatus {
+				t.Errorf("Expected status '%s', got '%s'", tc.expectedStatus, report.Status)
+			}
+			if report.Ready() != (tc.expectedStatus == StatusReady) {
+				t.Errorf("Expected Ready() to match status '%s'", report.Status)
+			}
+			if len(report.Checks) != len(tc.expectedChecks) {
+				t.Errorf("Expected checks %v, got %v", tc.expectedChecks, report.Checks)
+			}
+			for name, expected := range tc.expectedChecks {
+				if report.Checks[name] != expected {
+					t.Errorf("Expected check %s '%s', got '%s'", name, expected, report.Checks[name])
+				}
+			}
+		})
+	}
+}
+
+func newTestLogger(buf *bytes.Buffer) log.Logger {
+	return log.NewConsoleLoggerWithWriter(log.InfoLevel, buf, false)
+}
+
+func TestRegistryLogsCheckErrors(t *testing.T) {
+	var buf bytes.Buffer
+	registry := NewRegistry(0, newTestLogger(&buf))
+	registry.Register("db", func(context.Context) error { return errors.New("dial tcp 10.0.0.5:3306: connection refused") })
+
+	report := registry.Run(context.Background())
+	if report.Checks["db"] != CheckFail {
+		t.Errorf("Expected check db '%s', got '%s'", CheckFail, report.Checks["db"])
+	}
+	if !strings.Contains(buf.String(), "10.0.0.5:3306") || !strings.Contains(buf.String(), `"check":"db"`) {
+		t.Errorf("Expected the check error to be logged, got %s", buf.String())
+	}
+}
+
+func TestCached(t *testing.T) {
+	var calls atomic.Int32
+	failing := errors.New("connection refused")
+	check := Cached(func(context.Context) error {
+		calls.Add(1)
+		return failing
+	}, 50*time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		if err := check(context.Background()); !errors.Is(err, failing) {
+			t.Fatalf("Expected the cached error, got %v", err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Expected 1 call within the TTL, got %d", got)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	_ = check(context.Background())
+	if got := calls.Load(); got != 2 {
+		t.Errorf("Expected the check to run again after the TTL, got %d calls", got)
+	}
+}
+
+func TestRegistryConcurrentRegister(t *testing.T) {
+	registry := NewRegistry(0, newTestLogger(&bytes.Buffer{}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			registry.Register(string(rune('a'+i)), func(context.Context) error { return nil })
+		}(i)
+		go func() {
+			defer wg.Done()
+			registry.Run(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	if report := registry.Run(context.Background()); len(report.Checks) != 10 || !report.Ready() {
+		t.Errorf("Expected 10 passing checks, got %v", report)
+	}
+}
+
+func TestNewDatabaseCheck(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open sqlite database: %v", err)
+	}
+	check := NewDatabaseCheck(db)
+
+	if err := check(context.Background()); err != nil {
+		t.Errorf("Expected an open database to pass, got %v", err)
+	}
+	db.Close()
+	if err := check(context.Background()); err == nil {
+		t.Error("Expected a closed database to fail")
+	}
+}
+
+func TestNewHTTPCheck(t *testing.T) {
+	testCases := []struct {
+		name        string
+		status      int
+		expectError bool
+	}{
+		{"ok response", http.StatusOK, false},
+		{"no content response", http.StatusNoContent, false},
+		{"error response", http.StatusServiceUnavailable, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+			}))
+			defer upstream.Close()
+
+			err := NewHTTPCheck(upstream.URL)(context.Background())
+			if (err != nil) != tc.expectError {
+				t.Errorf("Expected error %v, got %v", tc.expectError, err)
+			}
+		})
+	}
+
+	if err := NewHTTPCheck("http://127.0.0.1:1")(context.Background()); err == nil {
+		t.Error("Expected an unreachable URL to fail")
+	}
+}
@@ -1,8 +1,13 @@
 package http
 
 import (
+	"encoding/base64"
+	"errors"
 	"net/http"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gofiber/fiber/v2"
@@ -15,6 +20,39 @@ type Response struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
+// Pagination defaults for ParsePagination
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// TotalCountHeader carries the total number of items of a paginated response
+const TotalCountHeader = "X-Total-Count"
+
+// PaginatedResponse is the standard response structure with offset pagination metadata
+type PaginatedResponse struct {
+	Code       int         `json:"code"`
+	Message    string      `json:"message"`
+	Data       interface{} `json:"data"`
+	Total      int64       `json:"total"`
+	Page       int         `json:"page"`
+	PageSize   int         `json:"page_size"`
+	TotalPages int         `json:"total_pages"`
+}
+
+// CursorResponse is the standard response structure with cursor pagination metadata.
+// The cursors are empty when there is no next or previous page.
+type CursorResponse struct {
+	Code       int         `json:"code"`
+	Message    string      `json:"message"`
+	Data       interface{} `json:"data"`
+	NextCursor string      `json:"next_cursor"`
+	PrevCursor string      `json:"prev_cursor"`
+}
+
+// ErrInvalidCursor is returned by DecodeCursor for cursors not created by EncodeCursor
+var ErrInvalidCursor = errors.New("invalid cursor")
+
 // Redact removes sensitive fields marked with `redact:"true"` tag
 func Redact(v interface{}) {
 	val := reflect.ValueOf(v).Elem()
@@ -100,8 +138,21 @@ func HandleFiberError(c *fiber.Ctx, statusCode int, message string) error {
 	return c.Status(statusCode).JSON(response)
 }
 
-// HandleFiberBadRequest sends a 400 Bad Request response for Fiber
-func HandleFiberBadRequest(c *fiber.Ctx, message string) error {
+// HandleFiberErrorWithData sends an error response for Fiber with details in the data field
+func HandleFiberErrorWithData(c *fiber.Ctx, statusCode int, message string, data interface{}) error {
+	response := Response{
+		Code:    statusCode,
+		Message: message,
+		Data:    data,
+	}
+	return c.Status(statusCode).JSON(response)
+}
+
+// HandleFiberBadRequest sends a 400 Bad Request response for Fiber, with optional details in the data field
+func HandleFiberBadRequest(c *fiber.Ctx, message string, data ...interface{}) error {
+	if len(data) > 0 {
+		return HandleFiberErrorWithData(c, fiber.StatusBadRequest, message, data[0])
+	}
 	return HandleFiberError(c, fiber.StatusBadRequest, message)
 }
 
@@ -124,3 +175,92 @@ func HandleFiberUnauthorized(c *fiber.Ctx, message string) error {
 func HandleFiberForbidden(c *fiber.Ctx, message string) error {
 	return HandleFiberError(c, fiber.StatusForbidden, message)
 }
+
+// HandleFiberPaginated sends a page of data for Fiber along with the pagination metadata and the X-Total-Count header
+func HandleFiberPaginated(c *fiber.Ctx, data interface{}, total int64, page, pageSize int) error {
+	totalPages := 0
+	if pageSize > 0 {
+		totalPages = int((total + int64(pageSize) - 1) / int64(pageSize))
+	}
+
+	c.Set(TotalCountHeader, strconv.FormatInt(total, 10))
+	return c.Status(fiber.StatusOK).JSON(PaginatedResponse{
+		Code:       0,
+		Message:    "success",
+		Data:       data,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	})
+}
+
+// ParsePagination reads the ?page= and ?page_size= query params.
+// Missing or invalid values fall back to page 1 and DefaultPageSize, and page_size is capped at MaxPageSize.
+func ParsePagination(c *fiber.Ctx) (page, pageSize int) {
+	page = c.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize = c.QueryInt("page_size", DefaultPageSize)
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	return page, pageSize
+}
+
+// HandleFiberCursor sends a page of data for Fiber along with the opaque cursors of the neighbouring pages
+func HandleFiberCursor(c *fiber.Ctx, data interface{}, nextCursor, prevCursor string) error {
+	return c.Status(fiber.StatusOK).JSON(CursorResponse{
+		Code:       0,
+		Message:    "success",
+		Data:       data,
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+	})
+}
+
+// ParseCursor reads the ?after=, ?before= and ?limit= query params.
+// limit falls back to DefaultPageSize when missing or invalid and is capped at MaxPageSize.
+func ParseCursor(c *fiber.Ctx) (after, before string, limit int) {
+	limit = c.QueryInt("limit", DefaultPageSize)
+	if limit < 1 {
+		limit = DefaultPageSize
+	}
+	if limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+	return c.Query("after"), c.Query("before"), limit
+}
+
+// EncodeCursor returns an opaque cursor for the row with the given ID and sort timestamp
+func EncodeCursor(id uint64, ts time.Time) string {
+	raw := strconv.FormatUint(id, 10) + ":" + strconv.FormatInt(ts.UnixNano(), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor returns the ID and timestamp encoded by EncodeCursor, the timestamp is in UTC
+func DecodeCursor(s string) (uint64, time.Time, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return 0, time.Time{}, ErrInvalidCursor
+	}
+
+	idPart, tsPart, found := strings.Cut(string(raw), ":")
+	if !found {
+		return 0, time.Time{}, ErrInvalidCursor
+	}
+	id, err := strconv.ParseUint(idPart, 10, 64)
+	if err != nil {
+		return 0, time.Time{}, ErrInvalidCursor
+	}
+	nanos, err := strconv.ParseInt(tsPart, 10, 64)
+	if err != nil {
+		return 0, time.Time{}, ErrInvalidCursor
+	}
+	return id, time.Unix(0, nanos).UTC(), nil
+}
@@ -0,0 +1,191 @@
+package http
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestHandleFiberPaginated(t *testing.T) {
+	testCases := []struct {
+		name               string
+		total              int64
+		page               int
+		pageSize           int
+		expectedTotalPages int
+	}{
+		{"exact pages", 40, 1, 20, 2},
+		{"partial last page", 41, 3, 20, 3},
+		{"single page", 5, 1, 20, 1},
+		{"no items", 0, 1, 20, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				return HandleFiberPaginated(c, []string{"a", "b"}, tc.total, tc.page, tc.pageSize)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if header := resp.Header.Get(TotalCountHeader); header != fmt.Sprint(tc.total) {
+				t.Errorf("Expected %s '%d', got '%s'", TotalCountHeader, tc.total, header)
+			}
+
+			var response PaginatedResponse
+			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to parse JSON response: %v", err)
+			}
+			if response.TotalPages != tc.expectedTotalPages {
+				t.Errorf("Expected %d total pages, got %d", tc.expectedTotalPages, response.TotalPages)
+			}
+			if response.Total != tc.total || response.Page != tc.page || response.PageSize != tc.pageSize {
+				t.Errorf("Expected total %d, page %d, page size %d, got %+v", tc.total, tc.page, tc.pageSize, response)
+			}
+			if items, ok := response.Data.([]interface{}); !ok || len(items) != 2 {
+				t.Errorf("Expected 2 items in data, got %v", response.Data)
+			}
+		})
+	}
+}
+
+func TestParsePagination(t *testing.T) {
+	testCases := []struct {
+		name             string
+		query            string
+		expectedPage     int
+		expectedPageSize int
+	}{
+		{"defaults", "", 1, DefaultPageSize},
+		{"explicit values", "?page=3&page_size=50", 3, 50},
+		{"page size clamped to the cap", "?page=2&page_size=1000", 2, MaxPageSize},
+		{"invalid values fall back", "?page=0&page_size=-5", 1, DefaultPageSize},
+		{"non-numeric values fall back", "?page=abc&page_size=xyz", 1, DefaultPageSize},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				page, pageSize := ParsePagination(c)
+				return c.SendString(fmt.Sprintf("%d/%d", page, pageSize))
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/"+tc.query, nil))
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+
+			var page, pageSize int
+			if _, err := fmt.Fscanf(resp.Body, "%d/%d", &page, &pageSize); err != nil {
+				t.Fatalf("Failed to read response: %v", err)
+			}
+			if page != tc.expectedPage || pageSize != tc.expectedPageSize {
+				t.Errorf("Expected page %d and page size %d, got %d and %d", tc.expectedPage, tc.expectedPageSize, page, pageSize)
+			}
+		})
+	}
+}
+
+func TestEncodeDecodeCursor(t *testing.T) {
+	ts := time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC)
+
+	cursor := EncodeCursor(42, ts)
+	if strings.Contains(cursor, ":") {
+		t.Errorf("Expected an opaque cursor, got '%s'", cursor)
+	}
+
+	id, decodedTS, err := DecodeCursor(cursor)
+	if err != nil {
+		t.Fatalf("Failed to decode cursor: %v", err)
+	}
+	if id != 42 {
+		t.Errorf("Expected ID 42, got %d", id)
+	}
+	if !decodedTS.Equal(ts) {
+		t.Errorf("Expected timestamp %v, got %v", ts, decodedTS)
+	}
+
+	for _, invalid := range []string{"", "not base64!", base64.RawURLEncoding.EncodeToString([]byte("42")), base64.RawURLEncoding.EncodeToString([]byte("x:1"))} {
+		if _, _, err := DecodeCursor(invalid); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Expected ErrInvalidCursor for %q, got %v", invalid, err)
+		}
+	}
+}
+
+func TestHandleFiberCursor(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		return HandleFiberCursor(c, []string{"a"}, EncodeCursor(2, time.Unix(0, 0)), "")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v", err)
+	}
+	for _, key := range []string{"data", "next_cursor", "prev_cursor"} {
+		if _, ok := response[key]; !ok {
+			t.Errorf("Expected %s in response, got %v", key, response)
+		}
+	}
+	if response["next_cursor"] != EncodeCursor(2, time.Unix(0, 0)) {
+		t.Errorf("Expected next_cursor to round-trip, got %v", response["next_cursor"])
+	}
+}
+
+func TestParseCursor(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		expectedAfter  string
+		expectedBefore string
+		expectedLimit  int
+	}{
+		{"defaults", "", "", "", DefaultPageSize},
+		{"after cursor", "?after=abc&limit=10", "abc", "", 10},
+		{"before cursor", "?before=def", "", "def", DefaultPageSize},
+		{"limit clamped to the cap", "?limit=500", "", "", MaxPageSize},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				after, before, limit := ParseCursor(c)
+				return c.JSON(fiber.Map{"after": after, "before": before, "limit": limit})
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/"+tc.query, nil))
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+
+			var response struct {
+				After  string `json:"after"`
+				Before string `json:"before"`
+				Limit  int    `json:"limit"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to parse JSON response: %v", err)
+			}
+			if response.After != tc.expectedAfter || response.Before != tc.expectedBefore || response.Limit != tc.expectedLimit {
+				t.Errorf("Expected after %q, before %q, limit %d, got %+v", tc.expectedAfter, tc.expectedBefore, tc.expectedLimit, response)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Package lifecycle logs the startup and shutdown phases of the application with their duration.
+//
+//	defer lifecycle.Phase("database init")()
+package lifecycle
+
+import (
+	"sync"
+	"time"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+var (
+	mu     sync.Mutex
+	logger log.Logger = log.NewNopLogger()
+	starts            = make(map[string]time.Time)
+)
+
+// SetLogger sets the logger the phases are logged to. Phases are not logged until it is called,
+// but OnFail still exits.
+func SetLogger(l log.Logger) {
+	mu.Lock()
+	defer mu.Unlock()
+	logger = l
+}
+
+// Phase logs the start of the named phase and returns a function that logs its completion with the elapsed duration
+func Phase(name string) func() {
+	start := time.Now()
+
+	mu.Lock()
+	starts[name] = start
+	l := logger
+	mu.Unlock()
+
+	l.Info("Phase started", log.String("phase", name))
+	return func() {
+		mu.Lock()
+		delete(starts, name)
+		mu.Unlock()
+
+		l.Info("Phase completed", log.String("phase", name), log.Duration("duration", time.Since(start)))
+	}
+}
+
+// OnFail logs err as fatal with the phase name and the time since the phase started, which exits the process
+func OnFail(name string, err error) {
+	mu.Lock()
+	start, ok := starts[name]
+	delete(starts, name)
+	l := logger
+	mu.Unlock()
+
+	var elapsed time.Duration
+	if ok {
+		elapsed = time.Since(start)
+	}
+	l.Fatal("Phase failed", log.String("phase", name), log.Duration("duration", elapsed), log.Error(err))
+}
@@ -0,0 +1,118 @@
+package lifecycle
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// fatalLogger records Fatal calls instead of exiting
+type fatalLogger struct {
+	log.Logger
+	msg    string
+	fields []log.Field
+}
+
+func (l *fatalLogger) Fatal(msg string, fields ...log.Field) {
+	l.msg = msg
+	l.fields = fields
+}
+
+// captureLogs points the package logger at a JSON console logger writing to the returned buffer
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	SetLogger(log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false))
+	t.Cleanup(func() { SetLogger(log.NewNopLogger()) })
+	return &buf
+}
+
+func TestPhase(t *testing.T) {
+	buf := captureLogs(t)
+
+	func() {
+		defer Phase("database init")()
+		time.Sleep(time.Millisecond)
+	}()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 log lines, got %d: %q", len(lines), buf.String())
+	}
+
+	var started, completed map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &started); err != nil {
+		t.Fatalf("Failed to parse log line %q: %v", lines[0], err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &completed); err != nil {
+		t.Fatalf("Failed to parse log line %q: %v", lines[1], err)
+	}
+
+	if started["message"] != "Phase started" || started["phase"] != "database init" {
+		t.Errorf("Expected a start line for the database init phase, got %v", started)
+	}
+	if _, ok := started["duration"]; ok {
+		t.Errorf("Expected no duration on the start line, got %v", started["duration"])
+	}
+	if completed["message"] != "Phase completed" || completed["phase"] != "database init" {
+		t.Errorf("Expected a completion line for the database init phase, got %v", completed)
+	}
+	duration, ok := completed["duration"].(float64)
+	if !ok {
+		t.Fatalf("Expected a numeric duration field, got %v", completed["duration"])
+	}
+	if time.Duration(duration) < time.Millisecond {
+		t.Errorf("Expected a duration of at least 1ms, got %v", time.Duration(duration))
+	}
+}
+
+func TestOnFail(t *testing.T) {
+	tests := []struct {
+		name        string
+		started     bool
+		expectTimed bool
+	}{
+		{name: "started phase", started: true, expectTimed: true},
+		{name: "unknown phase", started: false, expectTimed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			captureLogs(t)
+			if tt.started {
+				Phase("container init")
+				time.Sleep(time.Millisecond)
+			}
+
+			logger := &fatalLogger{}
+			SetLogger(logger)
+			OnFail("container init", errors.New("boom"))
+
+			if logger.msg != "Phase failed" {
+				t.Errorf("Expected message %q, got %q", "Phase failed", logger.msg)
+			}
+			fields := make(map[string]any, len(logger.fields))
+			for _, field := range logger.fields {
+				fields[field.Key] = field.Value
+			}
+			if fields["phase"] != "container init" {
+				t.Errorf("Expected phase %q, got %v", "container init", fields["phase"])
+			}
+			if err, _ := fields["error"].(error); err == nil || err.Error() != "boom" {
+				t.Errorf("Expected error %q, got %v", "boom", fields["error"])
+			}
+			duration, _ := fields["duration"].(time.Duration)
+			if tt.expectTimed && duration <= 0 {
+				t.Errorf("Expected a positive duration, got %v", duration)
+			}
+			if !tt.expectTimed && duration != 0 {
+				t.Errorf("Expected a zero duration, got %v", duration)
+			}
+		})
+	}
+}
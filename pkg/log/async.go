@@ -0,0 +1,297 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// defaultDrainTimeout bounds how long Close waits for queued entries to be written.
+const defaultDrainTimeout = 5 * time.Second
+
+// AsyncLogger queues log calls and writes them to the wrapped logger from a background goroutine,
+// so callers never block on slow outputs. Entries are dropped when the queue is full.
+// Fields are evaluated by the background goroutine, so their values must not be changed after the call.
+type AsyncLogger struct {
+	base  Logger
+	queue *asyncQueue // shared by loggers derived with WithFields/WithContext
+}
+
+// AsyncLoggerConfig contains configuration for the async wrapper; the wrapped logger is configured under logger.
+type AsyncLoggerConfig struct {
+	BufferSize   int           `mapstructure:"buffer_size"`   // queued entries before calls are dropped
+	DrainTimeout time.Duration `mapstructure:"drain_timeout"` // maximum time Close waits for queued entries
+}
+
+// logEntry is a queued log call. logger is the derived base logger the entry was logged on.
+type logEntry struct {
+	logger Logger
+	level  Level
+	msg    string
+	fields []Field
+	caller string        // call site captured before queueing, reported by loggers that support it
+	synced chan struct{} // set for flush markers, closed once every earlier entry is written
+}
+
+// asyncQueue holds the queued entries and the background goroutine state.
+type asyncQueue struct {
+	entries      chan logEntry
+	dropped      atomic.Uint64
+	closed       atomic.Bool
+	stop         chan struct{}
+	done         chan struct{}
+	drainTimeout time.Duration
+	closeOnce    sync.Once
+	closeErr     error
+}
+
+func init() {
+	RegisterFactory("async", NewAsyncLoggerFromConfig)
+}
+
+// NewAsyncLoggerFromConfig creates an async logger wrapping the logger configured under the logger key.
+func NewAsyncLoggerFromConfig(level Level, v *viper.Viper) (Logger, error) {
+	var config AsyncLoggerConfig
+	if err := v.Unmarshal(&config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal async logger config: %w", err)
+	}
+
+	baseConfig := v.Sub("logger")
+	if baseConfig == nil {
+		return nil, fmt.Errorf("async logger requires a nested logger config")
+	}
+	driver := baseConfig.GetString("driver")
+	factory, ok := loggerFactories[driver]
+	if !ok {
+		return nil, fmt.Errorf("async logger: driver %s not found", driver)
+	}
+	base, err := factory(level, baseConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create wrapped logger: %w", err)
+	}
+
+	return NewAsyncLoggerWithDrainTimeout(base, config.BufferSize, config.DrainTimeout), nil
+}
+
+// NewAsyncLogger wraps base so that log calls are queued in a buffer of bufferSize entries.
+// Call Close to write the remaining entries.
+func NewAsyncLogger(base Logger, bufferSize int) Logger {
+	return NewAsyncLoggerWithDrainTimeout(base, bufferSize, defaultDrainTimeout)
+}
+
+// NewAsyncLoggerWithDrainTimeout is NewAsyncLogger with the maximum time Close waits for queued entries.
+func NewAsyncLoggerWithDrainTimeout(base Logger, bufferSize int, drainTimeout time.Duration) *AsyncLogger {
+	// Set defaults if not provided
+	if bufferSize <= 0 {
+		bufferSize = 1024
+	}
+	if drainTimeout <= 0 {
+		drainTimeout = defaultDrainTimeout
+	}
+
+	queue := &asyncQueue{
+		entries:      make(chan logEntry, bufferSize),
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+		drainTimeout: drainTimeout,
+	}
+	go queue.run()
+
+	return &AsyncLogger{base: base, queue: queue}
+}
+
+// run writes queued entries until stopped, then writes the entries still queued.
+func (q *asyncQueue) run() {
+	defer close(q.done)
+
+	for {
+		select {
+		case entry := <-q.entries:
+			entry.write()
+		case <-q.stop:
+			for {
+				select {
+				case entry := <-q.entries:
+					entry.write()
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// write calls the logger method for the entry level.
+func (e logEntry) write() {
+	if e.synced != nil {
+		close(e.synced)
+		return
+	}
+
+	logger := reportingCaller(e.logger, e.caller)
+	switch e.level {
+	case DebugLevel:
+		logger.Debug(e.msg, e.fields...)
+	case InfoLevel:
+		logger.Info(e.msg, e.fields...)
+	case WarnLevel:
+		logger.Warn(e.msg, e.fields...)
+	case ErrorLevel:
+		logger.Error(e.msg, e.fields...)
+	}
+}
+
+// enqueue queues entry without blocking, counting it as dropped when the queue is full or closed.
+func (q *asyncQueue) enqueue(entry logEntry) {
+	if q.closed.Load() {
+		q.dropped.Add(1)
+		return
+	}
+
+	select {
+	case q.entries <- entry:
+	default:
+		q.dropped.Add(1)
+	}
+}
+
+// flush waits up to the drain timeout until the entries queued so far are written.
+func (q *asyncQueue) flush() {
+	if q.closed.Load() {
+		return
+	}
+
+	timeout := time.NewTimer(q.drainTimeout)
+	defer timeout.Stop()
+
+	synced := make(chan struct{})
+	select {
+	case q.entries <- logEntry{synced: synced}:
+	case <-timeout.C:
+		return
+	}
+	select {
+	case <-synced:
+	case <-timeout.C:
+	}
+}
+
+// close stops accepting entries and waits up to the drain timeout for the queued ones.
+func (q *asyncQueue) close() error {
+	q.closeOnce.Do(func() {
+		q.closed.Store(true)
+		close(q.stop)
+
+		select {
+		case <-q.done:
+		case <-time.After(q.drainTimeout):
+			q.closeErr = fmt.Errorf("timed out draining async logger after %s, %d entries pending", q.drainTimeout, len(q.entries))
+		}
+	})
+	return q.closeErr
+}
+
+// DroppedCount returns the number of entries dropped because the queue was full or closed.
+func (l *AsyncLogger) DroppedCount() uint64 {
+	return l.queue.dropped.Load()
+}
+
+// log queues a message. The caller is captured here since the background goroutine writing
+// the entry would otherwise be reported; it must be called directly by the exported methods.
+func (l *AsyncLogger) log(level Level, msg string, fields []Field) {
+	entry := logEntry{logger: l.base, level: level, msg: msg, fields: fields}
+	if _, ok := l.base.(callerReporter); ok {
+		entry.caller = callerOf(2)
+	}
+	l.queue.enqueue(entry)
+}
+
+// Debug queues a debug message.
+func (l *AsyncLogger) Debug(msg string, fields ...Field) {
+	l.log(DebugLevel, msg, fields)
+}
+
+// Info queues an info message.
+func (l *AsyncLogger) Info(msg string, fields ...Field) {
+	l.log(InfoLevel, msg, fields)
+}
+
+// Warn queues a warning message.
+func (l *AsyncLogger) Warn(msg string, fields ...Field) {
+	l.log(WarnLevel, msg, fields)
+}
+
+// Error queues an error message.
+func (l *AsyncLogger) Error(msg string, fields ...Field) {
+	l.log(ErrorLevel, msg, fields)
+}
+
+// Fatal writes the queued entries, then logs a fatal message synchronously since the process is about to exit.
+func (l *AsyncLogger) Fatal(msg string, fields ...Field) {
+	l.queue.flush()
+	reportingCaller(l.base, callerOf(1)).Fatal(msg, fields...)
+}
+
+// Panic writes the queued entries, then logs a panic message synchronously.
+func (l *AsyncLogger) Panic(msg string, fields ...Field) {
+	l.queue.flush()
+	reportingCaller(l.base, callerOf(1)).Panic(msg, fields...)
+}
+
+// Formatted logging methods, messages are formatted before queueing
+func (l *AsyncLogger) Debugf(format string, args ...interface{}) {
+	l.log(DebugLevel, fmt.Sprintf(format, args...), nil)
+}
+
+func (l *AsyncLogger) Infof(format string, args ...interface{}) {
+	l.log(InfoLevel, fmt.Sprintf(format, args...), nil)
+}
+
+func (l *AsyncLogger) Warnf(format string, args ...interface{}) {
+	l.log(WarnLevel, fmt.Sprintf(format, args...), nil)
+}
+
+func (l *AsyncLogger) Errorf(format string, args ...interface{}) {
+	l.log(ErrorLevel, fmt.Sprintf(format, args...), nil)
+}
+
+func (l *AsyncLogger) Fatalf(format string, args ...interface{}) {
+	l.queue.flush()
+	reportingCaller(l.base, callerOf(1)).Fatal(fmt.Sprintf(format, args...))
+}
+
+func (l *AsyncLogger) Panicf(format string, args ...interface{}) {
+	l.queue.flush()
+	reportingCaller(l.base, callerOf(1)).Panic(fmt.Sprintf(format, args...))
+}
+
+// WithFields creates a new async logger wrapping the base logger with additional context fields.
+func (l *AsyncLogger) WithFields(fields ...Field) Logger {
+	return &AsyncLogger{base: l.base.WithFields(fields...), queue: l.queue}
+}
+
+// WithContext creates a new async logger wrapping the base logger with context.
+func (l *AsyncLogger) WithContext(ctx context.Context) Logger {
+	return &AsyncLogger{base: l.base.WithContext(ctx), queue: l.queue}
+}
+
+// WithRedactedKeys creates a new async logger whose base logger redacts the given field keys.
+func (l *AsyncLogger) WithRedactedKeys(keys ...string) Logger {
+	return &AsyncLogger{base: l.base.WithRedactedKeys(keys...), queue: l.queue}
+}
+
+// Close writes the queued entries, stops the background goroutine and closes the base logger if it has a Close method.
+// The queue is shared with derived loggers, which drop their entries afterwards.
+func (l *AsyncLogger) Close() error {
+	if err := l.queue.close(); err != nil {
+		return err
+	}
+	if closer, ok := l.base.(interface{ Close() error }); ok {
+		return closer.Close()
+	}
+	return nil
+}
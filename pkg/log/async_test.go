@@ -0,0 +1,183 @@
+package log
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// blockingWriter blocks every write until release is closed, signalling entered on the first one
+type blockingWriter struct {
+	entered chan struct{}
+	release chan struct{}
+	buf     safeBuffer
+}
+
+func newBlockingWriter() *blockingWriter {
+	return &blockingWriter{entered: make(chan struct{}, 1), release: make(chan struct{})}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	select {
+	case w.entered <- struct{}{}:
+	default:
+	}
+	<-w.release
+	return w.buf.Write(p)
+}
+
+func TestAsyncLoggerWritesInOrder(t *testing.T) {
+	var buf safeBuffer
+	logger := NewAsyncLogger(NewConsoleLoggerWithWriter(DebugLevel, &buf, false), 16).(*AsyncLogger)
+
+	logger.Debug("first")
+	logger.WithFields(String("request_id", "123")).Info("second")
+	logger.Warnf("third %d", 3)
+	logger.Error("fourth", Int("code", 4))
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Failed to close async logger: %v", err)
+	}
+
+	output := buf.String()
+	last := -1
+	for _, expected := range []string{`"message":"first"`, `"request_id":"123"`, `"message":"third 3"`, `"code":4`} {
+		index := strings.Index(output, expected)
+		if index <= last {
+			t.Errorf("Expected %s after the previous entries, got: %s", expected, output)
+		}
+		last = index
+	}
+	if logger.DroppedCount() != 0 {
+		t.Errorf("Expected no dropped entries, got %d", logger.DroppedCount())
+	}
+}
+
+func TestAsyncLoggerReportsCaller(t *testing.T) {
+	var buf safeBuffer
+	logger := NewAsyncLogger(NewConsoleLoggerWithWriter(DebugLevel, &buf, false), 16).(*AsyncLogger)
+
+	logger.Info("plain")
+	logger.WithFields(String("request_id", "123")).Warn("derived")
+	logger.Errorf("formatted %d", 1)
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Failed to close async logger: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 entries, got %d: %s", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, "async_test.go:") || strings.Count(line, `"caller"`) != 1 {
+			t.Errorf("Expected a single caller in async_test.go, got: %s", line)
+		}
+	}
+}
+
+func TestAsyncLoggerDropsWhenFull(t *testing.T) {
+	writer := newBlockingWriter()
+	logger := NewAsyncLogger(NewConsoleLoggerWithWriter(InfoLevel, writer, false), 1).(*AsyncLogger)
+
+	logger.Info("written")
+	<-writer.entered // The background goroutine is blocked writing the first entry
+	logger.Info("queued")
+	logger.Info("dropped")
+	logger.Info("dropped")
+
+	if got := logger.DroppedCount(); got != 2 {
+		t.Errorf("Expected 2 dropped entries, got %d", got)
+	}
+
+	close(writer.release)
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Failed to close async logger: %v", err)
+	}
+	output := writer.buf.String()
+	if !strings.Contains(output, "written") || !strings.Contains(output, "queued") || strings.Contains(output, "dropped") {
+		t.Errorf("Expected only the queued entries to be written, got: %s", output)
+	}
+
+	// Entries logged after Close are dropped too
+	logger.Info("after close")
+	if got := logger.DroppedCount(); got != 3 {
+		t.Errorf("Expected 3 dropped entries, got %d", got)
+	}
+}
+
+func TestAsyncLoggerCloseDrainTimeout(t *testing.T) {
+	writer := newBlockingWriter()
+	logger := NewAsyncLoggerWithDrainTimeout(NewConsoleLoggerWithWriter(InfoLevel, writer, false), 4, 20*time.Millisecond)
+	defer func() {
+		// Let the background goroutine finish before other tests create loggers
+		close(writer.release)
+		<-logger.queue.done
+	}()
+
+	logger.Info("blocked")
+	<-writer.entered
+	logger.Info("pending")
+
+	start := time.Now()
+	if err := logger.Close(); err == nil {
+		t.Error("Expected a drain timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected Close to return after the drain timeout, took %s", elapsed)
+	}
+}
+
+func TestAsyncLoggerPanicFlushesQueue(t *testing.T) {
+	var buf safeBuffer
+	logger := NewAsyncLogger(NewConsoleLoggerWithWriter(InfoLevel, &buf, false), 16).(*AsyncLogger)
+	defer logger.Close()
+
+	logger.Info("queued before panic")
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected Panic to panic")
+			}
+		}()
+		logger.Panic("panic message")
+	}()
+
+	output := buf.String()
+	queued, panicked := strings.Index(output, "queued before panic"), strings.Index(output, "panic message")
+	if queued == -1 || panicked == -1 || queued > panicked {
+		t.Errorf("Expected the queued entry before the panic message, got: %s", output)
+	}
+}
+
+func TestAsyncLoggerRegistration(t *testing.T) {
+	v := viper.New()
+	v.Set("log.loggers.async_console.driver", "async")
+	v.Set("log.loggers.async_console.enabled", true)
+	v.Set("log.loggers.async_console.buffer_size", 64)
+	v.Set("log.loggers.async_console.drain_timeout", "1s")
+	v.Set("log.loggers.async_console.logger.driver", "console")
+
+	logger, err := CreateLoggerFromConfig(v)
+	if err != nil {
+		t.Fatalf("Failed to create logger from config: %v", err)
+	}
+	asyncLogger, ok := logger.(*AsyncLogger)
+	if !ok {
+		t.Fatalf("Expected *AsyncLogger, got %T", logger)
+	}
+	defer asyncLogger.Close()
+
+	if _, ok := asyncLogger.base.(*ConsoleLogger); !ok {
+		t.Errorf("Expected wrapped *ConsoleLogger, got %T", asyncLogger.base)
+	}
+	if cap(asyncLogger.queue.entries) != 64 || asyncLogger.queue.drainTimeout != time.Second {
+		t.Errorf("Expected buffer size 64 and drain timeout 1s, got %d and %s", cap(asyncLogger.queue.entries), asyncLogger.queue.drainTimeout)
+	}
+
+	// The wrapped logger config is required
+	v.Set("log.loggers.async_console.logger.driver", "unknown")
+	if _, err := CreateLoggerFromConfig(v); err == nil {
+		t.Error("Expected an error for an unknown wrapped driver")
+	}
+}
@@ -0,0 +1,105 @@
+package log
+
+import (
+	"sync"
+	"time"
+)
+
+// levelRank orders levels so buffered backends can skip entries below their configured level.
+var levelRank = map[Level]int{
+	DebugLevel: 0,
+	InfoLevel:  1,
+	WarnLevel:  2,
+	ErrorLevel: 3,
+	FatalLevel: 4,
+	PanicLevel: 5,
+}
+
+// levelEnabled reports whether entries at level are emitted by a logger configured with minimum.
+func levelEnabled(minimum, level Level) bool {
+	return levelRank[level] >= levelRank[minimum]
+}
+
+// batcher buffers items and hands them to flushFn from a background goroutine,
+// once size items are buffered or interval has elapsed.
+type batcher[T any] struct {
+	size    int
+	flushFn func(items []T)
+
+	mu    sync.Mutex
+	items []T
+
+	flush     chan struct{}
+	done      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// newBatcher creates a batcher and starts its flush goroutine. Call close to flush pending items.
+func newBatcher[T any](size int, interval time.Duration, flushFn func(items []T)) *batcher[T] {
+	b := &batcher[T]{
+		size:    size,
+		flushFn: flushFn,
+		flush:   make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	b.wg.Add(1)
+	go b.run(interval)
+	return b
+}
+
+// run flushes the buffer whenever the batch is full or the flush interval elapses.
+func (b *batcher[T]) run(interval time.Duration) {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.flushNow()
+		case <-b.flush:
+			b.flushNow()
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// add buffers an item and wakes the flush goroutine once the batch is full.
+func (b *batcher[T]) add(item T) {
+	b.mu.Lock()
+	b.items = append(b.items, item)
+	full := len(b.items) >= b.size
+	b.mu.Unlock()
+
+	if full {
+		select {
+		case b.flush <- struct{}{}:
+		default:
+			// A flush is already pending
+		}
+	}
+}
+
+// flushNow hands all buffered items to flushFn on the calling goroutine.
+func (b *batcher[T]) flushNow() {
+	b.mu.Lock()
+	items := b.items
+	b.items = nil
+	b.mu.Unlock()
+
+	if len(items) > 0 {
+		b.flushFn(items)
+	}
+}
+
+// close stops the flush goroutine and flushes the remaining items.
+func (b *batcher[T]) close() {
+	b.closeOnce.Do(func() {
+		close(b.done)
+		b.wg.Wait()
+		b.flushNow()
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -22,7 +23,11 @@ type ConsoleLogger struct {
 	logger      zerolog.Logger
 	level       Level
 	contextData map[string]any
+	redact      redactSet
+	levels      *levelState // shared by loggers derived from this one, see SetLevel
 	writer      io.Writer
+	colorized   bool
+	callerSkip  int // extra frames skipped when reporting the caller, see WithCallerSkip
 }
 
 func init() {
@@ -52,96 +57,145 @@ func NewConsoleLogger(level Level) Logger {
 func NewConsoleLoggerWithWriter(level Level, writer io.Writer, colorized bool) Logger {
 	// Configure zerolog
 	zerolog.TimeFieldFormat = time.RFC3339Nano
-	zerolog.SetGlobalLevel(parseLogLevel(string(level)))
-
-	var logger zerolog.Logger
-	if colorized {
-		logger = zerolog.New(zerolog.ConsoleWriter{Out: writer}).With().Timestamp().Caller().Logger()
-	} else {
-		logger = zerolog.New(writer).With().Timestamp().Caller().Logger()
-	}
+	HotReloadLevel(string(level))
 
 	return &ConsoleLogger{
-		logger:      logger,
+		logger:      newConsoleZerolog(writer, colorized, 0),
 		level:       level,
 		contextData: make(map[string]any),
+		levels:      newLevelState(),
 		writer:      writer,
+		colorized:   colorized,
+	}
+}
+
+// newConsoleZerolog builds the zerolog logger, reporting the caller callerSkip frames above the log call.
+func newConsoleZerolog(writer io.Writer, colorized bool, callerSkip int) zerolog.Logger {
+	return newConsoleZerologContext(writer, colorized).CallerWithSkipFrameCount(callerSkipFrameCount(callerSkip)).Logger()
+}
+
+// newConsoleZerologContext starts the zerolog logger without a caller.
+func newConsoleZerologContext(writer io.Writer, colorized bool) zerolog.Context {
+	if colorized {
+		writer = zerolog.ConsoleWriter{Out: writer}
 	}
+	return zerolog.New(writer).With().Timestamp()
+}
+
+// event starts a zerolog event at level, or returns nil when the level is filtered out.
+func (l *ConsoleLogger) event(level zerolog.Level) *zerolog.Event {
+	return newLevelEvent(&l.logger, l.levels, level)
+}
+
+// SetLevel changes the minimum level of this logger and the loggers derived from it.
+func (l *ConsoleLogger) SetLevel(level Level) {
+	l.levels.set(level)
+}
+
+// GetLevel returns the current minimum level.
+func (l *ConsoleLogger) GetLevel() Level {
+	return l.levels.level()
 }
 
 // addFields adds fields to the zerolog event.
 func (l *ConsoleLogger) addFields(event *zerolog.Event, fields []Field) *zerolog.Event {
+	// Disabled levels return a nil event, skip field evaluation entirely
+	if event == nil {
+		return event
+	}
+
 	// Add context data first
 	for k, v := range l.contextData {
-		event = event.Interface(k, v)
+		event = appendField(event, k, l.redact.value(k, v))
 	}
 
 	// Add provided fields
 	for _, field := range fields {
-		event = event.Interface(field.Key, field.Value)
+		event = appendField(event, field.Key, l.redact.value(field.Key, field.Value))
 	}
 	return event
 }
 
 // Debug logs a debug message.
 func (l *ConsoleLogger) Debug(msg string, fields ...Field) {
-	event := l.logger.Debug()
+	event := l.event(zerolog.DebugLevel)
 	l.addFields(event, fields).Msg(msg)
 }
 
 // Info logs an info message.
 func (l *ConsoleLogger) Info(msg string, fields ...Field) {
-	event := l.logger.Info()
+	event := l.event(zerolog.InfoLevel)
 	l.addFields(event, fields).Msg(msg)
 }
 
 // Warn logs a warning message.
 func (l *ConsoleLogger) Warn(msg string, fields ...Field) {
-	event := l.logger.Warn()
+	event := l.event(zerolog.WarnLevel)
 	l.addFields(event, fields).Msg(msg)
 }
 
 // Error logs an error message.
 func (l *ConsoleLogger) Error(msg string, fields ...Field) {
-	event := l.logger.Error()
-	l.addFields(event, fields).Msg(msg)
+	event := l.event(zerolog.ErrorLevel)
+	l.addFields(event, formatStackTrace(fields)).Msg(msg)
 }
 
 // Fatal logs a fatal message and exits.
 func (l *ConsoleLogger) Fatal(msg string, fields ...Field) {
-	event := l.logger.Fatal()
+	event := l.event(zerolog.FatalLevel)
 	l.addFields(event, fields).Msg(msg)
 }
 
 // Panic logs a panic message and panics.
 func (l *ConsoleLogger) Panic(msg string, fields ...Field) {
-	event := l.logger.Panic()
+	event := l.event(zerolog.PanicLevel)
 	l.addFields(event, fields).Msg(msg)
 }
 
+// formatStackTrace splits a stack_trace field into lines so it stays readable in console output.
+func formatStackTrace(fields []Field) []Field {
+	for i, field := range fields {
+		stack, ok := field.Value.(string)
+		if field.Key != StackTraceKey || !ok {
+			continue
+		}
+
+		lines := strings.Split(strings.TrimSpace(stack), "\n")
+		for j := range lines {
+			lines[j] = strings.TrimSpace(lines[j])
+		}
+
+		formatted := make([]Field, len(fields))
+		copy(formatted, fields)
+		formatted[i] = Field{Key: StackTraceKey, Value: lines}
+		return formatted
+	}
+	return fields
+}
+
 // Formatted logging methods
 func (l *ConsoleLogger) Debugf(format string, args ...interface{}) {
-	l.logger.Debug().Msg(fmt.Sprintf(format, args...))
+	l.addFields(l.event(zerolog.DebugLevel), nil).Msg(fmt.Sprintf(format, args...))
 }
 
 func (l *ConsoleLogger) Infof(format string, args ...interface{}) {
-	l.logger.Info().Msg(fmt.Sprintf(format, args...))
+	l.addFields(l.event(zerolog.InfoLevel), nil).Msg(fmt.Sprintf(format, args...))
 }
 
 func (l *ConsoleLogger) Warnf(format string, args ...interface{}) {
-	l.logger.Warn().Msg(fmt.Sprintf(format, args...))
+	l.addFields(l.event(zerolog.WarnLevel), nil).Msg(fmt.Sprintf(format, args...))
 }
 
 func (l *ConsoleLogger) Errorf(format string, args ...interface{}) {
-	l.logger.Error().Msg(fmt.Sprintf(format, args...))
+	l.addFields(l.event(zerolog.ErrorLevel), nil).Msg(fmt.Sprintf(format, args...))
 }
 
 func (l *ConsoleLogger) Fatalf(format string, args ...interface{}) {
-	l.logger.Fatal().Msg(fmt.Sprintf(format, args...))
+	l.addFields(l.event(zerolog.FatalLevel), nil).Msg(fmt.Sprintf(format, args...))
 }
 
 func (l *ConsoleLogger) Panicf(format string, args ...interface{}) {
-	l.logger.Panic().Msg(fmt.Sprintf(format, args...))
+	l.addFields(l.event(zerolog.PanicLevel), nil).Msg(fmt.Sprintf(format, args...))
 }
 
 // WithFields creates a new logger with additional context fields.
@@ -162,7 +216,56 @@ func (l *ConsoleLogger) WithFields(fields ...Field) Logger {
 		logger:      l.logger,
 		level:       l.level,
 		contextData: newContextData,
+		redact:      l.redact,
+		levels:      l.levels,
+		writer:      l.writer,
+		colorized:   l.colorized,
+		callerSkip:  l.callerSkip,
+	}
+}
+
+// WithCallerSkip creates a new logger that reports the caller n frames further up the stack,
+// e.g. WithCallerSkip(1) inside a logging helper reports the helper's caller. Skips add up across
+// calls and are kept by WithFields, WithContext and WithRedactedKeys.
+func (l *ConsoleLogger) WithCallerSkip(n int) Logger {
+	callerSkip := l.callerSkip + n
+	return &ConsoleLogger{
+		logger:      newConsoleZerolog(l.writer, l.colorized, callerSkip),
+		level:       l.level,
+		contextData: l.contextData,
+		redact:      l.redact,
+		levels:      l.levels,
+		writer:      l.writer,
+		colorized:   l.colorized,
+		callerSkip:  callerSkip,
+	}
+}
+
+// withCaller creates a new logger that reports caller instead of the code calling its methods.
+func (l *ConsoleLogger) withCaller(caller string) Logger {
+	return &ConsoleLogger{
+		logger:      newConsoleZerologContext(l.writer, l.colorized).Str(zerolog.CallerFieldName, caller).Logger(),
+		level:       l.level,
+		contextData: l.contextData,
+		redact:      l.redact,
+		levels:      l.levels,
+		writer:      l.writer,
+		colorized:   l.colorized,
+		callerSkip:  l.callerSkip,
+	}
+}
+
+// WithRedactedKeys creates a new logger that redacts the values of fields with the given keys.
+func (l *ConsoleLogger) WithRedactedKeys(keys ...string) Logger {
+	return &ConsoleLogger{
+		logger:      l.logger,
+		level:       l.level,
+		contextData: l.contextData,
+		redact:      l.redact.with(keys...),
+		levels:      l.levels,
 		writer:      l.writer,
+		colorized:   l.colorized,
+		callerSkip:  l.callerSkip,
 	}
 }
 
@@ -173,6 +276,10 @@ func (l *ConsoleLogger) WithContext(ctx context.Context) Logger {
 		logger:      l.logger,
 		level:       l.level,
 		contextData: l.contextData,
+		redact:      l.redact,
+		levels:      l.levels,
 		writer:      l.writer,
+		colorized:   l.colorized,
+		callerSkip:  l.callerSkip,
 	}
 }
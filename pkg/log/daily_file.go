@@ -0,0 +1,254 @@
+package log
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dailyDateFormat is the date appended to daily log file names, e.g. app-2024-01-31.log
+const dailyDateFormat = "2006-01-02"
+
+// DailyFileLogger is a FileLogger that starts a new file at midnight UTC instead of rotating by size.
+// Derived loggers are plain FileLoggers sharing the same daily file.
+type DailyFileLogger struct {
+	*FileLogger
+	writer *dailyFileWriter
+}
+
+// NewDailyFileLogger creates a new file logger with daily rotation.
+// Only the newest MaxBackups previous files are kept, older ones are compressed if Compress is set and
+// removed otherwise. Files older than MaxAge days are removed.
+func NewDailyFileLogger(level Level, config *FileLoggerConfig) (Logger, error) {
+	return newDailyFileLogger(level, config, time.Now)
+}
+
+// newDailyFileLogger creates a daily file logger using clockFunc as the current time, e.g. a fake clock in tests.
+func newDailyFileLogger(level Level, config *FileLoggerConfig, clockFunc func() time.Time) (*DailyFileLogger, error) {
+	// Set defaults if not provided
+	if config.MaxBackups == 0 {
+		config.MaxBackups = 7
+	}
+	if config.MaxAge == 0 {
+		config.MaxAge = 30 // 30 days
+	}
+
+	writer := &dailyFileWriter{
+		filename:   config.Filename,
+		maxBackups: config.MaxBackups,
+		maxAge:     config.MaxAge,
+		compress:   config.Compress,
+		clockFunc:  clockFunc,
+	}
+	if err := writer.open(); err != nil {
+		return nil, err
+	}
+	writer.scheduleRotation()
+
+	return &DailyFileLogger{
+		FileLogger: newFileLoggerWithOutput(level, config, writer),
+		writer:     writer,
+	}, nil
+}
+
+// dailyFileWriter writes to the file of the current UTC day and switches files from a time.AfterFunc timer.
+type dailyFileWriter struct {
+	filename   string // base path, the date is inserted before the extension
+	maxBackups int
+	maxAge     int // days
+	compress   bool
+	clockFunc  func() time.Time
+
+	mu     sync.Mutex
+	file   *os.File
+	day    string
+	timer  *time.Timer
+	closed bool
+}
+
+// now returns the current time in UTC.
+func (w *dailyFileWriter) now() time.Time {
+	return w.clockFunc().UTC()
+}
+
+// pathFor returns the file path for day, e.g. logs/app-2024-01-31.log for logs/app.log.
+func (w *dailyFileWriter) pathFor(day string) string {
+	ext := filepath.Ext(w.filename)
+	return strings.TrimSuffix(w.filename, ext) + "-" + day + ext
+}
+
+// open opens, or creates, the file of the current day. The caller must hold mu, except during construction.
+func (w *dailyFileWriter) open() error {
+	day := w.now().Format(dailyDateFormat)
+	file, err := os.OpenFile(w.pathFor(day), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return fmt.Errorf("failed to open daily log file: %w", err)
+	}
+	w.file = file
+	w.day = day
+	return nil
+}
+
+// Write writes p to the file of the current day.
+func (w *dailyFileWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return 0, os.ErrClosed
+	}
+	return w.file.Write(p)
+}
+
+// scheduleRotation arms the timer for the next midnight UTC.
+func (w *dailyFileWriter) scheduleRotation() {
+	now := w.now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return
+	}
+	w.timer = time.AfterFunc(midnight.Sub(now), func() {
+		// Errors are dropped to avoid logging loops, writes continue to the previous file
+		_ = w.rotate()
+		w.scheduleRotation()
+	})
+}
+
+// rotate switches to the file of the current day if the day has changed, then cleans up old files.
+func (w *dailyFileWriter) rotate() error {
+	w.mu.Lock()
+	if w.closed || w.now().Format(dailyDateFormat) == w.day {
+		w.mu.Unlock()
+		return nil
+	}
+
+	previous := w.file
+	if err := w.open(); err != nil {
+		w.mu.Unlock()
+		return err
+	}
+	w.mu.Unlock()
+
+	if err := previous.Close(); err != nil {
+		return fmt.Errorf("failed to close daily log file: %w", err)
+	}
+	return w.cleanup()
+}
+
+// backups returns the previous daily files, plain and compressed, oldest first.
+func (w *dailyFileWriter) backups() ([]string, error) {
+	ext := filepath.Ext(w.filename)
+	prefix := strings.TrimSuffix(w.filename, ext) + "-"
+
+	matches, err := filepath.Glob(prefix + "[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]" + ext + "*")
+	if err != nil {
+		return nil, err
+	}
+
+	current := w.pathFor(w.day)
+	var backups []string
+	for _, path := range matches {
+		if path != current && (strings.HasSuffix(path, ext) || strings.HasSuffix(path, ext+".gz")) {
+			backups = append(backups, path)
+		}
+	}
+	// The date sorts lexically
+	sort.Strings(backups)
+	return backups, nil
+}
+
+// cleanup keeps the newest maxBackups files, compresses or removes older ones and removes
+// any file older than maxAge days.
+func (w *dailyFileWriter) cleanup() error {
+	w.mu.Lock()
+	backups, err := w.backups()
+	cutoff := w.now().AddDate(0, 0, -w.maxAge).Format(dailyDateFormat)
+	w.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	ext := filepath.Ext(w.filename)
+	prefix := strings.TrimSuffix(w.filename, ext) + "-"
+	var errs []error
+	for i, path := range backups {
+		day := strings.TrimPrefix(path, prefix)[:len(dailyDateFormat)]
+		compressed := strings.HasSuffix(path, ".gz")
+
+		switch {
+		case day < cutoff:
+			errs = append(errs, os.Remove(path))
+		case i >= len(backups)-w.maxBackups || compressed:
+			// Kept as is
+		case w.compress:
+			errs = append(errs, compressFile(path))
+		default:
+			errs = append(errs, os.Remove(path))
+		}
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return fmt.Errorf("failed to clean up daily log files: %w", err)
+		}
+	}
+	return nil
+}
+
+// compressFile gzips path to path.gz and removes the original.
+func compressFile(path string) error {
+	if err := gzipFile(path, path+".gz"); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// gzipFile writes the gzip-compressed content of src to dst.
+func gzipFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0640)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	if _, err := io.Copy(gz, in); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// Close stops the rotation timer and closes the current file.
+func (w *dailyFileWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
@@ -0,0 +1,227 @@
+package log
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// fakeClock is a settable clock for the daily file logger
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// newTestDailyFileLogger creates a daily file logger in a temporary directory starting on 2024-01-31
+func newTestDailyFileLogger(t *testing.T, config *FileLoggerConfig) (*DailyFileLogger, *fakeClock, string) {
+	t.Helper()
+	dir := t.TempDir()
+	config.Filename = filepath.Join(dir, "app.log")
+	config.JsonFormat = true
+
+	clock := &fakeClock{now: time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)}
+	logger, err := newDailyFileLogger(InfoLevel, config, clock.Now)
+	if err != nil {
+		t.Fatalf("Failed to create daily file logger: %v", err)
+	}
+	t.Cleanup(func() { logger.Close() })
+	return logger, clock, dir
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	return string(data)
+}
+
+func TestDailyFileLoggerRotatesAtMidnight(t *testing.T) {
+	logger, clock, dir := newTestDailyFileLogger(t, &FileLoggerConfig{})
+
+	logger.Info("first day")
+
+	// Rotation before midnight keeps the current file
+	clock.Advance(11 * time.Hour)
+	if err := logger.writer.rotate(); err != nil {
+		t.Fatalf("Failed to rotate: %v", err)
+	}
+	logger.WithFields(String("request_id", "123")).Info("still first day")
+
+	clock.Advance(2 * time.Hour)
+	if err := logger.writer.rotate(); err != nil {
+		t.Fatalf("Failed to rotate: %v", err)
+	}
+	logger.Info("second day")
+
+	first := readFile(t, filepath.Join(dir, "app-2024-01-31.log"))
+	if !strings.Contains(first, "first day") || !strings.Contains(first, "still first day") {
+		t.Errorf("Expected both first day messages in the first file, got: %s", first)
+	}
+	second := readFile(t, filepath.Join(dir, "app-2024-02-01.log"))
+	if !strings.Contains(second, "second day") || strings.Contains(second, "first day") {
+		t.Errorf("Expected only the second day message in the second file, got: %s", second)
+	}
+}
+
+func TestDailyFileLoggerRetention(t *testing.T) {
+	testCases := []struct {
+		name     string
+		compress bool
+		expected []string
+	}{
+		{"older files compressed", true, []string{"app-2024-01-31.log.gz", "app-2024-02-01.log.gz", "app-2024-02-02.log", "app-2024-02-03.log"}},
+		{"older files removed", false, []string{"app-2024-02-02.log", "app-2024-02-03.log"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, clock, dir := newTestDailyFileLogger(t, &FileLoggerConfig{MaxBackups: 1, Compress: tc.compress})
+
+			for day := 0; day < 4; day++ {
+				if day > 0 {
+					clock.Advance(24 * time.Hour)
+					if err := logger.writer.rotate(); err != nil {
+						t.Fatalf("Failed to rotate: %v", err)
+					}
+				}
+				logger.Infof("day %d", day)
+			}
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatalf("Failed to read log directory: %v", err)
+			}
+			var names []string
+			for _, entry := range entries {
+				names = append(names, entry.Name())
+			}
+			if strings.Join(names, ",") != strings.Join(tc.expected, ",") {
+				t.Errorf("Expected files %v, got %v", tc.expected, names)
+			}
+		})
+	}
+}
+
+func TestDailyFileLoggerCompressedContent(t *testing.T) {
+	logger, clock, dir := newTestDailyFileLogger(t, &FileLoggerConfig{MaxBackups: 1, Compress: true})
+
+	logger.Info("archived message")
+	for i := 0; i < 2; i++ {
+		clock.Advance(24 * time.Hour)
+		if err := logger.writer.rotate(); err != nil {
+			t.Fatalf("Failed to rotate: %v", err)
+		}
+	}
+
+	file, err := os.Open(filepath.Join(dir, "app-2024-01-31.log.gz"))
+	if err != nil {
+		t.Fatalf("Expected compressed file: %v", err)
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("Failed to open gzip reader: %v", err)
+	}
+	data, _ := io.ReadAll(gz)
+	if !strings.Contains(string(data), "archived message") {
+		t.Errorf("Expected compressed file to contain the message, got: %s", data)
+	}
+}
+
+func TestDailyFileLoggerMaxAge(t *testing.T) {
+	logger, clock, dir := newTestDailyFileLogger(t, &FileLoggerConfig{MaxBackups: 10, MaxAge: 1})
+
+	for i := 0; i < 3; i++ {
+		clock.Advance(24 * time.Hour)
+		if err := logger.writer.rotate(); err != nil {
+			t.Fatalf("Failed to rotate: %v", err)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "app-2024-01-31.log")); !os.IsNotExist(err) {
+		t.Error("Expected file older than max age to be removed")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "app-2024-02-02.log")); err != nil {
+		t.Errorf("Expected recent file to be kept: %v", err)
+	}
+}
+
+func TestDailyFileLoggerClose(t *testing.T) {
+	logger, _, _ := newTestDailyFileLogger(t, &FileLoggerConfig{})
+
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Failed to close: %v", err)
+	}
+	if _, err := logger.writer.Write([]byte("after close")); err == nil {
+		t.Error("Expected write after close to fail")
+	}
+	if err := logger.writer.rotate(); err != nil {
+		t.Errorf("Expected rotate after close to be a no-op, got %v", err)
+	}
+}
+
+func TestFileLoggerRotationStrategy(t *testing.T) {
+	testCases := []struct {
+		strategy    string
+		expectDaily bool
+		expectError bool
+	}{
+		{"", false, false},
+		{"size", false, false},
+		{"daily", true, false},
+		{"weekly", false, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.strategy, func(t *testing.T) {
+			v := viper.New()
+			v.Set("log.loggers.file.driver", "file")
+			v.Set("log.loggers.file.enabled", true)
+			v.Set("log.loggers.file.directory", t.TempDir())
+			v.Set("log.loggers.file.filename", "app.log")
+			v.Set("log.loggers.file.rotation_strategy", tc.strategy)
+
+			logger, err := CreateLoggerFromConfig(v)
+			if tc.expectError {
+				if err == nil {
+					t.Error("Expected an error for an unknown rotation strategy")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to create logger from config: %v", err)
+			}
+
+			switch l := logger.(type) {
+			case *DailyFileLogger:
+				defer l.Close()
+			case *FileLogger:
+				defer l.Close()
+			}
+			if _, ok := logger.(*DailyFileLogger); ok != tc.expectDaily {
+				t.Errorf("Expected daily logger %v, got %T", tc.expectDaily, logger)
+			}
+		})
+	}
+}
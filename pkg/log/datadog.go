@@ -28,11 +28,19 @@ type DatadogLogger struct {
 	config      *DatadogLoggerConfig
 	level       Level
 	contextData map[string]any
-	conn        net.Conn
-	connMutex   sync.RWMutex
+	redact      redactSet
+	conn        *datadogConnection // shared by loggers derived with WithFields/WithContext
 	address     string
 }
 
+// datadogConnection guards the TCP connection shared by a logger and its derived loggers.
+type datadogConnection struct {
+	mu      sync.RWMutex
+	conn    net.Conn
+	address string
+	timeout time.Duration
+}
+
 // DatadogLogEntry represents a log entry in JSON format for Datadog.
 type DatadogLogEntry struct {
 	Timestamp   string                 `json:"timestamp"`
@@ -99,34 +107,74 @@ func NewDatadogLogger(level Level, config *DatadogLoggerConfig) Logger {
 		config:      config,
 		level:       level,
 		contextData: make(map[string]any),
-		address:     address,
+		conn: &datadogConnection{
+			address: address,
+			timeout: time.Duration(config.Timeout) * time.Second,
+		},
+		address: address,
 	}
 }
 
-// ensureConnection ensures we have a valid TCP connection to the Datadog agent.
-func (d *DatadogLogger) ensureConnection() error {
-	d.connMutex.RLock()
-	if d.conn != nil {
-		d.connMutex.RUnlock()
-		return nil
+// get returns the current TCP connection to the Datadog agent, dialing one if needed.
+func (c *datadogConnection) get() (net.Conn, error) {
+	c.mu.RLock()
+	if c.conn != nil {
+		conn := c.conn
+		c.mu.RUnlock()
+		return conn, nil
 	}
-	d.connMutex.RUnlock()
+	c.mu.RUnlock()
 
-	d.connMutex.Lock()
-	defer d.connMutex.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
 	// Double-check after acquiring write lock
-	if d.conn != nil {
-		return nil
+	if c.conn != nil {
+		return c.conn, nil
 	}
 
 	// Create connection with timeout
-	conn, err := net.DialTimeout("tcp", d.address, time.Duration(d.config.Timeout)*time.Second)
+	conn, err := net.DialTimeout("tcp", c.address, c.timeout)
 	if err != nil {
-		return fmt.Errorf("failed to connect to Datadog agent at %s: %w", d.address, err)
+		return nil, fmt.Errorf("failed to connect to Datadog agent at %s: %w", c.address, err)
 	}
 
-	d.conn = conn
+	c.conn = conn
+	return conn, nil
+}
+
+// write sends line over the connection, dropping the connection on failure so the next write reconnects.
+func (c *datadogConnection) write(line []byte) error {
+	conn, err := c.get()
+	if err != nil {
+		return err
+	}
+
+	// Set write deadline to prevent hanging
+	conn.SetWriteDeadline(time.Now().Add(c.timeout))
+
+	if _, err := conn.Write(line); err != nil {
+		c.mu.Lock()
+		if c.conn == conn {
+			c.conn.Close()
+			c.conn = nil
+		}
+		c.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// close closes the current connection; a later write dials a new one.
+func (c *datadogConnection) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn != nil {
+		err := c.conn.Close()
+		c.conn = nil
+		return err
+	}
 	return nil
 }
 
@@ -135,32 +183,9 @@ func (d *DatadogLogger) sendLogEntry(level, message string, fields []Field) {
 	// Build structured log line
 	logLine := d.buildLogLine(level, message, fields)
 
-	// Send asynchronously to avoid blocking
+	// Send asynchronously to avoid blocking; errors are dropped to avoid logging loops
 	go func() {
-		if err := d.ensureConnection(); err != nil {
-			// If we can't connect, silently fail to avoid logging loops
-			return
-		}
-
-		d.connMutex.RLock()
-		conn := d.conn
-		d.connMutex.RUnlock()
-
-		if conn != nil {
-			// Set write deadline to prevent hanging
-			conn.SetWriteDeadline(time.Now().Add(time.Duration(d.config.Timeout) * time.Second))
-
-			_, err := conn.Write([]byte(logLine + "\n"))
-			if err != nil {
-				// Connection failed, close it and next log will try to reconnect
-				d.connMutex.Lock()
-				if d.conn != nil {
-					d.conn.Close()
-					d.conn = nil
-				}
-				d.connMutex.Unlock()
-			}
-		}
+		_ = d.conn.write([]byte(logLine + "\n"))
 	}()
 }
 
@@ -181,12 +206,12 @@ func (d *DatadogLogger) processLogs(timestamp, level, message string, fields []F
 
 	// Add context data
 	for k, v := range d.contextData {
-		allFields[k] = v
+		allFields[k] = resolveValue(d.redact.value(k, v))
 	}
 
 	// Add provided fields
 	for _, field := range fields {
-		allFields[field.Key] = field.Value
+		allFields[field.Key] = resolveValue(d.redact.value(field.Key, field.Value))
 	}
 
 	return &preparedLogData{
@@ -331,6 +356,19 @@ func (d *DatadogLogger) WithFields(fields ...Field) Logger {
 		config:      d.config,
 		level:       d.level,
 		contextData: newContextData,
+		redact:      d.redact,
+		conn:        d.conn, // Share connection
+		address:     d.address,
+	}
+}
+
+// WithRedactedKeys creates a new logger that redacts the values of fields with the given keys.
+func (d *DatadogLogger) WithRedactedKeys(keys ...string) Logger {
+	return &DatadogLogger{
+		config:      d.config,
+		level:       d.level,
+		contextData: d.contextData,
+		redact:      d.redact.with(keys...),
 		conn:        d.conn, // Share connection
 		address:     d.address,
 	}
@@ -342,20 +380,14 @@ func (d *DatadogLogger) WithContext(ctx context.Context) Logger {
 		config:      d.config,
 		level:       d.level,
 		contextData: d.contextData,
+		redact:      d.redact,
 		conn:        d.conn, // Share connection
 		address:     d.address,
 	}
 }
 
 // Close closes the TCP connection to the Datadog agent.
+// The connection is shared with derived loggers, which reconnect on their next log.
 func (d *DatadogLogger) Close() error {
-	d.connMutex.Lock()
-	defer d.connMutex.Unlock()
-
-	if d.conn != nil {
-		err := d.conn.Close()
-		d.conn = nil
-		return err
-	}
-	return nil
+	return d.conn.close()
 }
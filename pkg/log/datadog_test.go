@@ -1,7 +1,9 @@
 package log
 
 import (
+	"bufio"
 	"encoding/json"
+	"net"
 	"testing"
 	"time"
 
@@ -509,3 +511,109 @@ func TestDatadogLoggerProcessLogs(t *testing.T) {
 		t.Errorf("Expected %d fields, got %d", expectedFieldCount, len(data.Fields))
 	}
 }
+
+func TestDatadogLoggerFormattedWithFields(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to start listener: %v", err)
+	}
+	defer listener.Close()
+
+	lines := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		lines <- line
+	}()
+
+	config := &DatadogLoggerConfig{
+		Host:    "127.0.0.1",
+		Port:    listener.Addr().(*net.TCPAddr).Port,
+		Service: "test-service",
+		Timeout: 1,
+	}
+
+	logger := NewDatadogLogger(InfoLevel, config)
+	logger.WithFields(String("request_id", "123")).Infof("hello %s", "world")
+
+	select {
+	case line := <-lines:
+		if !contains(line, "request_id=123") {
+			t.Errorf("Expected log line to contain request_id, got: %s", line)
+		}
+		if !contains(line, `msg="hello world"`) {
+			t.Errorf("Expected log line to contain formatted message, got: %s", line)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for log line")
+	}
+}
+
+func TestDatadogLoggerChildCloseKeepsParentUsable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to start listener: %v", err)
+	}
+	defer listener.Close()
+
+	lines := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					lines <- scanner.Text()
+				}
+			}()
+		}
+	}()
+
+	parent := NewDatadogLogger(InfoLevel, &DatadogLoggerConfig{
+		Host:    "127.0.0.1",
+		Port:    listener.Addr().(*net.TCPAddr).Port,
+		Service: "test-service",
+		Timeout: 1,
+	}).(*DatadogLogger)
+	defer parent.Close()
+
+	child := parent.WithFields(String("component", "child")).(*DatadogLogger)
+	if child.conn != parent.conn {
+		t.Fatal("Expected child to share the parent's connection")
+	}
+
+	child.Info("from child")
+	waitForLine(t, lines, "from child")
+
+	if err := child.Close(); err != nil {
+		t.Fatalf("Close should not error: %v", err)
+	}
+
+	parent.Info("from parent")
+	waitForLine(t, lines, "from parent")
+}
+
+// waitForLine waits until a received line contains want
+func waitForLine(t *testing.T, lines <-chan string, want string) {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case line := <-lines:
+			if contains(line, want) {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("Timed out waiting for log line containing %q", want)
+		}
+	}
+}
@@ -0,0 +1,382 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// ElasticsearchLoggerConfig contains configuration for Elasticsearch logging.
+type ElasticsearchLoggerConfig struct {
+	Addresses     []string      `mapstructure:"addresses"`    // cluster nodes, tried in order until one accepts the batch
+	IndexPrefix   string        `mapstructure:"index_prefix"` // daily indices are named <prefix>-YYYY.MM.DD
+	Username      string        `mapstructure:"username"`
+	Password      string        `mapstructure:"password"`
+	TLSSkipVerify bool          `mapstructure:"tls_skip_verify"`
+	Service       string        `mapstructure:"service"`
+	Environment   string        `mapstructure:"environment"`
+	Source        string        `mapstructure:"source"`
+	Tags          string        `mapstructure:"tags"`
+	BulkSize      int           `mapstructure:"bulk_size"`      // documents buffered before a bulk request
+	FlushInterval time.Duration `mapstructure:"flush_interval"` // maximum time a document stays buffered
+	Timeout       int           `mapstructure:"timeout"`        // timeout in seconds for each bulk request
+}
+
+// ElasticsearchLogger implements Logger interface for Elasticsearch via the _bulk API.
+type ElasticsearchLogger struct {
+	config      *ElasticsearchLoggerConfig
+	level       Level
+	contextData map[string]any
+	redact      redactSet
+	client      *elasticsearchClient // shared by loggers derived with WithFields/WithContext
+}
+
+// ElasticsearchLogDocument is the indexed log document, a DatadogLogEntry with an ISO 8601 @timestamp.
+type ElasticsearchLogDocument struct {
+	AtTimestamp string `json:"@timestamp"`
+	DatadogLogEntry
+}
+
+// elasticsearchClient buffers documents and bulk-indexes them from a background goroutine.
+type elasticsearchClient struct {
+	config     *ElasticsearchLoggerConfig
+	httpClient *http.Client
+	batch      *batcher[ElasticsearchLogDocument]
+	failed     atomic.Uint64 // documents that were not indexed, see FailedCount
+}
+
+// bulkResponse is the part of a _bulk response reporting the documents that were not indexed
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int             `json:"status"`
+		Error  json.RawMessage `json:"error"`
+	} `json:"items"`
+}
+
+func init() {
+	RegisterFactory("elasticsearch", NewElasticsearchLoggerFromConfig)
+}
+
+// NewElasticsearchLoggerFromConfig creates a new Elasticsearch logger from a Viper configuration.
+func NewElasticsearchLoggerFromConfig(level Level, v *viper.Viper) (Logger, error) {
+	var config ElasticsearchLoggerConfig
+	if err := v.Unmarshal(&config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal elasticsearch logger config: %w", err)
+	}
+	if len(config.Addresses) == 0 {
+		return nil, fmt.Errorf("elasticsearch logger requires at least one address")
+	}
+
+	return NewElasticsearchLogger(level, &config), nil
+}
+
+// NewElasticsearchLogger creates a new Elasticsearch logger and starts its flush goroutine.
+// Call Close to index pending documents.
+func NewElasticsearchLogger(level Level, config *ElasticsearchLoggerConfig) Logger {
+	// Set defaults
+	if config.IndexPrefix == "" {
+		config.IndexPrefix = "scaffold-logs"
+	}
+	if config.Service == "" {
+		config.Service = "scaffold"
+	}
+	if config.Environment == "" {
+		config.Environment = "development"
+	}
+	if config.Source == "" {
+		config.Source = "go"
+	}
+	if config.BulkSize <= 0 {
+		config.BulkSize = 500
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = 5 * time.Second
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 5 // 5 seconds default timeout
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.TLSSkipVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} // Opt-in for self-signed clusters
+	}
+
+	client := &elasticsearchClient{
+		config: config,
+		httpClient: &http.Client{
+			Timeout:   time.Duration(config.Timeout) * time.Second,
+			Transport: transport,
+		},
+	}
+	client.batch = newBatcher(config.BulkSize, config.FlushInterval, client.indexDocuments)
+
+	return &ElasticsearchLogger{
+		config:      config,
+		level:       level,
+		contextData: make(map[string]any),
+		client:      client,
+	}
+}
+
+// indexDocuments bulk-indexes a batch; a batch is dropped when no node accepts it to avoid unbounded growth.
+// Errors are not logged to avoid logging loops, the documents that were not indexed are counted instead.
+func (c *elasticsearchClient) indexDocuments(documents []ElasticsearchLogDocument) {
+	body, err := c.buildBulkBody(documents)
+	if err != nil {
+		c.failed.Add(uint64(len(documents)))
+		return
+	}
+	rejected, err := c.bulk(body)
+	if err != nil {
+		rejected = len(documents)
+	}
+	c.failed.Add(uint64(rejected))
+}
+
+// buildBulkBody encodes documents as the newline-delimited action/document pairs of the _bulk API.
+func (c *elasticsearchClient) buildBulkBody(documents []ElasticsearchLogDocument) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+
+	for _, document := range documents {
+		// The document date in UTC, e.g. scaffold-logs-2024.01.31
+		day := strings.ReplaceAll(document.AtTimestamp[:len("2006-01-02")], "-", ".")
+		action := map[string]map[string]string{"index": {"_index": c.config.IndexPrefix + "-" + day}}
+		if err := encoder.Encode(action); err != nil {
+			return nil, err
+		}
+		if err := encoder.Encode(document); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// bulk sends body to the first address that accepts it and returns the number of documents it rejected,
+// which a 200 response reports with "errors": true and the error of each failed item.
+func (c *elasticsearchClient) bulk(body []byte) (int, error) {
+	var lastErr error
+	for _, address := range c.config.Addresses {
+		url := strings.TrimRight(address, "/") + "/_bulk"
+
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			lastErr = fmt.Errorf("failed to create bulk request for %s: %w", url, err)
+			continue
+		}
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		if c.config.Username != "" {
+			req.SetBasicAuth(c.config.Username, c.config.Password)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to send bulk request to %s: %w", url, err)
+			continue
+		}
+		if resp.StatusCode < 400 {
+			rejected := countRejectedItems(resp.Body)
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			return rejected, nil
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode < 500 {
+			return 0, fmt.Errorf("bulk request to %s rejected with status %d", url, resp.StatusCode)
+		}
+		// Try the next node
+		lastErr = fmt.Errorf("bulk request to %s failed with status %d", url, resp.StatusCode)
+	}
+	return 0, lastErr
+}
+
+// countRejectedItems returns the number of failed items of a _bulk response body, 0 when it cannot be decoded
+func countRejectedItems(body io.Reader) int {
+	var response bulkResponse
+	if err := json.NewDecoder(body).Decode(&response); err != nil || !response.Errors {
+		return 0
+	}
+	rejected := 0
+	for _, item := range response.Items {
+		for _, result := range item {
+			if len(result.Error) > 0 || result.Status >= 300 {
+				rejected++
+			}
+		}
+	}
+	return rejected
+}
+
+// log builds and buffers a document if level is enabled.
+func (l *ElasticsearchLogger) log(level Level, message string, fields []Field) {
+	if !levelEnabled(l.level, level) {
+		return
+	}
+	l.client.batch.add(l.buildDocument(strings.ToUpper(string(level)), message, fields))
+}
+
+// buildDocument creates the log document with the context data and fields.
+func (l *ElasticsearchLogger) buildDocument(level, message string, fields []Field) ElasticsearchLogDocument {
+	now := time.Now().UTC()
+
+	allFields := make(map[string]interface{}, len(l.contextData)+len(fields))
+	for k, v := range l.contextData {
+		allFields[k] = resolveValue(l.redact.value(k, v))
+	}
+	for _, field := range fields {
+		allFields[field.Key] = resolveValue(l.redact.value(field.Key, field.Value))
+	}
+	for k, v := range allFields {
+		if err, ok := v.(error); ok {
+			if msg, ok := errorMessage(err); ok {
+				allFields[k] = msg
+			} else {
+				allFields[k] = nil
+			}
+		}
+	}
+	if len(allFields) == 0 {
+		allFields = nil
+	}
+
+	return ElasticsearchLogDocument{
+		AtTimestamp: now.Format(time.RFC3339Nano),
+		DatadogLogEntry: DatadogLogEntry{
+			Timestamp:   now.Format(time.RFC3339),
+			Level:       level,
+			Message:     message,
+			Service:     l.config.Service,
+			Environment: l.config.Environment,
+			Source:      l.config.Source,
+			Tags:        l.config.Tags,
+			Fields:      allFields,
+		},
+	}
+}
+
+// Debug logs a debug message.
+func (l *ElasticsearchLogger) Debug(msg string, fields ...Field) {
+	l.log(DebugLevel, msg, fields)
+}
+
+// Info logs an info message.
+func (l *ElasticsearchLogger) Info(msg string, fields ...Field) {
+	l.log(InfoLevel, msg, fields)
+}
+
+// Warn logs a warning message.
+func (l *ElasticsearchLogger) Warn(msg string, fields ...Field) {
+	l.log(WarnLevel, msg, fields)
+}
+
+// Error logs an error message.
+func (l *ElasticsearchLogger) Error(msg string, fields ...Field) {
+	l.log(ErrorLevel, msg, fields)
+}
+
+// Fatal logs a fatal message and flushes the buffer, since the process is usually about to exit.
+func (l *ElasticsearchLogger) Fatal(msg string, fields ...Field) {
+	l.log(FatalLevel, msg, fields)
+	l.client.batch.flushNow()
+}
+
+// Panic logs a panic message and flushes the buffer.
+func (l *ElasticsearchLogger) Panic(msg string, fields ...Field) {
+	l.log(PanicLevel, msg, fields)
+	l.client.batch.flushNow()
+}
+
+// Formatted logging methods
+func (l *ElasticsearchLogger) Debugf(format string, args ...interface{}) {
+	l.Debug(fmt.Sprintf(format, args...))
+}
+
+func (l *ElasticsearchLogger) Infof(format string, args ...interface{}) {
+	l.Info(fmt.Sprintf(format, args...))
+}
+
+func (l *ElasticsearchLogger) Warnf(format string, args ...interface{}) {
+	l.Warn(fmt.Sprintf(format, args...))
+}
+
+func (l *ElasticsearchLogger) Errorf(format string, args ...interface{}) {
+	l.Error(fmt.Sprintf(format, args...))
+}
+
+func (l *ElasticsearchLogger) Fatalf(format string, args ...interface{}) {
+	l.Fatal(fmt.Sprintf(format, args...))
+}
+
+func (l *ElasticsearchLogger) Panicf(format string, args ...interface{}) {
+	l.Panic(fmt.Sprintf(format, args...))
+}
+
+// WithFields creates a new logger with additional context fields.
+func (l *ElasticsearchLogger) WithFields(fields ...Field) Logger {
+	newContextData := make(map[string]any, len(l.contextData)+len(fields))
+
+	// Copy existing context data
+	for k, v := range l.contextData {
+		newContextData[k] = v
+	}
+
+	// Add new fields
+	for _, field := range fields {
+		newContextData[field.Key] = field.Value
+	}
+
+	return &ElasticsearchLogger{
+		config:      l.config,
+		level:       l.level,
+		contextData: newContextData,
+		redact:      l.redact,
+		client:      l.client, // Share buffer
+	}
+}
+
+// WithRedactedKeys creates a new logger that redacts the values of fields with the given keys.
+func (l *ElasticsearchLogger) WithRedactedKeys(keys ...string) Logger {
+	return &ElasticsearchLogger{
+		config:      l.config,
+		level:       l.level,
+		contextData: l.contextData,
+		redact:      l.redact.with(keys...),
+		client:      l.client, // Share buffer
+	}
+}
+
+// WithContext creates a new logger with context.
+func (l *ElasticsearchLogger) WithContext(ctx context.Context) Logger {
+	return &ElasticsearchLogger{
+		config:      l.config,
+		level:       l.level,
+		contextData: l.contextData,
+		redact:      l.redact,
+		client:      l.client, // Share buffer
+	}
+}
+
+// FailedCount returns the number of documents that were not indexed, because no node accepted their
+// bulk request or the accepting node rejected them individually.
+func (l *ElasticsearchLogger) FailedCount() uint64 {
+	return l.client.failed.Load()
+}
+
+// Close stops the background flush and indexes any buffered documents.
+// The buffer is shared with derived loggers, which must not be used afterwards.
+func (l *ElasticsearchLogger) Close() error {
+	l.client.batch.close()
+	return nil
+}
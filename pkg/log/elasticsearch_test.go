@@ -0,0 +1,244 @@
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// bulkRequest is a request received by the mock Elasticsearch node
+type bulkRequest struct {
+	lines    []string
+	username string
+	password string
+}
+
+// newMockElasticsearch records _bulk requests and answers with status
+func newMockElasticsearch(t *testing.T, status int) (*httptest.Server, func() []bulkRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []bulkRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_bulk" || r.Header.Get("Content-Type") != "application/x-ndjson" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		request := bulkRequest{}
+		request.username, request.password, _ = r.BasicAuth()
+		scanner := bufio.NewScanner(bytes.NewReader(body))
+		for scanner.Scan() {
+			request.lines = append(request.lines, scanner.Text())
+		}
+
+		mu.Lock()
+		requests = append(requests, request)
+		mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []bulkRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]bulkRequest(nil), requests...)
+	}
+}
+
+func TestElasticsearchLoggerBulkFormat(t *testing.T) {
+	server, requests := newMockElasticsearch(t, http.StatusOK)
+	logger := NewElasticsearchLogger(InfoLevel, &ElasticsearchLoggerConfig{
+		Addresses:     []string{server.URL},
+		IndexPrefix:   "app-logs",
+		Username:      "elastic",
+		Password:      "changeme",
+		Service:       "test-service",
+		Environment:   "test",
+		BulkSize:      100,
+		FlushInterval: time.Hour,
+	}).(*ElasticsearchLogger)
+
+	logger.WithFields(String("request_id", "123")).Info("hello")
+	logger.Debug("filtered by level")
+	logger.Close()
+
+	received := requests()
+	if len(received) != 1 {
+		t.Fatalf("Expected 1 bulk request, got %d", len(received))
+	}
+	if received[0].username != "elastic" || received[0].password != "changeme" {
+		t.Errorf("Expected basic auth credentials, got %q:%q", received[0].username, received[0].password)
+	}
+	if len(received[0].lines) != 2 {
+		t.Fatalf("Expected one action and one document line, got %d lines", len(received[0].lines))
+	}
+
+	var action map[string]map[string]string
+	if err := json.Unmarshal([]byte(received[0].lines[0]), &action); err != nil {
+		t.Fatalf("Failed to parse action line: %v", err)
+	}
+	expectedIndex := "app-logs-" + time.Now().UTC().Format("2006.01.02")
+	if action["index"]["_index"] != expectedIndex {
+		t.Errorf("Expected index %s, got %v", expectedIndex, action)
+	}
+
+	var document map[string]any
+	if err := json.Unmarshal([]byte(received[0].lines[1]), &document); err != nil {
+		t.Fatalf("Failed to parse document line: %v", err)
+	}
+	if _, err := time.Parse(time.RFC3339Nano, document["@timestamp"].(string)); err != nil {
+		t.Errorf("Expected ISO 8601 @timestamp, got %v", document["@timestamp"])
+	}
+	expected := map[string]any{"level": "INFO", "message": "hello", "service": "test-service", "environment": "test", "source": "go"}
+	for k, v := range expected {
+		if document[k] != v {
+			t.Errorf("Expected %s=%v, got %v", k, v, document[k])
+		}
+	}
+	if _, ok := document["timestamp"]; !ok {
+		t.Error("Expected the DatadogLogEntry timestamp field")
+	}
+	if fields, _ := document["fields"].(map[string]any); fields["request_id"] != "123" {
+		t.Errorf("Expected request_id field, got %v", document["fields"])
+	}
+}
+
+func TestElasticsearchLoggerBulkSizeAndInterval(t *testing.T) {
+	testCases := []struct {
+		name          string
+		bulkSize      int
+		flushInterval time.Duration
+		messages      int
+	}{
+		{"bulk size reached", 2, time.Hour, 2},
+		{"flush interval elapsed", 100, 20 * time.Millisecond, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server, requests := newMockElasticsearch(t, http.StatusOK)
+			logger := NewElasticsearchLogger(InfoLevel, &ElasticsearchLoggerConfig{
+				Addresses:     []string{server.URL},
+				BulkSize:      tc.bulkSize,
+				FlushInterval: tc.flushInterval,
+			}).(*ElasticsearchLogger)
+			defer logger.Close()
+
+			for i := 0; i < tc.messages; i++ {
+				logger.Info("batched")
+			}
+
+			deadline := time.Now().Add(2 * time.Second)
+			for len(requests()) == 0 {
+				if time.Now().After(deadline) {
+					t.Fatal("Timed out waiting for bulk request")
+				}
+				time.Sleep(5 * time.Millisecond)
+			}
+			if got := len(requests()[0].lines); got != 2*tc.messages {
+				t.Errorf("Expected %d lines, got %d", 2*tc.messages, got)
+			}
+		})
+	}
+}
+
+func TestElasticsearchLoggerFailover(t *testing.T) {
+	unavailable, unavailableRequests := newMockElasticsearch(t, http.StatusServiceUnavailable)
+	healthy, healthyRequests := newMockElasticsearch(t, http.StatusOK)
+
+	logger := NewElasticsearchLogger(InfoLevel, &ElasticsearchLoggerConfig{
+		Addresses:     []string{unavailable.URL, healthy.URL},
+		BulkSize:      100,
+		FlushInterval: time.Hour,
+	}).(*ElasticsearchLogger)
+
+	logger.Error("failover")
+	logger.Close()
+
+	if len(unavailableRequests()) != 1 || len(healthyRequests()) != 1 {
+		t.Errorf("Expected the batch to be retried on the next node, got %d and %d requests",
+			len(unavailableRequests()), len(healthyRequests()))
+	}
+}
+
+func TestElasticsearchLoggerFailedCount(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   int
+		body     string
+		expected uint64
+	}{
+		{"all indexed", http.StatusOK, `{"errors":false,"items":[{"index":{"status":201}},{"index":{"status":201}}]}`, 0},
+		{"item rejected", http.StatusOK, `{"errors":true,"items":[{"index":{"status":201}},{"index":{"status":400,"error":{"type":"mapper_parsing_exception"}}}]}`, 1},
+		{"request rejected", http.StatusBadRequest, "", 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.Copy(io.Discard, r.Body)
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			logger := NewElasticsearchLogger(InfoLevel, &ElasticsearchLoggerConfig{
+				Addresses:     []string{server.URL},
+				BulkSize:      100,
+				FlushInterval: time.Hour,
+			}).(*ElasticsearchLogger)
+
+			logger.Info("first")
+			logger.Info("second")
+			logger.Close()
+
+			if got := logger.FailedCount(); got != tc.expected {
+				t.Errorf("Expected %d failed documents, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestElasticsearchLoggerRegistration(t *testing.T) {
+	server, _ := newMockElasticsearch(t, http.StatusOK)
+
+	v := viper.New()
+	v.Set("log.level", "info")
+	v.Set("log.loggers.es.driver", "elasticsearch")
+	v.Set("log.loggers.es.enabled", true)
+	v.Set("log.loggers.es.addresses", []string{server.URL})
+	v.Set("log.loggers.es.index_prefix", "scaffold")
+	v.Set("log.loggers.es.bulk_size", 50)
+	v.Set("log.loggers.es.flush_interval", "2s")
+	v.Set("log.loggers.es.tls_skip_verify", true)
+
+	logger, err := CreateLoggerFromConfig(v)
+	if err != nil {
+		t.Fatalf("Failed to create logger from config: %v", err)
+	}
+	esLogger, ok := logger.(*ElasticsearchLogger)
+	if !ok {
+		t.Fatalf("Expected *ElasticsearchLogger, got %T", logger)
+	}
+	defer esLogger.Close()
+
+	if esLogger.config.BulkSize != 50 || esLogger.config.FlushInterval != 2*time.Second || !esLogger.config.TLSSkipVerify {
+		t.Errorf("Expected config to be unmarshalled, got %+v", esLogger.config)
+	}
+
+	// At least one address is required
+	v.Set("log.loggers.es.addresses", []string{})
+	if _, err := CreateLoggerFromConfig(v); err == nil {
+		t.Error("Expected an error without addresses")
+	}
+}
@@ -1,11 +1,17 @@
 package log
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/spf13/viper"
 )
 
+// ErrPartialLoggerInit is wrapped by CreateLoggerFromConfig when some configured loggers failed
+// but the others were created; the returned logger is usable.
+var ErrPartialLoggerInit = errors.New("some loggers failed to initialize")
+
 // LoggerFactory defines the signature for functions that can create a new logger.
 type LoggerFactory func(level Level, config *viper.Viper) (Logger, error)
 
@@ -25,8 +31,24 @@ func RegisterFactory(name string, factory LoggerFactory) {
 	loggerFactories[name] = factory
 }
 
+// MustCreateLoggerFromConfig creates the logger of the configuration for the commands' startup.
+// It panics when no logger could be created; when only some failed, the error is logged and the others are used.
+func MustCreateLoggerFromConfig(v *viper.Viper) Logger {
+	logger, err := CreateLoggerFromConfig(v)
+	if err != nil && !errors.Is(err, ErrPartialLoggerInit) {
+		panic(fmt.Sprintf("failed to create logger: %v", err))
+	}
+	if err != nil {
+		// Keep running with the loggers that could be created
+		logger.Error("Failed to create some loggers", Error(err))
+	}
+	return logger
+}
+
 // CreateLoggerFromConfig creates a logger instance based on the provided Viper configuration.
 // It can create a single logger or a multi-logger if multiple outputs are configured.
+// Every enabled logger is attempted and all failures are returned together; if at least one
+// logger was created it is returned along with an error wrapping ErrPartialLoggerInit.
 func CreateLoggerFromConfig(v *viper.Viper) (Logger, error) {
 	if v == nil {
 		// Default to a simple console logger if no configuration is provided.
@@ -45,25 +67,42 @@ func CreateLoggerFromConfig(v *viper.Viper) (Logger, error) {
 	allLoggers := loggerBackend.AllSettings()
 	loggers := make([]Logger, 0, len(allLoggers))
 
+	// Sorted so errors are reported in a stable order
+	keys := make([]string, 0, len(allLoggers))
 	for key := range allLoggers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
 		loggerConfig := loggerBackend.Sub(key)
-		if !loggerConfig.GetBool("enabled") {
+		if loggerConfig == nil || !loggerConfig.GetBool("enabled") {
 			continue
 		}
 
 		driver := loggerConfig.GetString("driver")
 		factory, ok := loggerFactories[driver]
 		if !ok {
-			return nil, fmt.Errorf("logger driver %s not found", driver)
+			errs = append(errs, fmt.Errorf("logger %s: driver %s not found", key, driver))
+			continue
 		}
 
 		logger, err := factory(level, loggerConfig)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create logger %s: %w", key, err)
+			errs = append(errs, fmt.Errorf("failed to create logger %s: %w", key, err))
+			continue
 		}
 		loggers = append(loggers, logger)
 	}
 
+	if len(errs) > 0 {
+		if len(loggers) == 0 {
+			return nil, errors.Join(errs...)
+		}
+		errs = append([]error{ErrPartialLoggerInit}, errs...)
+	}
+
 	// Shrink slice to fit the actual number of enabled loggers.
 	if len(loggers) < cap(loggers) {
 		_loggers := make([]Logger, len(loggers))
@@ -77,9 +116,9 @@ func CreateLoggerFromConfig(v *viper.Viper) (Logger, error) {
 		// If no loggers are enabled, default to a console logger.
 		return NewConsoleLogger(level), nil
 	case 1:
-		return loggers[0], nil
+		return loggers[0], errors.Join(errs...)
 	default:
-		return NewMultiLogger(loggers...), nil
+		return NewMultiLogger(loggers...), errors.Join(errs...)
 	}
 }
 
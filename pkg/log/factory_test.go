@@ -0,0 +1,118 @@
+package log
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// blockedDirectory returns a log directory that cannot be created because its parent is a file
+func blockedDirectory(t *testing.T) string {
+	t.Helper()
+	parent := filepath.Join(t.TempDir(), "not-a-directory")
+	if err := os.WriteFile(parent, []byte("x"), 0o644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	return filepath.Join(parent, "logs")
+}
+
+func TestCreateLoggerFromConfigReportsAllErrors(t *testing.T) {
+	v := viper.New()
+	v.Set("log.level", "info")
+	v.Set("log.loggers.console.driver", "console")
+	v.Set("log.loggers.console.enabled", true)
+	v.Set("log.loggers.broken_driver.driver", "logdna")
+	v.Set("log.loggers.broken_driver.enabled", true)
+	v.Set("log.loggers.broken_file.driver", "file")
+	v.Set("log.loggers.broken_file.enabled", true)
+	v.Set("log.loggers.broken_file.directory", blockedDirectory(t))
+	v.Set("log.loggers.broken_file.filename", "app.log")
+
+	logger, err := CreateLoggerFromConfig(v)
+	if err == nil {
+		t.Fatal("Expected an error for the misconfigured loggers")
+	}
+	if !errors.Is(err, ErrPartialLoggerInit) {
+		t.Errorf("Expected error to wrap ErrPartialLoggerInit, got %v", err)
+	}
+	for _, want := range []string{"broken_driver", "driver logdna not found", "failed to create logger broken_file"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got: %v", want, err)
+		}
+	}
+
+	// The console logger was created and is still usable
+	if _, ok := logger.(*ConsoleLogger); !ok {
+		t.Errorf("Expected the working console logger, got %T", logger)
+	}
+}
+
+func TestCreateLoggerFromConfigAllFailed(t *testing.T) {
+	v := viper.New()
+	v.Set("log.loggers.first.driver", "missing-one")
+	v.Set("log.loggers.first.enabled", true)
+	v.Set("log.loggers.second.driver", "missing-two")
+	v.Set("log.loggers.second.enabled", true)
+
+	logger, err := CreateLoggerFromConfig(v)
+	if err == nil {
+		t.Fatal("Expected an error when no logger could be created")
+	}
+	if errors.Is(err, ErrPartialLoggerInit) {
+		t.Error("Expected a total failure not to be reported as partial")
+	}
+	if logger != nil {
+		t.Errorf("Expected no logger, got %T", logger)
+	}
+	for _, want := range []string{"missing-one", "missing-two"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got: %v", want, err)
+		}
+	}
+}
+
+func TestCreateLoggerFromConfigSkipsDisabled(t *testing.T) {
+	v := viper.New()
+	v.Set("log.loggers.console.driver", "console")
+	v.Set("log.loggers.console.enabled", true)
+	v.Set("log.loggers.disabled.driver", "missing")
+	v.Set("log.loggers.disabled.enabled", false)
+
+	if _, err := CreateLoggerFromConfig(v); err != nil {
+		t.Errorf("Expected disabled loggers to be ignored, got %v", err)
+	}
+}
+
+func TestMustCreateLoggerFromConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		drivers     map[string]string
+		expectPanic bool
+	}{
+		{"partial failure", map[string]string{"console": "console", "broken": "missing-one"}, false},
+		{"all failed", map[string]string{"broken": "missing-one"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := viper.New()
+			for name, driver := range tc.drivers {
+				v.Set("log.loggers."+name+".driver", driver)
+				v.Set("log.loggers."+name+".enabled", true)
+			}
+
+			defer func() {
+				if r := recover(); (r != nil) != tc.expectPanic {
+					t.Errorf("Expected panic %v, got %v", tc.expectPanic, r)
+				}
+			}()
+			if logger := MustCreateLoggerFromConfig(v); logger == nil {
+				t.Error("Expected the loggers that could be created")
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package log
 import (
 	"context"
 	"fmt"
+	"io"
 	"path/filepath"
 	"time"
 
@@ -22,6 +23,8 @@ type FileLoggerConfig struct {
 	MaxAge     int    `mapstructure:"max_age"`     // days
 	Compress   bool   `mapstructure:"compress"`    // compress rotated files
 	JsonFormat bool   `mapstructure:"json_format"` // use JSON format
+
+	RotationStrategy string `mapstructure:"rotation_strategy"` // "size" (default) or "daily", see DailyFileLogger
 }
 
 // FileLogger implements Logger interface for file output with rotation.
@@ -29,8 +32,11 @@ type FileLogger struct {
 	logger      zerolog.Logger
 	level       Level
 	contextData map[string]any
-	lumberjack  *lumberjack.Logger
+	redact      redactSet
+	levels      *levelState    // shared by loggers derived from this one, see SetLevel
+	output      io.WriteCloser // lumberjack for size-based rotation, or a dailyFileWriter
 	config      *FileLoggerConfig
+	callerSkip  int // extra frames skipped when reporting the caller, see WithCallerSkip
 }
 
 func init() {
@@ -57,9 +63,18 @@ func NewFileLoggerFromConfig(level Level, v *viper.Viper) (Logger, error) {
 		MaxAge:     config.MaxAge,
 		Compress:   config.Compress,
 		JsonFormat: config.JsonFormat,
+
+		RotationStrategy: config.RotationStrategy,
 	}
 
-	return NewFileLogger(level, fileLoggerConfig), nil
+	switch config.RotationStrategy {
+	case "", "size":
+		return NewFileLogger(level, fileLoggerConfig), nil
+	case "daily":
+		return NewDailyFileLogger(level, fileLoggerConfig)
+	default:
+		return nil, fmt.Errorf("unknown rotation strategy %q, expected size or daily", config.RotationStrategy)
+	}
 }
 
 // NewFileLogger creates a new file logger with rotation.
@@ -83,99 +98,132 @@ func NewFileLogger(level Level, config *FileLoggerConfig) Logger {
 		Compress:   config.Compress,
 	}
 
+	return newFileLoggerWithOutput(level, config, lj)
+}
+
+// newFileLoggerWithOutput creates a file logger writing to output, which is closed by Close.
+func newFileLoggerWithOutput(level Level, config *FileLoggerConfig, output io.WriteCloser) *FileLogger {
 	// Configure zerolog
 	zerolog.TimeFieldFormat = time.RFC3339Nano
-	zerolog.SetGlobalLevel(parseLogLevel(string(level)))
-
-	var logger zerolog.Logger
-	if config.JsonFormat {
-		logger = zerolog.New(lj).With().Timestamp().Caller().Logger()
-	} else {
-		logger = zerolog.New(zerolog.ConsoleWriter{Out: lj, NoColor: true}).With().Timestamp().Caller().Logger()
-	}
+	HotReloadLevel(string(level))
 
 	return &FileLogger{
-		logger:      logger,
+		logger:      newFileZerolog(output, config.JsonFormat, 0),
 		level:       level,
 		contextData: make(map[string]any),
-		lumberjack:  lj,
+		levels:      newLevelState(),
+		output:      output,
 		config:      config,
 	}
 }
 
+// newFileZerolog builds the zerolog logger, reporting the caller callerSkip frames above the log call.
+func newFileZerolog(output io.Writer, jsonFormat bool, callerSkip int) zerolog.Logger {
+	return newFileZerologContext(output, jsonFormat).CallerWithSkipFrameCount(callerSkipFrameCount(callerSkip)).Logger()
+}
+
+// newFileZerologContext starts the zerolog logger without a caller.
+func newFileZerologContext(output io.Writer, jsonFormat bool) zerolog.Context {
+	writer := output
+	if !jsonFormat {
+		writer = zerolog.ConsoleWriter{Out: output, NoColor: true}
+	}
+	return zerolog.New(writer).With().Timestamp()
+}
+
+// event starts a zerolog event at level, or returns nil when the level is filtered out.
+func (l *FileLogger) event(level zerolog.Level) *zerolog.Event {
+	return newLevelEvent(&l.logger, l.levels, level)
+}
+
+// SetLevel changes the minimum level of this logger and the loggers derived from it.
+func (l *FileLogger) SetLevel(level Level) {
+	l.levels.set(level)
+}
+
+// GetLevel returns the current minimum level.
+func (l *FileLogger) GetLevel() Level {
+	return l.levels.level()
+}
+
 // addFields adds fields to the zerolog event.
 func (l *FileLogger) addFields(event *zerolog.Event, fields []Field) *zerolog.Event {
+	// Disabled levels return a nil event, skip field evaluation entirely
+	if event == nil {
+		return event
+	}
+
 	// Add context data first
 	for k, v := range l.contextData {
-		event = event.Interface(k, v)
+		event = appendField(event, k, l.redact.value(k, v))
 	}
 
 	// Add provided fields
 	for _, field := range fields {
-		event = event.Interface(field.Key, field.Value)
+		event = appendField(event, field.Key, l.redact.value(field.Key, field.Value))
 	}
 	return event
 }
 
 // Debug logs a debug message.
 func (l *FileLogger) Debug(msg string, fields ...Field) {
-	event := l.logger.Debug()
+	event := l.event(zerolog.DebugLevel)
 	l.addFields(event, fields).Msg(msg)
 }
 
 // Info logs an info message.
 func (l *FileLogger) Info(msg string, fields ...Field) {
-	event := l.logger.Info()
+	event := l.event(zerolog.InfoLevel)
 	l.addFields(event, fields).Msg(msg)
 }
 
 // Warn logs a warning message.
 func (l *FileLogger) Warn(msg string, fields ...Field) {
-	event := l.logger.Warn()
+	event := l.event(zerolog.WarnLevel)
 	l.addFields(event, fields).Msg(msg)
 }
 
 // Error logs an error message.
 func (l *FileLogger) Error(msg string, fields ...Field) {
-	event := l.logger.Error()
+	event := l.event(zerolog.ErrorLevel)
 	l.addFields(event, fields).Msg(msg)
 }
 
 // Fatal logs a fatal message and exits.
 func (l *FileLogger) Fatal(msg string, fields ...Field) {
-	event := l.logger.Fatal()
+	event := l.event(zerolog.FatalLevel)
 	l.addFields(event, fields).Msg(msg)
 }
 
 // Panic logs a panic message and panics.
 func (l *FileLogger) Panic(msg string, fields ...Field) {
-	event := l.logger.Panic()
+	event := l.event(zerolog.PanicLevel)
 	l.addFields(event, fields).Msg(msg)
 }
 
 // Formatted logging methods
 func (l *FileLogger) Debugf(format string, args ...interface{}) {
-	l.logger.Debug().Msg(fmt.Sprintf(format, args...))
+	l.addFields(l.event(zerolog.DebugLevel), nil).Msg(fmt.Sprintf(format, args...))
 }
 
 func (l *FileLogger) Infof(format string, args ...interface{}) {
-	l.logger.Info().Msg(fmt.Sprintf(format, args...))
+	l.addFields(l.event(zerolog.InfoLevel), nil).Msg(fmt.Sprintf(format, args...))
 }
 
 func (l *FileLogger) Warnf(format string, args ...interface{}) {
-	l.logger.Warn().Msg(fmt.Sprintf(format, args...))
+	l.addFields(l.event(zerolog.WarnLevel), nil).Msg(fmt.Sprintf(format, args...))
 }
 
 func (l *FileLogger) Errorf(format string, args ...interface{}) {
-	l.logger.Error().Msg(fmt.Sprintf(format, args...))
+	l.addFields(l.event(zerolog.ErrorLevel), nil).Msg(fmt.Sprintf(format, args...))
 }
 
 func (l *FileLogger) Fatalf(format string, args ...interface{}) {
-	l.logger.Fatal().Msg(fmt.Sprintf(format, args...))
+	l.addFields(l.event(zerolog.FatalLevel), nil).Msg(fmt.Sprintf(format, args...))
 }
 
 func (l *FileLogger) Panicf(format string, args ...interface{}) {
-	l.logger.Panic().Msg(fmt.Sprintf(format, args...))
+	l.addFields(l.event(zerolog.PanicLevel), nil).Msg(fmt.Sprintf(format, args...))
 }
 
 // WithFields creates a new logger with additional context fields.
@@ -196,8 +244,55 @@ func (l *FileLogger) WithFields(fields ...Field) Logger {
 		logger:      l.logger,
 		level:       l.level,
 		contextData: newContextData,
-		lumberjack:  l.lumberjack,
+		redact:      l.redact,
+		levels:      l.levels,
+		output:      l.output,
+		config:      l.config,
+		callerSkip:  l.callerSkip,
+	}
+}
+
+// WithCallerSkip creates a new logger that reports the caller n frames further up the stack.
+// Skips add up across calls and are kept by WithFields, WithContext and WithRedactedKeys.
+func (l *FileLogger) WithCallerSkip(n int) Logger {
+	callerSkip := l.callerSkip + n
+	return &FileLogger{
+		logger:      newFileZerolog(l.output, l.config.JsonFormat, callerSkip),
+		level:       l.level,
+		contextData: l.contextData,
+		redact:      l.redact,
+		levels:      l.levels,
+		output:      l.output,
+		config:      l.config,
+		callerSkip:  callerSkip,
+	}
+}
+
+// withCaller creates a new logger that reports caller instead of the code calling its methods.
+func (l *FileLogger) withCaller(caller string) Logger {
+	return &FileLogger{
+		logger:      newFileZerologContext(l.output, l.config.JsonFormat).Str(zerolog.CallerFieldName, caller).Logger(),
+		level:       l.level,
+		contextData: l.contextData,
+		redact:      l.redact,
+		levels:      l.levels,
+		output:      l.output,
+		config:      l.config,
+		callerSkip:  l.callerSkip,
+	}
+}
+
+// WithRedactedKeys creates a new logger that redacts the values of fields with the given keys.
+func (l *FileLogger) WithRedactedKeys(keys ...string) Logger {
+	return &FileLogger{
+		logger:      l.logger,
+		level:       l.level,
+		contextData: l.contextData,
+		redact:      l.redact.with(keys...),
+		levels:      l.levels,
+		output:      l.output,
 		config:      l.config,
+		callerSkip:  l.callerSkip,
 	}
 }
 
@@ -208,12 +303,15 @@ func (l *FileLogger) WithContext(ctx context.Context) Logger {
 		logger:      l.logger,
 		level:       l.level,
 		contextData: l.contextData,
-		lumberjack:  l.lumberjack,
+		redact:      l.redact,
+		levels:      l.levels,
+		output:      l.output,
 		config:      l.config,
+		callerSkip:  l.callerSkip,
 	}
 }
 
 // Close closes the file logger and flushes any remaining logs.
 func (l *FileLogger) Close() error {
-	return l.lumberjack.Close()
+	return l.output.Close()
 }
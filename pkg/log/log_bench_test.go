@@ -0,0 +1,236 @@
+package log
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+)
+
+// Benchmarks run with b.RunParallel; GOMAXPROCS is set with -cpu (e.g. -cpu 1,4,8) and
+// LOG_BENCH_PARALLELISM sets the goroutines per proc.
+//
+//	go test ./pkg/log -run '^$' -bench . -benchmem -cpu 1,4,8
+
+// benchBaselineFile stores the ns/op reference used by TestBenchmarkConsoleBaseline
+const benchBaselineFile = "testdata/bench_baseline.json"
+
+// maxConsoleRegression is the allowed slowdown of BenchmarkConsole over the stored baseline
+const maxConsoleRegression = 0.20
+
+// runParallel reports allocations and runs body with the configured parallelism
+func runParallel(b *testing.B, body func()) {
+	b.Helper()
+	if p, err := strconv.Atoi(os.Getenv("LOG_BENCH_PARALLELISM")); err == nil && p > 0 {
+		b.SetParallelism(p)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			body()
+		}
+	})
+}
+
+// newBenchFileLogger creates a file logger writing to a temporary directory
+func newBenchFileLogger(b *testing.B, jsonFormat bool) Logger {
+	b.Helper()
+	logger := NewFileLogger(InfoLevel, &FileLoggerConfig{
+		Filename:   filepath.Join(b.TempDir(), "bench.log"),
+		MaxSize:    100,
+		MaxBackups: 1,
+		JsonFormat: jsonFormat,
+	})
+	b.Cleanup(func() { logger.(*FileLogger).Close() })
+	return logger
+}
+
+// startMockDatadogAgent accepts TCP connections and discards everything written to them
+func startMockDatadogAgent(b *testing.B) *net.TCPAddr {
+	b.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		b.Fatalf("Failed to start mock Datadog agent: %v", err)
+	}
+	b.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(io.Discard, conn)
+			}()
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr)
+}
+
+func BenchmarkFileLogger(b *testing.B) {
+	logger := newBenchFileLogger(b, false)
+
+	runParallel(b, func() {
+		logger.Info("File benchmark message", String("key", "value"), Int("count", 42))
+	})
+}
+
+func BenchmarkFileLoggerJSON(b *testing.B) {
+	logger := newBenchFileLogger(b, true)
+
+	runParallel(b, func() {
+		logger.Info("File benchmark message", String("key", "value"), Int("count", 42))
+	})
+}
+
+func BenchmarkDatadogLogger(b *testing.B) {
+	addr := startMockDatadogAgent(b)
+	logger := NewDatadogLogger(InfoLevel, &DatadogLoggerConfig{
+		Host:        addr.IP.String(),
+		Port:        addr.Port,
+		Service:     "bench",
+		Environment: "test",
+		Source:      "go",
+		Timeout:     5,
+		JsonFormat:  true,
+	})
+	b.Cleanup(func() { logger.(*DatadogLogger).Close() })
+
+	runParallel(b, func() {
+		logger.Info("Datadog benchmark message", String("key", "value"), Int("count", 42))
+	})
+}
+
+func BenchmarkMultiLoggerThree(b *testing.B) {
+	addr := startMockDatadogAgent(b)
+	console := NewConsoleLoggerWithWriter(InfoLevel, io.Discard, false)
+	file := newBenchFileLogger(b, true)
+	datadog := NewDatadogLogger(InfoLevel, &DatadogLoggerConfig{
+		Host:       addr.IP.String(),
+		Port:       addr.Port,
+		Timeout:    5,
+		JsonFormat: true,
+	})
+	b.Cleanup(func() { datadog.(*DatadogLogger).Close() })
+	logger := NewMultiLogger(console, file, datadog)
+
+	runParallel(b, func() {
+		logger.Info("Multi benchmark message", String("key", "value"))
+	})
+}
+
+func BenchmarkWithFieldsDeep(b *testing.B) {
+	logger := NewConsoleLoggerWithWriter(InfoLevel, io.Discard, false)
+	for i := 0; i < 10; i++ {
+		logger = logger.WithFields(Int("level"+strconv.Itoa(i), i))
+	}
+
+	runParallel(b, func() {
+		logger.Info("Deep fields benchmark message")
+	})
+}
+
+// BenchmarkAsyncLoggerBase is the synchronous file logger wrapped by BenchmarkAsyncLogger
+func BenchmarkAsyncLoggerBase(b *testing.B) {
+	logger := newBenchFileLogger(b, true)
+
+	runParallel(b, func() {
+		logger.Info("Async benchmark message", String("key", "value"), Int("count", 42))
+	})
+}
+
+func BenchmarkAsyncLogger(b *testing.B) {
+	benchmarkAsyncLogger(b)
+}
+
+// benchmarkAsyncLogger runs BenchmarkAsyncLogger and returns the number of dropped entries.
+func benchmarkAsyncLogger(b *testing.B) uint64 {
+	logger := NewAsyncLogger(newBenchFileLogger(b, true), 8192).(*AsyncLogger)
+	b.Cleanup(func() { logger.Close() })
+
+	runParallel(b, func() {
+		logger.Info("Async benchmark message", String("key", "value"), Int("count", 42))
+	})
+	dropped := logger.DroppedCount()
+	b.ReportMetric(float64(dropped)/float64(b.N), "dropped/op")
+	return dropped
+}
+
+// minAsyncSpeedup is the required throughput of BenchmarkAsyncLogger over BenchmarkAsyncLoggerBase
+const minAsyncSpeedup = 3
+
+// TestBenchmarkAsyncSpeedup fails when the async logger is not at least 3x faster than its synchronous base,
+// or when it reaches that by dropping entries. It needs 8 cores and only runs with LOG_BENCH_ASYNC=1.
+func TestBenchmarkAsyncSpeedup(t *testing.T) {
+	if os.Getenv("LOG_BENCH_ASYNC") == "" {
+		t.Skip("Set LOG_BENCH_ASYNC=1 to compare BenchmarkAsyncLogger against BenchmarkAsyncLoggerBase")
+	}
+	if runtime.NumCPU() < 8 {
+		t.Skipf("Expected at least 8 CPUs, got %d", runtime.NumCPU())
+	}
+
+	base := testing.Benchmark(BenchmarkAsyncLoggerBase).NsPerOp()
+	var dropped uint64
+	async := testing.Benchmark(func(b *testing.B) { dropped = benchmarkAsyncLogger(b) }).NsPerOp()
+	if dropped > 0 {
+		t.Fatalf("Expected the async logger to write every entry, got %d dropped", dropped)
+	}
+	if async <= 0 || float64(base)/float64(async) < minAsyncSpeedup {
+		t.Errorf("Expected async logger at least %dx faster than %d ns/op, got %d ns/op", minAsyncSpeedup, base, async)
+	}
+}
+
+// benchBaseline is the stored reference result for one benchmark
+type benchBaseline struct {
+	NsPerOp int64 `json:"ns_per_op"`
+}
+
+// TestBenchmarkConsoleBaseline fails when BenchmarkConsole is more than 20% slower than the stored baseline.
+// Timings depend on the machine, so it only runs with LOG_BENCH_BASELINE=1; LOG_BENCH_UPDATE=1 rewrites the baseline.
+func TestBenchmarkConsoleBaseline(t *testing.T) {
+	if os.Getenv("LOG_BENCH_BASELINE") == "" && os.Getenv("LOG_BENCH_UPDATE") == "" {
+		t.Skip("Set LOG_BENCH_BASELINE=1 to compare BenchmarkConsole against the stored baseline")
+	}
+
+	result := testing.Benchmark(BenchmarkConsole)
+	current := result.NsPerOp()
+
+	if os.Getenv("LOG_BENCH_UPDATE") != "" {
+		data, err := json.MarshalIndent(map[string]benchBaseline{"BenchmarkConsole": {NsPerOp: current}}, "", "  ")
+		if err != nil {
+			t.Fatalf("Failed to encode baseline: %v", err)
+		}
+		if err := os.WriteFile(benchBaselineFile, append(data, '\n'), 0o644); err != nil {
+			t.Fatalf("Failed to write baseline: %v", err)
+		}
+		t.Logf("Updated BenchmarkConsole baseline to %d ns/op", current)
+		return
+	}
+
+	data, err := os.ReadFile(benchBaselineFile)
+	if err != nil {
+		t.Fatalf("Failed to read baseline: %v", err)
+	}
+	var baselines map[string]benchBaseline
+	if err := json.Unmarshal(data, &baselines); err != nil {
+		t.Fatalf("Failed to parse baseline: %v", err)
+	}
+	baseline, ok := baselines["BenchmarkConsole"]
+	if !ok || baseline.NsPerOp <= 0 {
+		t.Fatal("Expected a BenchmarkConsole baseline")
+	}
+
+	limit := float64(baseline.NsPerOp) * (1 + maxConsoleRegression)
+	if float64(current) > limit {
+		t.Errorf("Expected BenchmarkConsole within %.0f%% of %d ns/op, got %d ns/op", maxConsoleRegression*100, baseline.NsPerOp, current)
+	}
+}
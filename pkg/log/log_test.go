@@ -3,8 +3,15 @@ package log
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"math"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 )
@@ -124,6 +131,62 @@ func TestMulti(t *testing.T) {
 	}
 }
 
+func TestMultiFormattedFanOut(t *testing.T) {
+	var consoleBuf bytes.Buffer
+	console := NewConsoleLoggerWithWriter(DebugLevel, &consoleBuf, false)
+
+	logFile := filepath.Join(t.TempDir(), "multi_formatted.log")
+	file := NewFileLogger(DebugLevel, &FileLoggerConfig{
+		Filename:   logFile,
+		MaxSize:    1,
+		MaxBackups: 1,
+		MaxAge:     1,
+		JsonFormat: true,
+	})
+
+	multi := NewMultiLogger(console, file)
+	tests := []struct {
+		level Level
+		log   func(format string, args ...interface{})
+	}{
+		{DebugLevel, multi.Debugf},
+		{InfoLevel, multi.Infof},
+		{WarnLevel, multi.Warnf},
+		{ErrorLevel, multi.Errorf},
+	}
+	for _, tt := range tests {
+		tt.log("formatted %s message %d", tt.level, 42)
+	}
+	if closer, ok := multi.(interface{ Close() error }); ok {
+		if err := closer.Close(); err != nil {
+			t.Fatalf("Failed to close multi logger: %v", err)
+		}
+	}
+
+	fileContent, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+
+	outputs := map[string]string{"console": consoleBuf.String(), "file": string(fileContent)}
+	for name, output := range outputs {
+		lines := strings.Split(strings.TrimSpace(output), "\n")
+		if len(lines) != len(tests) {
+			t.Fatalf("Expected %d %s log lines, got %d: %s", len(tests), name, len(lines), output)
+		}
+		for i, tt := range tests {
+			var entry map[string]interface{}
+			if err := json.Unmarshal([]byte(lines[i]), &entry); err != nil {
+				t.Fatalf("Failed to parse %s log line %q: %v", name, lines[i], err)
+			}
+			expected := fmt.Sprintf("formatted %s message 42", tt.level)
+			if entry["level"] != string(tt.level) || entry["message"] != expected {
+				t.Errorf("Expected %s %q in %s output, got %v %v", tt.level, expected, name, entry["level"], entry["message"])
+			}
+		}
+	}
+}
+
 func TestWithFields(t *testing.T) {
 	var buf bytes.Buffer
 	logger := NewConsoleLoggerWithWriter(DebugLevel, &buf, false)
@@ -144,6 +207,224 @@ func TestWithFields(t *testing.T) {
 	}
 }
 
+func TestFormattedLoggingWithFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewConsoleLoggerWithWriter(DebugLevel, &buf, false)
+
+	contextLogger := logger.WithFields(String("request_id", "123"))
+
+	tests := []struct {
+		name string
+		log  func(format string, args ...interface{})
+	}{
+		{"Debugf", contextLogger.Debugf},
+		{"Infof", contextLogger.Infof},
+		{"Warnf", contextLogger.Warnf},
+		{"Errorf", contextLogger.Errorf},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			tt.log("hello %s", "world")
+
+			output := buf.String()
+			if !contains(output, `"request_id":"123"`) {
+				t.Errorf("Expected output to contain request_id, got: %s", output)
+			}
+			if !contains(output, "hello world") {
+				t.Errorf("Expected output to contain formatted message, got: %s", output)
+			}
+		})
+	}
+}
+
+func TestFileLoggerFormattedWithFields(t *testing.T) {
+	logFile := "test_file_formatted_fields.log"
+	defer func() { _ = os.Remove(logFile) }()
+
+	logger := NewFileLogger(InfoLevel, &FileLoggerConfig{
+		Filename:   logFile,
+		JsonFormat: true,
+	})
+	defer func() { _ = logger.(*FileLogger).Close() }()
+
+	logger.WithFields(String("request_id", "123")).Infof("hello %s", "world")
+
+	content, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatal("Could not read log file")
+	}
+	if !contains(string(content), `"request_id":"123"`) {
+		t.Errorf("Expected log file to contain request_id, got: %s", string(content))
+	}
+}
+
+func TestErrorWithStack(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewConsoleLoggerWithWriter(DebugLevel, &buf, false)
+
+	err := errors.New("boom")
+	logger.Error("operation failed", Error(err), ErrorWithStack(err))
+
+	output := buf.String()
+	if !contains(output, `"stack_trace":[`) {
+		t.Errorf("Expected stack_trace to be formatted as lines, got: %s", output)
+	}
+	if !contains(output, "TestErrorWithStack") {
+		t.Errorf("Expected stack trace to contain the test function name, got: %s", output)
+	}
+}
+
+func TestWrapCapturesStack(t *testing.T) {
+	base := errors.New("connection refused")
+	wrapped := wrapInHelper(base)
+
+	if !errors.Is(wrapped, base) {
+		t.Error("Wrapped error should unwrap to the original error")
+	}
+	if wrapped.Error() != "loading users: connection refused" {
+		t.Errorf("Expected wrapped message, got '%s'", wrapped.Error())
+	}
+
+	// The stack should come from the Wrap call site, not the logging site
+	field := ErrorWithStack(wrapped)
+	stack, ok := field.Value.(string)
+	if !ok {
+		t.Fatalf("Expected stack trace to be a string, got %T", field.Value)
+	}
+	if field.Key != StackTraceKey {
+		t.Errorf("Expected key '%s', got '%s'", StackTraceKey, field.Key)
+	}
+	if !contains(stack, "wrapInHelper") || !contains(stack, "TestWrapCapturesStack") {
+		t.Errorf("Expected stack trace to contain the wrapping call site, got: %s", stack)
+	}
+
+	if Wrap(nil, "ignored") != nil {
+		t.Error("Wrap(nil) should return nil")
+	}
+}
+
+func wrapInHelper(err error) error {
+	return Wrap(err, "loading users")
+}
+
+func TestHotReloadLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewConsoleLoggerWithWriter(InfoLevel, &buf, false)
+	defer HotReloadLevel("info")
+
+	logger.Debug("hidden debug message")
+	if contains(buf.String(), "hidden debug message") {
+		t.Error("Debug message should be filtered at info level")
+	}
+
+	HotReloadLevel("debug")
+	logger.Debug("visible debug message")
+	if !contains(buf.String(), "visible debug message") {
+		t.Error("Debug message should be logged after reloading to debug level")
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	var consoleBuf, fileBuf bytes.Buffer
+	console := NewConsoleLoggerWithWriter(InfoLevel, &consoleBuf, false)
+	other := NewConsoleLoggerWithWriter(InfoLevel, &fileBuf, false)
+	multi := NewMultiLogger(console, other)
+	child := multi.WithFields(String("request_id", "123"))
+
+	child.Debug("hidden debug message")
+	if contains(consoleBuf.String(), "hidden debug message") {
+		t.Error("Debug message should be filtered at info level")
+	}
+
+	controller, ok := multi.(LevelController)
+	if !ok {
+		t.Fatal("Expected MultiLogger to implement LevelController")
+	}
+	controller.SetLevel(DebugLevel)
+	if controller.GetLevel() != DebugLevel {
+		t.Errorf("Expected level debug, got %s", controller.GetLevel())
+	}
+
+	// Loggers derived before the change follow it
+	child.Debug("visible debug message")
+	for i, output := range []string{consoleBuf.String(), fileBuf.String()} {
+		if !contains(output, "visible debug message") {
+			t.Errorf("Expected logger %d to log debug after SetLevel, got: %s", i, output)
+		}
+	}
+
+	// An explicit level is kept when the default level is reloaded
+	HotReloadLevel("error")
+	defer HotReloadLevel("info")
+	console.Info("still visible")
+	if !contains(consoleBuf.String(), "still visible") {
+		t.Error("Expected SetLevel to take precedence over HotReloadLevel")
+	}
+}
+
+func TestSetLevelConcurrent(t *testing.T) {
+	logger := NewConsoleLoggerWithWriter(InfoLevel, io.Discard, false)
+	controller := logger.(LevelController)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			logger.WithFields(Int("i", i)).Debug("concurrent")
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		if i%2 == 0 {
+			controller.SetLevel(DebugLevel)
+		} else {
+			controller.SetLevel(WarnLevel)
+		}
+	}
+	<-done
+}
+
+func TestLazyField(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewConsoleLoggerWithWriter(InfoLevel, &buf, false)
+
+	calls := 0
+	expensive := func() any {
+		calls++
+		return "computed"
+	}
+
+	logger.Debug("suppressed", LazyField("lazy", expensive))
+	if calls != 0 {
+		t.Errorf("Lazy field should not be evaluated for suppressed levels, got %d calls", calls)
+	}
+
+	logger.Info("emitted", LazyField("lazy", expensive))
+	if calls != 1 {
+		t.Errorf("Lazy field should be evaluated once when emitted, got %d calls", calls)
+	}
+	if !contains(buf.String(), `"lazy":"computed"`) {
+		t.Errorf("Expected output to contain the lazy value, got: %s", buf.String())
+	}
+
+	logger.WithFields(LazyField("ctx_lazy", func() any { return 7 })).Info("with context")
+	if !contains(buf.String(), `"ctx_lazy":7`) {
+		t.Errorf("Expected output to contain the lazy context value, got: %s", buf.String())
+	}
+}
+
+func TestLazyFieldSuppressedAllocations(t *testing.T) {
+	logger := NewConsoleLoggerWithWriter(InfoLevel, io.Discard, false).(*ConsoleLogger)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		logger.Debug("suppressed", LazyField("payload", expensivePayload))
+	})
+	if allocs != 0 {
+		t.Errorf("Expected zero allocations for a suppressed lazy field, got %.1f", allocs)
+	}
+}
+
 func TestWithContext(t *testing.T) {
 	logger := NewConsoleLogger(InfoLevel)
 	ctx := context.Background()
@@ -174,6 +455,7 @@ func TestFieldHelpers(t *testing.T) {
 		Time("time_field", testTime),
 		Duration("duration_field", testDuration),
 		Any("any_field", map[string]string{"key": "value"}),
+		UUID("uuid_field", "f47ac10b-58cc-4372-a567-0e02b2c3d479"),
 	)
 
 	// Test error field
@@ -184,6 +466,341 @@ func TestFieldHelpers(t *testing.T) {
 	if output == "" {
 		t.Error("No output generated for field helpers")
 	}
+	if !contains(output, `"uuid_field":"f47ac10b-58cc-4372-a567-0e02b2c3d479"`) {
+		t.Error("Output should contain the UUID field")
+	}
+}
+
+func TestThirtyTwoBitFields(t *testing.T) {
+	// Mirrors the field types of a protobuf generated message
+	type rpcStatus struct {
+		Code    int32
+		Retries uint32
+		Ratio   float32
+	}
+	status := rpcStatus{Code: -2147483648, Retries: 4294967295, Ratio: 0.1}
+
+	testCases := []struct {
+		name      string
+		colorized bool
+		expected  []string
+	}{
+		{"json", false, []string{`"code":-2147483648`, `"retries":4294967295`, `"ratio":0.1`}},
+		{"text", true, []string{"-2147483648", "4294967295", "0.1"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := NewConsoleLoggerWithWriter(InfoLevel, &buf, tc.colorized)
+
+			logger.Info("RPC finished",
+				Int32("code", status.Code),
+				Uint32("retries", status.Retries),
+				Float32("ratio", status.Ratio),
+			)
+
+			output := buf.String()
+			for _, want := range tc.expected {
+				if !contains(output, want) {
+					t.Errorf("Expected output to contain %s, got: %s", want, output)
+				}
+			}
+			// float32 widened to float64 would print as 0.10000000149011612
+			if contains(output, "0.100000001") {
+				t.Errorf("Float32 field lost precision: %s", output)
+			}
+		})
+	}
+}
+
+func TestConsoleLoggerSmallUnsignedFields(t *testing.T) {
+	testCases := []struct {
+		name      string
+		colorized bool
+		expected  []string
+	}{
+		{"json", false, []string{`"ttl":64`, `"port":8080`}},
+		{"text", true, []string{"64", "8080"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := NewConsoleLoggerWithWriter(InfoLevel, &buf, tc.colorized)
+
+			logger.Info("Packet received", Uint8("ttl", 64), Uint16("port", 8080))
+
+			output := buf.String()
+			for _, want := range tc.expected {
+				if !contains(output, want) {
+					t.Errorf("Expected output to contain %s, got: %s", want, output)
+				}
+			}
+			if contains(output, "64.0") || contains(output, "8080.0") {
+				t.Errorf("Expected integer serialization, got: %s", output)
+			}
+		})
+	}
+}
+
+func TestTypedNumericFields(t *testing.T) {
+	testCases := []struct {
+		name     string
+		field    Field
+		expected string
+	}{
+		{"int32", Int32("value", -2147483648), `"value":-2147483648`},
+		{"uint", Uint("value", 42), `"value":42`},
+		{"uint64 max", Uint64("value", math.MaxUint64), `"value":18446744073709551615`},
+		{"bytes as string", Bytes("value", []byte("hello")), `"value":"hello"`},
+		{"nil bytes", Bytes("value", nil), `"value":""`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := NewConsoleLoggerWithWriter(InfoLevel, &buf, false)
+
+			logger.Info("Typed field", tc.field)
+
+			output := buf.String()
+			if !contains(output, tc.expected) {
+				t.Errorf("Expected output to contain %s, got: %s", tc.expected, output)
+			}
+
+			var entry map[string]any
+			decoder := json.NewDecoder(strings.NewReader(output))
+			decoder.UseNumber()
+			if err := decoder.Decode(&entry); err != nil {
+				t.Fatalf("Expected valid JSON, got %v: %s", err, output)
+			}
+			_, isNumber := entry["value"].(json.Number)
+			if _, isBytes := tc.field.Value.([]byte); isBytes == isNumber {
+				t.Errorf("Expected %s to be encoded as a JSON number: %v, got %T", tc.name, !isBytes, entry["value"])
+			}
+		})
+	}
+}
+
+func TestFileLoggerTypedNumericFields(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "typed.log")
+	logger := NewFileLogger(InfoLevel, &FileLoggerConfig{Filename: logFile, JsonFormat: true})
+	defer func() { _ = logger.(*FileLogger).Close() }()
+
+	logger.Info("Typed fields", Uint("count", 3), Uint64("offset", 1<<40), Bytes("payload", []byte("raw")))
+
+	content, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatal("Could not read log file")
+	}
+	for _, want := range []string{`"count":3`, `"offset":1099511627776`, `"payload":"raw"`} {
+		if !contains(string(content), want) {
+			t.Errorf("Expected log file to contain %s, got: %s", want, string(content))
+		}
+	}
+}
+
+func TestErrFields(t *testing.T) {
+	testCases := []struct {
+		name     string
+		field    Field
+		expected string
+	}{
+		{"Err uses err key", Err(errors.New("boom")), `"err":"boom"`},
+		{"ErrField uses custom key", ErrField("cause", errors.New("timeout")), `"cause":"timeout"`},
+		{"Error keeps error key", Error(errors.New("boom")), `"error":"boom"`},
+		{"nil error is null", Err(nil), `"err":null`},
+		{"typed nil error is null", Err((*os.PathError)(nil)), `"err":null`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := NewConsoleLoggerWithWriter(InfoLevel, &buf, false)
+
+			logger.Info("Request failed", tc.field)
+
+			output := buf.String()
+			if !contains(output, tc.expected) {
+				t.Errorf("Expected output to contain %s, got: %s", tc.expected, output)
+			}
+			if contains(output, "<nil>") {
+				t.Errorf("Expected nil error not to be stringified, got: %s", output)
+			}
+		})
+	}
+}
+
+func TestTypedNilErrorInBatchedLoggers(t *testing.T) {
+	field := Err((*os.PathError)(nil))
+
+	loki := NewLokiLogger(InfoLevel, &LokiLoggerConfig{URL: "http://127.0.0.1:1", JsonFormat: true, FlushInterval: time.Hour}).(*LokiLogger)
+	defer loki.Close()
+	if line := loki.buildLogLine("ERROR", "Request failed", []Field{field}); !contains(line, `"err":null`) {
+		t.Errorf("Expected the Loki line to contain a null err, got: %s", line)
+	}
+
+	elastic := NewElasticsearchLogger(InfoLevel, &ElasticsearchLoggerConfig{Addresses: []string{"http://127.0.0.1:1"}, FlushInterval: time.Hour}).(*ElasticsearchLogger)
+	defer elastic.Close()
+	doc := elastic.buildDocument("ERROR", "Request failed", []Field{field})
+	if value, ok := doc.Fields["err"]; !ok || value != nil {
+		t.Errorf("Expected the Elasticsearch document to contain a null err, got: %v", doc.Fields)
+	}
+}
+
+func TestRedactedKeys(t *testing.T) {
+	var consoleBuf, multiBuf bytes.Buffer
+	logFile := "test_redacted_keys.log"
+	defer func() { _ = os.Remove(logFile) }()
+
+	fileLogger := NewFileLogger(InfoLevel, &FileLoggerConfig{Filename: logFile, JsonFormat: true})
+	defer func() { _ = fileLogger.(*FileLogger).Close() }()
+
+	loggers := []struct {
+		name   string
+		logger Logger
+		output func() string
+	}{
+		{"console", NewConsoleLoggerWithWriter(InfoLevel, &consoleBuf, false), consoleBuf.String},
+		{"file", fileLogger, func() string {
+			content, _ := os.ReadFile(logFile)
+			return string(content)
+		}},
+		{"multi", NewMultiLogger(NewConsoleLoggerWithWriter(InfoLevel, &multiBuf, false)), multiBuf.String},
+	}
+
+	for _, tc := range loggers {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := tc.logger.WithRedactedKeys("password", "Token")
+
+			// Redaction is inherited by derived loggers and applies to context fields
+			logger.WithFields(String("password", "password123")).
+				WithContext(context.Background()).
+				Info("Login", String("username", "alice"), String("token", "secret-token"), String("PASSWORD", "password123"))
+			logger.WithRedactedKeys("credit_card").Info("Payment", String("credit_card", "4111111111111111"), String("password", "password123"))
+
+			output := tc.output()
+			for _, secret := range []string{"password123", "secret-token", "4111111111111111"} {
+				if contains(output, secret) {
+					t.Errorf("Expected %s to be redacted, got: %s", secret, output)
+				}
+			}
+			for _, want := range []string{`"password":"[REDACTED]"`, `"token":"[REDACTED]"`, `"credit_card":"[REDACTED]"`, `"username":"alice"`} {
+				if !contains(output, want) {
+					t.Errorf("Expected output to contain %s, got: %s", want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestRedactedKeysSkipLazyEvaluation(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewConsoleLoggerWithWriter(InfoLevel, &buf, false).WithRedactedKeys("password")
+
+	logger.Info("Login", LazyField("password", func() any {
+		t.Error("Expected redacted lazy field not to be evaluated")
+		return "password123"
+	}))
+
+	if !contains(buf.String(), `"password":"[REDACTED]"`) {
+		t.Errorf("Expected redacted lazy field, got: %s", buf.String())
+	}
+}
+
+func TestDatadogLoggerRedactedKeys(t *testing.T) {
+	logger := NewDatadogLogger(InfoLevel, &DatadogLoggerConfig{JsonFormat: true}).
+		WithRedactedKeys("password").
+		WithFields(String("password", "password123")).(*DatadogLogger)
+
+	line := logger.buildLogLine("INFO", "Login", []Field{String("user", "alice")})
+	if contains(line, "password123") {
+		t.Errorf("Expected password to be redacted, got: %s", line)
+	}
+	if !contains(line, `"password":"[REDACTED]"`) {
+		t.Errorf("Expected redacted password field, got: %s", line)
+	}
+}
+
+// logViaHelpers logs through two helper levels, skipping both when reporting the caller
+func logViaHelpers(logger Logger) {
+	innerLogHelper(logger.(interface{ WithCallerSkip(int) Logger }).WithCallerSkip(2))
+}
+
+func innerLogHelper(logger Logger) {
+	logger.WithFields(String("helper", "inner")).Info("Logged from helper")
+}
+
+// nextLine returns the file:line following the call to nextLine
+func nextLine() string {
+	_, file, line, _ := runtime.Caller(1)
+	return fmt.Sprintf("%s:%d", file, line+1)
+}
+
+func TestWithCallerSkip(t *testing.T) {
+	logFile := "test_caller_skip.log"
+	defer func() { _ = os.Remove(logFile) }()
+
+	var buf bytes.Buffer
+	fileLogger := NewFileLogger(InfoLevel, &FileLoggerConfig{Filename: logFile, JsonFormat: true})
+	defer func() { _ = fileLogger.(*FileLogger).Close() }()
+
+	loggers := []struct {
+		name   string
+		logger Logger
+		output func() string
+	}{
+		{"console", NewConsoleLoggerWithWriter(InfoLevel, &buf, false), buf.String},
+		{"file", fileLogger, func() string {
+			content, _ := os.ReadFile(logFile)
+			return string(content)
+		}},
+	}
+
+	for _, tc := range loggers {
+		t.Run(tc.name, func(t *testing.T) {
+			expected := nextLine()
+			logViaHelpers(tc.logger)
+
+			// Direct calls report the calling line, not the logger implementation
+			direct := nextLine()
+			tc.logger.Info("Logged directly")
+
+			output := tc.output()
+			for _, want := range []string{expected, direct} {
+				if !contains(output, want) {
+					t.Errorf("Expected caller %s in output, got: %s", want, output)
+				}
+			}
+			if contains(output, "consoleLogger.go") || contains(output, "fileLogger.go") {
+				t.Errorf("Expected no logger internals as caller, got: %s", output)
+			}
+		})
+	}
+}
+
+func TestFileLoggerThirtyTwoBitFields(t *testing.T) {
+	logFile := "test_file_32bit_fields.log"
+	defer func() { _ = os.Remove(logFile) }()
+
+	logger := NewFileLogger(InfoLevel, &FileLoggerConfig{
+		Filename:   logFile,
+		JsonFormat: true,
+	})
+	defer func() { _ = logger.(*FileLogger).Close() }()
+
+	logger.WithFields(Uint32("shard", 7)).Info("RPC finished", Int32("code", 5), Float32("ratio", 0.3))
+
+	content, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatal("Could not read log file")
+	}
+	for _, want := range []string{`"shard":7`, `"code":5`, `"ratio":0.3`} {
+		if !contains(string(content), want) {
+			t.Errorf("Expected log file to contain %s, got: %s", want, string(content))
+		}
+	}
 }
 
 func TestLevels(t *testing.T) {
@@ -300,6 +917,52 @@ func BenchmarkConsole(b *testing.B) {
 	})
 }
 
+// BenchmarkConsoleRedaction compares field logging without redaction against a redact list that never matches
+func BenchmarkConsoleRedaction(b *testing.B) {
+	base := NewConsoleLoggerWithWriter(InfoLevel, io.Discard, false)
+	cases := []struct {
+		name   string
+		logger Logger
+	}{
+		{"none", base},
+		{"no_match", base.WithRedactedKeys("password", "token", "credit_card")},
+	}
+
+	for _, bc := range cases {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				bc.logger.Info("Benchmark message", String("user", "alice"), Int("attempt", i))
+			}
+		})
+	}
+}
+
+// expensivePayload simulates a costly value that should only be built when logged
+func expensivePayload() any {
+	return fmt.Sprintf("payload: %v", map[string]int{"a": 1, "b": 2, "c": 3})
+}
+
+func BenchmarkLazyFieldSuppressed(b *testing.B) {
+	logger := NewConsoleLoggerWithWriter(InfoLevel, io.Discard, false).(*ConsoleLogger)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Debug("Suppressed debug message", LazyField("payload", expensivePayload))
+	}
+}
+
+func BenchmarkEagerFieldSuppressed(b *testing.B) {
+	logger := NewConsoleLoggerWithWriter(InfoLevel, io.Discard, false).(*ConsoleLogger)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Debug("Suppressed debug message", Any("payload", expensivePayload()))
+	}
+}
+
 func BenchmarkFormattedLogging(b *testing.B) {
 	logger := NewConsoleLoggerWithWriter(InfoLevel, io.Discard, false)
 
@@ -414,6 +1077,70 @@ func TestFileLoggerClose(t *testing.T) {
 	}
 }
 
+func TestMultiPanicReachesEveryLogger(t *testing.T) {
+	tests := []struct {
+		name  string
+		panic func(m Logger)
+	}{
+		{"Panic", func(m Logger) { m.Panic("shutting down", String("reason", "test")) }},
+		{"Panicf", func(m Logger) { m.Panicf("shutting %s", "down") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var first, second bytes.Buffer
+			multi := NewMultiLogger(
+				NewConsoleLoggerWithWriter(InfoLevel, &first, false),
+				NewConsoleLoggerWithWriter(InfoLevel, &second, false),
+			)
+
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Error("Expected the multi logger to panic")
+					}
+				}()
+				tt.panic(multi)
+			}()
+
+			if !strings.Contains(first.String(), `"level":"error"`) || !strings.Contains(first.String(), "shutting down") {
+				t.Errorf("Expected the first logger to receive the message at error level, got: %s", first.String())
+			}
+			if !strings.Contains(second.String(), `"level":"panic"`) || !strings.Contains(second.String(), "shutting down") {
+				t.Errorf("Expected the last logger to receive the message at panic level, got: %s", second.String())
+			}
+		})
+	}
+}
+
+func TestNopLogger(t *testing.T) {
+	logger := NewNopLogger().WithFields(String("key", "value")).WithContext(context.Background()).WithRedactedKeys("password")
+	logger.Info("discarded")
+	logger.Errorf("discarded %d", 1)
+
+	defer func() {
+		if r := recover(); r != "stop 2" {
+			t.Errorf("Expected Panicf to panic with %q, got %v", "stop 2", r)
+		}
+	}()
+	logger.Panicf("stop %d", 2)
+}
+
+func TestMultiLoggerClose(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "test_multi_close.log")
+	fileLogger := NewFileLogger(InfoLevel, &FileLoggerConfig{Filename: logFile})
+	consoleLogger := NewConsoleLoggerWithWriter(InfoLevel, &bytes.Buffer{}, false)
+
+	multi := NewMultiLogger(consoleLogger, fileLogger)
+	closer, ok := multi.(io.Closer)
+	if !ok {
+		t.Fatal("Expected MultiLogger to implement io.Closer")
+	}
+	if err := closer.Close(); err != nil {
+		t.Errorf("Error closing multi logger: %v", err)
+	}
+}
+
 func TestMultiLoggerDebug(t *testing.T) {
 	var consoleBuf bytes.Buffer
 	consoleLogger := NewConsoleLoggerWithWriter(DebugLevel, &consoleBuf, false)
@@ -437,6 +1164,87 @@ func TestMultiLoggerDebug(t *testing.T) {
 	}
 }
 
+func TestMultiLoggerWithContextKeepsFields(t *testing.T) {
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+	var firstBuf, secondBuf bytes.Buffer
+	multiLogger := NewMultiLogger(
+		NewConsoleLoggerWithWriter(InfoLevel, &firstBuf, false),
+		NewConsoleLoggerWithWriter(InfoLevel, &secondBuf, false),
+	)
+
+	tests := []struct {
+		name   string
+		logger Logger
+	}{
+		{"WithFields then WithContext", multiLogger.WithFields(String("request_id", "123")).WithContext(ctx)},
+		{"WithContext then WithFields", multiLogger.WithContext(ctx).WithFields(String("request_id", "123"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			firstBuf.Reset()
+			secondBuf.Reset()
+
+			tt.logger.WithFields(String("user_id", "42")).Info("chained")
+
+			for i, output := range []string{firstBuf.String(), secondBuf.String()} {
+				for _, want := range []string{`"request_id":"123"`, `"user_id":"42"`, "chained"} {
+					if !contains(output, want) {
+						t.Errorf("Expected logger %d output to contain %s, got: %s", i, want, output)
+					}
+				}
+			}
+		})
+	}
+
+	// Deriving must not leak fields back into the parent
+	firstBuf.Reset()
+	multiLogger.Info("parent")
+	if contains(firstBuf.String(), "request_id") {
+		t.Errorf("Expected parent logger without derived fields, got: %s", firstBuf.String())
+	}
+}
+
+func TestMultiLoggerFormattedDelegation(t *testing.T) {
+	var firstBuf, secondBuf bytes.Buffer
+	multiLogger := NewMultiLogger(
+		NewConsoleLoggerWithWriter(DebugLevel, &firstBuf, false),
+		NewConsoleLoggerWithWriter(DebugLevel, &secondBuf, false),
+	)
+
+	contextLogger := multiLogger.WithFields(String("request_id", "123"))
+
+	tests := []struct {
+		name string
+		log  func(format string, args ...interface{})
+	}{
+		{"Debugf", contextLogger.Debugf},
+		{"Infof", contextLogger.Infof},
+		{"Warnf", contextLogger.Warnf},
+		{"Errorf", contextLogger.Errorf},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			firstBuf.Reset()
+			secondBuf.Reset()
+
+			tt.log("hello %s", "world")
+
+			for i, output := range []string{firstBuf.String(), secondBuf.String()} {
+				if !contains(output, "hello world") {
+					t.Errorf("Expected logger %d output to contain 'hello world', got: %s", i, output)
+				}
+				if !contains(output, `"request_id":"123"`) {
+					t.Errorf("Expected logger %d output to contain request_id, got: %s", i, output)
+				}
+			}
+		})
+	}
+}
+
 func TestLoggerWithEmptyFields(t *testing.T) {
 	var buf bytes.Buffer
 	logger := NewConsoleLoggerWithWriter(InfoLevel, &buf, false)
@@ -2,6 +2,14 @@ package log
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -41,6 +49,9 @@ type Logger interface {
 
 	WithFields(fields ...Field) Logger
 	WithContext(ctx context.Context) Logger
+	// WithRedactedKeys returns a logger that replaces the values of fields with these keys by RedactedValue.
+	// The keys are added to the ones already redacted and are inherited by derived loggers.
+	WithRedactedKeys(keys ...string) Logger
 }
 
 // parseLogLevel converts string to zerolog level.
@@ -63,6 +74,115 @@ func parseLogLevel(level string) zerolog.Level {
 	}
 }
 
+// callerSkipFrameCount returns the zerolog skip count that reports the code calling a logger method,
+// plus extra frames for wrapping helpers.
+func callerSkipFrameCount(extra int) int {
+	// One frame for the ConsoleLogger/FileLogger method itself
+	return zerolog.CallerSkipFrameCount + 1 + extra
+}
+
+// callerReporter is implemented by loggers that can report a caller captured earlier instead of
+// looking up the code calling their methods, so AsyncLogger entries point at the original call site.
+type callerReporter interface {
+	withCaller(caller string) Logger
+}
+
+// callerOf returns the caller skip frames above the function calling it, formatted like zerolog's caller field.
+func callerOf(skip int) string {
+	pc, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return ""
+	}
+	return zerolog.CallerMarshalFunc(pc, file, line)
+}
+
+// reportingCaller returns logger reporting caller when it supports it, otherwise logger itself.
+func reportingCaller(logger Logger, caller string) Logger {
+	if reporter, ok := logger.(callerReporter); ok && caller != "" {
+		return reporter.withCaller(caller)
+	}
+	return logger
+}
+
+// LevelController is implemented by loggers whose minimum level can be changed at runtime.
+type LevelController interface {
+	SetLevel(level Level)
+	GetLevel() Level
+}
+
+// inheritLevel marks a level state that follows defaultLevel.
+const inheritLevel = math.MinInt32
+
+// defaultLevel is the minimum level of zerolog-backed loggers that were not given their own with SetLevel.
+// It is set by the logger constructors and HotReloadLevel.
+var defaultLevel atomic.Int32
+
+func init() {
+	defaultLevel.Store(int32(zerolog.InfoLevel))
+}
+
+// HotReloadLevel changes the minimum level of the zerolog-backed loggers at runtime.
+// Loggers given their own level with SetLevel keep it.
+func HotReloadLevel(level string) {
+	defaultLevel.Store(int32(parseLogLevel(level)))
+}
+
+// levelState is the minimum level shared by a logger and the loggers derived from it.
+// It is read on every log call, so it is stored atomically instead of behind a lock.
+type levelState struct {
+	value atomic.Int32
+}
+
+// newLevelState returns a level state that follows defaultLevel until set.
+func newLevelState() *levelState {
+	state := &levelState{}
+	state.value.Store(inheritLevel)
+	return state
+}
+
+// get returns the effective minimum level.
+func (s *levelState) get() zerolog.Level {
+	value := s.value.Load()
+	if value == inheritLevel {
+		value = defaultLevel.Load()
+	}
+	return zerolog.Level(value)
+}
+
+// set overrides the minimum level.
+func (s *levelState) set(level Level) {
+	s.value.Store(int32(parseLogLevel(string(level))))
+}
+
+// level returns the effective minimum level as a Level.
+func (s *levelState) level() Level {
+	return Level(s.get().String())
+}
+
+// newLevelEvent starts an event at level, or returns nil when state filters it out.
+func newLevelEvent(logger *zerolog.Logger, state *levelState, level zerolog.Level) *zerolog.Event {
+	if level < state.get() {
+		return nil
+	}
+
+	switch level {
+	case zerolog.DebugLevel:
+		return logger.Debug()
+	case zerolog.InfoLevel:
+		return logger.Info()
+	case zerolog.WarnLevel:
+		return logger.Warn()
+	case zerolog.ErrorLevel:
+		return logger.Error()
+	case zerolog.FatalLevel:
+		return logger.Fatal()
+	case zerolog.PanicLevel:
+		return logger.Panic()
+	default:
+		return logger.WithLevel(level)
+	}
+}
+
 // Field represents a key-value pair for structured logging.
 type Field struct {
 	Key   string
@@ -84,6 +204,41 @@ func Int64(key string, value int64) Field {
 	return Field{Key: key, Value: value}
 }
 
+// Int32 creates an int32 field.
+func Int32(key string, value int32) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Uint creates a uint field.
+func Uint(key string, value uint) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Uint64 creates a uint64 field.
+func Uint64(key string, value uint64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Uint8 creates a uint8 field.
+func Uint8(key string, value uint8) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Uint16 creates a uint16 field.
+func Uint16(key string, value uint16) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Uint32 creates a uint32 field.
+func Uint32(key string, value uint32) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Float32 creates a float32 field.
+func Float32(key string, value float32) Field {
+	return Field{Key: key, Value: value}
+}
+
 // Float64 creates a float64 field.
 func Float64(key string, value float64) Field {
 	return Field{Key: key, Value: value}
@@ -99,6 +254,21 @@ func Error(err error) Field {
 	return Field{Key: "error", Value: err}
 }
 
+// Err creates an error field with the ECS-compatible key "err".
+func Err(err error) Field {
+	return ErrField("err", err)
+}
+
+// ErrField creates an error field with a custom key.
+func ErrField(key string, err error) Field {
+	return Field{Key: key, Value: err}
+}
+
+// Bytes creates a byte slice field, logged as a string instead of base64.
+func Bytes(key string, value []byte) Field {
+	return Field{Key: key, Value: value}
+}
+
 // Time creates a time field.
 func Time(key string, value time.Time) Field {
 	return Field{Key: key, Value: value}
@@ -109,7 +279,142 @@ func Duration(key string, value time.Duration) Field {
 	return Field{Key: key, Value: value}
 }
 
+// UUID creates a UUID field.
+func UUID(key, value string) Field {
+	return Field{Key: key, Value: value}
+}
+
+// lazyVal marks a field value that is computed only when the entry is emitted.
+type lazyVal func() any
+
+// LazyField creates a field whose value is computed by fn only if the log entry is emitted.
+func LazyField(key string, fn func() any) Field {
+	return Field{Key: key, Value: lazyVal(fn)}
+}
+
+// RedactedValue replaces the value of fields whose key is redacted.
+const RedactedValue = "[REDACTED]"
+
+// redactSet holds the lower-cased field keys whose values are redacted; it is never modified after creation.
+type redactSet map[string]struct{}
+
+// with returns a new set containing the existing keys and keys.
+func (r redactSet) with(keys ...string) redactSet {
+	merged := make(redactSet, len(r)+len(keys))
+	for key := range r {
+		merged[key] = struct{}{}
+	}
+	for _, key := range keys {
+		merged[strings.ToLower(key)] = struct{}{}
+	}
+	return merged
+}
+
+// value returns RedactedValue when key is redacted and value otherwise, without evaluating lazy fields.
+func (r redactSet) value(key string, value any) any {
+	if len(r) == 0 {
+		return value
+	}
+	if _, ok := r[strings.ToLower(key)]; ok {
+		return RedactedValue
+	}
+	return value
+}
+
+// resolveValue evaluates lazy field values and returns all other values unchanged.
+func resolveValue(value any) any {
+	if lazy, ok := value.(lazyVal); ok {
+		return lazy()
+	}
+	return value
+}
+
+// appendField adds a single resolved value to the event, using typed encoders for numbers and byte slices.
+func appendField(event *zerolog.Event, key string, value any) *zerolog.Event {
+	switch v := resolveValue(value).(type) {
+	case int:
+		return event.Int(key, v)
+	case int64:
+		return event.Int64(key, v)
+	case int32:
+		return event.Int32(key, v)
+	case uint:
+		return event.Uint(key, v)
+	case uint64:
+		return event.Uint64(key, v)
+	case uint8:
+		return event.Uint8(key, v)
+	case uint16:
+		return event.Uint16(key, v)
+	case uint32:
+		return event.Uint32(key, v)
+	case float32:
+		return event.Float32(key, v)
+	case []byte:
+		return event.Bytes(key, v)
+	case error:
+		// Encode the message, errors usually have no exported fields and marshal to {}
+		if msg, ok := errorMessage(v); ok {
+			return event.Str(key, msg)
+		}
+		return event.Interface(key, nil)
+	default:
+		return event.Interface(key, v)
+	}
+}
+
+// errorMessage returns the message of err, or false for typed nil errors such as a nil *os.PathError,
+// whose Error method would panic. Those are logged as null like untyped nil errors.
+func errorMessage(err error) (string, bool) {
+	switch v := reflect.ValueOf(err); v.Kind() {
+	case reflect.Invalid:
+		return "", false
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		if v.IsNil() {
+			return "", false
+		}
+	}
+	return err.Error(), true
+}
+
 // Any creates a field with any value.
 func Any(key string, value any) Field {
 	return Field{Key: key, Value: value}
 }
+
+// StackTraceKey is the field key used for captured stack traces.
+const StackTraceKey = "stack_trace"
+
+// stackError wraps an error with the stack captured at the time it was wrapped.
+type stackError struct {
+	err   error
+	stack []byte
+}
+
+func (e *stackError) Error() string {
+	return e.err.Error()
+}
+
+func (e *stackError) Unwrap() error {
+	return e.err
+}
+
+// Wrap annotates err with msg and captures the current stack trace.
+func Wrap(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	return &stackError{
+		err:   fmt.Errorf("%s: %w", msg, err),
+		stack: debug.Stack(),
+	}
+}
+
+// ErrorWithStack creates a stack trace field, preferring the stack captured by Wrap.
+func ErrorWithStack(err error) Field {
+	var se *stackError
+	if errors.As(err, &se) {
+		return Field{Key: StackTraceKey, Value: string(se.stack)}
+	}
+	return Field{Key: StackTraceKey, Value: string(debug.Stack())}
+}
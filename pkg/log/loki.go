@@ -0,0 +1,369 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// lokiPushPath is the Loki HTTP push API endpoint.
+const lokiPushPath = "/loki/api/v1/push"
+
+// LokiLoggerConfig contains configuration for Grafana Loki logging.
+type LokiLoggerConfig struct {
+	URL           string        `mapstructure:"url"`       // base URL of the Loki server, e.g. http://127.0.0.1:3100
+	TenantID      string        `mapstructure:"tenant_id"` // sent as X-Scope-OrgID when set
+	Service       string        `mapstructure:"service"`
+	Environment   string        `mapstructure:"environment"`
+	Source        string        `mapstructure:"source"`
+	BatchSize     int           `mapstructure:"batch_size"`     // entries buffered before a push
+	FlushInterval time.Duration `mapstructure:"flush_interval"` // maximum time an entry stays buffered
+	MaxRetries    int           `mapstructure:"max_retries"`    // retries for 5xx responses
+	Timeout       int           `mapstructure:"timeout"`        // timeout in seconds for each push
+	JsonFormat    bool          `mapstructure:"json_format"`    // whether to use JSON format
+}
+
+// LokiLogger implements Logger interface for Grafana Loki via its HTTP push API.
+type LokiLogger struct {
+	config      *LokiLoggerConfig
+	level       Level
+	contextData map[string]any
+	redact      redactSet
+	client      *lokiClient // shared by loggers derived with WithFields/WithContext
+}
+
+// lokiEntry is a buffered log line with the level used as its stream label.
+type lokiEntry struct {
+	level     string
+	timestamp time.Time
+	line      string
+}
+
+// lokiPushRequest is the body of a Loki push request.
+type lokiPushRequest struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+// lokiStream is a set of log lines sharing the same labels; values are [unix nanoseconds, line] pairs.
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// lokiClient buffers entries and pushes them to Loki from a background goroutine.
+type lokiClient struct {
+	config     *LokiLoggerConfig
+	httpClient *http.Client
+	pushURL    string
+	batch      *batcher[lokiEntry]
+}
+
+func init() {
+	RegisterFactory("loki", NewLokiLoggerFromConfig)
+}
+
+// NewLokiLoggerFromConfig creates a new Loki logger from a Viper configuration.
+func NewLokiLoggerFromConfig(level Level, v *viper.Viper) (Logger, error) {
+	var config LokiLoggerConfig
+	if err := v.Unmarshal(&config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal loki logger config: %w", err)
+	}
+	if config.URL == "" {
+		return nil, fmt.Errorf("loki logger requires a url")
+	}
+
+	return NewLokiLogger(level, &config), nil
+}
+
+// NewLokiLogger creates a new Loki logger and starts its flush goroutine. Call Close to flush pending entries.
+func NewLokiLogger(level Level, config *LokiLoggerConfig) Logger {
+	// Set defaults
+	if config.Service == "" {
+		config.Service = "scaffold"
+	}
+	if config.Environment == "" {
+		config.Environment = "development"
+	}
+	if config.Source == "" {
+		config.Source = "go"
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = 5 * time.Second
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 5 // 5 seconds default timeout
+	}
+
+	client := &lokiClient{
+		config:     config,
+		httpClient: &http.Client{Timeout: time.Duration(config.Timeout) * time.Second},
+		pushURL:    strings.TrimRight(config.URL, "/") + lokiPushPath,
+	}
+	client.batch = newBatcher(config.BatchSize, config.FlushInterval, client.pushEntries)
+
+	return &LokiLogger{
+		config:      config,
+		level:       level,
+		contextData: make(map[string]any),
+		client:      client,
+	}
+}
+
+// pushEntries pushes a batch of entries; a batch is dropped when the push fails to avoid unbounded growth.
+func (c *lokiClient) pushEntries(entries []lokiEntry) {
+	body, err := json.Marshal(c.buildPushRequest(entries))
+	if err != nil {
+		return
+	}
+	// Errors are dropped to avoid logging loops
+	_ = c.push(body)
+}
+
+// buildPushRequest groups entries into one stream per level.
+func (c *lokiClient) buildPushRequest(entries []lokiEntry) lokiPushRequest {
+	streams := make(map[string]*lokiStream)
+	var levels []string
+
+	for _, entry := range entries {
+		stream, ok := streams[entry.level]
+		if !ok {
+			stream = &lokiStream{
+				Stream: map[string]string{
+					"service":     c.config.Service,
+					"environment": c.config.Environment,
+					"source":      c.config.Source,
+					"level":       entry.level,
+				},
+			}
+			streams[entry.level] = stream
+			levels = append(levels, entry.level)
+		}
+		stream.Values = append(stream.Values, [2]string{strconv.FormatInt(entry.timestamp.UnixNano(), 10), entry.line})
+	}
+
+	request := lokiPushRequest{Streams: make([]lokiStream, 0, len(levels))}
+	for _, level := range levels {
+		request.Streams = append(request.Streams, *streams[level])
+	}
+	return request
+}
+
+// push sends body to Loki, retrying server errors with a linear backoff.
+func (c *lokiClient) push(body []byte) error {
+	var lastErr error
+	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(attempt) * 100 * time.Millisecond)
+		}
+
+		req, err := http.NewRequest(http.MethodPost, c.pushURL, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to create loki push request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if c.config.TenantID != "" {
+			req.Header.Set("X-Scope-OrgID", c.config.TenantID)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to push logs to loki at %s: %w", c.pushURL, err)
+			continue
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		switch {
+		case resp.StatusCode >= 500:
+			lastErr = fmt.Errorf("loki push failed with status %d", resp.StatusCode)
+		case resp.StatusCode >= 400:
+			// Client errors are not retried, the same batch would be rejected again
+			return fmt.Errorf("loki rejected push with status %d", resp.StatusCode)
+		default:
+			return nil
+		}
+	}
+	return lastErr
+}
+
+// log formats and buffers an entry if level is enabled.
+func (l *LokiLogger) log(level Level, message string, fields []Field) {
+	if !levelEnabled(l.level, level) {
+		return
+	}
+
+	l.client.batch.add(lokiEntry{
+		level:     string(level),
+		timestamp: time.Now(),
+		line:      l.buildLogLine(string(level), message, fields),
+	})
+}
+
+// buildLogLine creates the log line in either text or JSON format; service and level are stream labels.
+func (l *LokiLogger) buildLogLine(level, message string, fields []Field) string {
+	allFields := make(map[string]any, len(l.contextData)+len(fields))
+	for k, v := range l.contextData {
+		allFields[k] = resolveValue(l.redact.value(k, v))
+	}
+	for _, field := range fields {
+		allFields[field.Key] = resolveValue(l.redact.value(field.Key, field.Value))
+	}
+	for k, v := range allFields {
+		if err, ok := v.(error); ok {
+			if msg, ok := errorMessage(err); ok {
+				allFields[k] = msg
+			} else {
+				allFields[k] = nil
+			}
+		}
+	}
+
+	if l.config.JsonFormat {
+		entry := map[string]any{"level": level, "message": message}
+		if len(allFields) > 0 {
+			entry["fields"] = allFields
+		}
+		if data, err := json.Marshal(entry); err == nil {
+			return string(data)
+		}
+		// If we can't marshal, fall back to text format
+	}
+
+	keys := make([]string, 0, len(allFields))
+	for k := range allFields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "level=%s", level)
+	for _, k := range keys {
+		fmt.Fprintf(&b, " %s=%v", k, allFields[k])
+	}
+	fmt.Fprintf(&b, " msg=%q", message)
+	return b.String()
+}
+
+// Debug logs a debug message.
+func (l *LokiLogger) Debug(msg string, fields ...Field) {
+	l.log(DebugLevel, msg, fields)
+}
+
+// Info logs an info message.
+func (l *LokiLogger) Info(msg string, fields ...Field) {
+	l.log(InfoLevel, msg, fields)
+}
+
+// Warn logs a warning message.
+func (l *LokiLogger) Warn(msg string, fields ...Field) {
+	l.log(WarnLevel, msg, fields)
+}
+
+// Error logs an error message.
+func (l *LokiLogger) Error(msg string, fields ...Field) {
+	l.log(ErrorLevel, msg, fields)
+}
+
+// Fatal logs a fatal message and flushes the buffer, since the process is usually about to exit.
+func (l *LokiLogger) Fatal(msg string, fields ...Field) {
+	l.log(FatalLevel, msg, fields)
+	l.client.batch.flushNow()
+}
+
+// Panic logs a panic message and flushes the buffer.
+func (l *LokiLogger) Panic(msg string, fields ...Field) {
+	l.log(PanicLevel, msg, fields)
+	l.client.batch.flushNow()
+}
+
+// Formatted logging methods
+func (l *LokiLogger) Debugf(format string, args ...interface{}) {
+	l.Debug(fmt.Sprintf(format, args...))
+}
+
+func (l *LokiLogger) Infof(format string, args ...interface{}) {
+	l.Info(fmt.Sprintf(format, args...))
+}
+
+func (l *LokiLogger) Warnf(format string, args ...interface{}) {
+	l.Warn(fmt.Sprintf(format, args...))
+}
+
+func (l *LokiLogger) Errorf(format string, args ...interface{}) {
+	l.Error(fmt.Sprintf(format, args...))
+}
+
+func (l *LokiLogger) Fatalf(format string, args ...interface{}) {
+	l.Fatal(fmt.Sprintf(format, args...))
+}
+
+func (l *LokiLogger) Panicf(format string, args ...interface{}) {
+	l.Panic(fmt.Sprintf(format, args...))
+}
+
+// WithFields creates a new logger with additional context fields.
+func (l *LokiLogger) WithFields(fields ...Field) Logger {
+	newContextData := make(map[string]any, len(l.contextData)+len(fields))
+
+	// Copy existing context data
+	for k, v := range l.contextData {
+		newContextData[k] = v
+	}
+
+	// Add new fields
+	for _, field := range fields {
+		newContextData[field.Key] = field.Value
+	}
+
+	return &LokiLogger{
+		config:      l.config,
+		level:       l.level,
+		contextData: newContextData,
+		redact:      l.redact,
+		client:      l.client, // Share buffer
+	}
+}
+
+// WithRedactedKeys creates a new logger that redacts the values of fields with the given keys.
+func (l *LokiLogger) WithRedactedKeys(keys ...string) Logger {
+	return &LokiLogger{
+		config:      l.config,
+		level:       l.level,
+		contextData: l.contextData,
+		redact:      l.redact.with(keys...),
+		client:      l.client, // Share buffer
+	}
+}
+
+// WithContext creates a new logger with context.
+func (l *LokiLogger) WithContext(ctx context.Context) Logger {
+	return &LokiLogger{
+		config:      l.config,
+		level:       l.level,
+		contextData: l.contextData,
+		redact:      l.redact,
+		client:      l.client, // Share buffer
+	}
+}
+
+// Close stops the background flush and pushes any buffered entries to Loki.
+// The buffer is shared with derived loggers, which must not be used afterwards.
+func (l *LokiLogger) Close() error {
+	l.client.batch.close()
+	return nil
+}
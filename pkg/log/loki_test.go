@@ -0,0 +1,248 @@
+package log
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// mockLokiServer records push requests and answers with the next status from statuses (204 once exhausted)
+type mockLokiServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	pushes   []lokiPushRequest
+	tenants  []string
+	attempts atomic.Int32
+	received chan struct{}
+}
+
+func newMockLokiServer(t *testing.T, statuses ...int) *mockLokiServer {
+	t.Helper()
+	m := &mockLokiServer{received: make(chan struct{}, 10)}
+	m.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempt := int(m.attempts.Add(1)) - 1
+		if r.URL.Path != lokiPushPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if attempt < len(statuses) {
+			w.WriteHeader(statuses[attempt])
+			return
+		}
+
+		var push lokiPushRequest
+		if err := json.NewDecoder(r.Body).Decode(&push); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		m.mu.Lock()
+		m.pushes = append(m.pushes, push)
+		m.tenants = append(m.tenants, r.Header.Get("X-Scope-OrgID"))
+		m.mu.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+		m.received <- struct{}{}
+	}))
+	t.Cleanup(m.Close)
+	return m
+}
+
+// wait blocks until a push was accepted
+func (m *mockLokiServer) wait(t *testing.T) {
+	t.Helper()
+	select {
+	case <-m.received:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for Loki push")
+	}
+}
+
+func (m *mockLokiServer) accepted() []lokiPushRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]lokiPushRequest(nil), m.pushes...)
+}
+
+// countValues returns the number of log lines across all streams of a push
+func countValues(push lokiPushRequest) int {
+	count := 0
+	for _, stream := range push.Streams {
+		count += len(stream.Values)
+	}
+	return count
+}
+
+func TestLokiLoggerBatchSize(t *testing.T) {
+	server := newMockLokiServer(t)
+	logger := NewLokiLogger(InfoLevel, &LokiLoggerConfig{
+		URL:           server.URL,
+		TenantID:      "team-a",
+		BatchSize:     3,
+		FlushInterval: time.Hour,
+	}).(*LokiLogger)
+	defer logger.Close()
+
+	logger.Info("first")
+	logger.Info("second")
+	select {
+	case <-server.received:
+		t.Fatal("Expected no push before the batch is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	logger.Warn("third")
+	server.wait(t)
+
+	pushes := server.accepted()
+	if len(pushes) != 1 {
+		t.Fatalf("Expected 1 push, got %d", len(pushes))
+	}
+	if got := countValues(pushes[0]); got != 3 {
+		t.Errorf("Expected 3 entries in the batch, got %d", got)
+	}
+	if len(pushes[0].Streams) != 2 {
+		t.Errorf("Expected one stream per level, got %d", len(pushes[0].Streams))
+	}
+	if server.tenants[0] != "team-a" {
+		t.Errorf("Expected X-Scope-OrgID team-a, got %q", server.tenants[0])
+	}
+}
+
+func TestLokiLoggerFlushInterval(t *testing.T) {
+	server := newMockLokiServer(t)
+	logger := NewLokiLogger(InfoLevel, &LokiLoggerConfig{
+		URL:           server.URL,
+		BatchSize:     100,
+		FlushInterval: 20 * time.Millisecond,
+	}).(*LokiLogger)
+	defer logger.Close()
+
+	logger.Info("flushed by ticker")
+	server.wait(t)
+
+	if got := countValues(server.accepted()[0]); got != 1 {
+		t.Errorf("Expected 1 entry, got %d", got)
+	}
+}
+
+func TestLokiLoggerStreamFormat(t *testing.T) {
+	server := newMockLokiServer(t)
+	logger := NewLokiLogger(InfoLevel, &LokiLoggerConfig{
+		URL:           server.URL,
+		Service:       "test-service",
+		Environment:   "test",
+		BatchSize:     100,
+		FlushInterval: time.Hour,
+		JsonFormat:    true,
+	})
+
+	before := time.Now().UnixNano()
+	logger.WithFields(String("request_id", "123")).Info("hello")
+	logger.Debug("filtered by level")
+	logger.(*LokiLogger).Close()
+
+	pushes := server.accepted()
+	if len(pushes) != 1 || len(pushes[0].Streams) != 1 {
+		t.Fatalf("Expected a single stream, got %+v", pushes)
+	}
+
+	stream := pushes[0].Streams[0]
+	expectedLabels := map[string]string{"service": "test-service", "environment": "test", "source": "go", "level": "info"}
+	for k, v := range expectedLabels {
+		if stream.Stream[k] != v {
+			t.Errorf("Expected label %s=%s, got %q", k, v, stream.Stream[k])
+		}
+	}
+	if len(stream.Values) != 1 {
+		t.Fatalf("Expected 1 value, got %d", len(stream.Values))
+	}
+
+	var timestamp int64
+	if err := json.Unmarshal([]byte(stream.Values[0][0]), &timestamp); err != nil || timestamp < before {
+		t.Errorf("Expected a unix nanosecond timestamp, got %q", stream.Values[0][0])
+	}
+
+	var line map[string]any
+	if err := json.Unmarshal([]byte(stream.Values[0][1]), &line); err != nil {
+		t.Fatalf("Expected a JSON log line, got %q", stream.Values[0][1])
+	}
+	if line["message"] != "hello" {
+		t.Errorf("Expected message hello, got %v", line["message"])
+	}
+	if fields, _ := line["fields"].(map[string]any); fields["request_id"] != "123" {
+		t.Errorf("Expected request_id field, got %v", line["fields"])
+	}
+}
+
+func TestLokiLoggerRetriesServerErrors(t *testing.T) {
+	testCases := []struct {
+		name       string
+		statuses   []int
+		maxRetries int
+		accepted   int
+	}{
+		{"retries 5xx", []int{http.StatusInternalServerError, http.StatusServiceUnavailable}, 2, 1},
+		{"gives up after max retries", []int{http.StatusBadGateway, http.StatusBadGateway}, 1, 0},
+		{"does not retry 4xx", []int{http.StatusBadRequest}, 3, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newMockLokiServer(t, tc.statuses...)
+			logger := NewLokiLogger(InfoLevel, &LokiLoggerConfig{
+				URL:           server.URL,
+				BatchSize:     100,
+				FlushInterval: time.Hour,
+				MaxRetries:    tc.maxRetries,
+			}).(*LokiLogger)
+
+			logger.Info("retried")
+			logger.Close()
+
+			if got := len(server.accepted()); got != tc.accepted {
+				t.Errorf("Expected %d accepted pushes, got %d (attempts %d)", tc.accepted, got, server.attempts.Load())
+			}
+		})
+	}
+}
+
+func TestLokiLoggerRegistration(t *testing.T) {
+	server := newMockLokiServer(t)
+
+	v := viper.New()
+	v.Set("log.level", "info")
+	v.Set("log.loggers.loki.driver", "loki")
+	v.Set("log.loggers.loki.enabled", true)
+	v.Set("log.loggers.loki.url", server.URL)
+	v.Set("log.loggers.loki.batch_size", 10)
+	v.Set("log.loggers.loki.flush_interval", "1s")
+
+	logger, err := CreateLoggerFromConfig(v)
+	if err != nil {
+		t.Fatalf("Failed to create logger from config: %v", err)
+	}
+	lokiLogger, ok := logger.(*LokiLogger)
+	if !ok {
+		t.Fatalf("Expected *LokiLogger, got %T", logger)
+	}
+	defer lokiLogger.Close()
+
+	if lokiLogger.config.FlushInterval != time.Second {
+		t.Errorf("Expected flush_interval 1s, got %v", lokiLogger.config.FlushInterval)
+	}
+	if lokiLogger.config.BatchSize != 10 {
+		t.Errorf("Expected batch_size 10, got %d", lokiLogger.config.BatchSize)
+	}
+
+	// The url is required
+	v.Set("log.loggers.loki.url", "")
+	if _, err := CreateLoggerFromConfig(v); err == nil {
+		t.Error("Expected an error without a url")
+	}
+}
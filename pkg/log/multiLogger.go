@@ -2,6 +2,9 @@ package log
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 )
 
 // MultiLogger implements Logger interface and forwards logs to multiple loggers.
@@ -47,55 +50,107 @@ func (m *MultiLogger) Error(msg string, fields ...Field) {
 	}
 }
 
-// Fatal logs a fatal message to all underlying loggers and exits.
+// Fatal logs the message at error level to every underlying logger but the last, which logs it
+// as fatal and exits, so every output receives it before the process stops.
 func (m *MultiLogger) Fatal(msg string, fields ...Field) {
-	for _, logger := range m.loggers {
-		logger.Fatal(msg, fields...)
+	if last := m.errorAllButLast(msg, fields); last != nil {
+		last.Fatal(msg, fields...)
 	}
 }
 
-// Panic logs a panic message to all underlying loggers and panics.
+// Panic logs the message at error level to every underlying logger but the last, which logs it
+// at panic level and panics.
 func (m *MultiLogger) Panic(msg string, fields ...Field) {
-	for _, logger := range m.loggers {
-		logger.Panic(msg, fields...)
+	if last := m.errorAllButLast(msg, fields); last != nil {
+		last.Panic(msg, fields...)
+	}
+}
+
+// errorAllButLast logs msg at error level to every underlying logger but the last one, which it returns.
+// Only one logger may exit or panic, the others would never receive the message.
+func (m *MultiLogger) errorAllButLast(msg string, fields []Field) Logger {
+	if len(m.loggers) == 0 {
+		return nil
+	}
+	for _, logger := range m.loggers[:len(m.loggers)-1] {
+		logger.Error(msg, fields...)
 	}
+	return m.loggers[len(m.loggers)-1]
 }
 
-// Formatted logging methods
+// Debugf logs a formatted debug message to all underlying loggers.
 func (m *MultiLogger) Debugf(format string, args ...interface{}) {
 	for _, logger := range m.loggers {
 		logger.Debugf(format, args...)
 	}
 }
 
+// Infof logs a formatted info message to all underlying loggers.
 func (m *MultiLogger) Infof(format string, args ...interface{}) {
 	for _, logger := range m.loggers {
 		logger.Infof(format, args...)
 	}
 }
 
+// Warnf logs a formatted warning message to all underlying loggers.
 func (m *MultiLogger) Warnf(format string, args ...interface{}) {
 	for _, logger := range m.loggers {
 		logger.Warnf(format, args...)
 	}
 }
 
+// Errorf logs a formatted error message to all underlying loggers.
 func (m *MultiLogger) Errorf(format string, args ...interface{}) {
 	for _, logger := range m.loggers {
 		logger.Errorf(format, args...)
 	}
 }
 
+// Fatalf logs a formatted message like Fatal.
 func (m *MultiLogger) Fatalf(format string, args ...interface{}) {
+	m.Fatal(fmt.Sprintf(format, args...))
+}
+
+// Panicf logs a formatted message like Panic.
+func (m *MultiLogger) Panicf(format string, args ...interface{}) {
+	m.Panic(fmt.Sprintf(format, args...))
+}
+
+// SetLevel changes the minimum level of every sub-logger that supports it.
+func (m *MultiLogger) SetLevel(level Level) {
 	for _, logger := range m.loggers {
-		logger.Fatalf(format, args...)
+		if controller, ok := logger.(LevelController); ok {
+			controller.SetLevel(level)
+		}
 	}
 }
 
-func (m *MultiLogger) Panicf(format string, args ...interface{}) {
+// GetLevel returns the most verbose level of the sub-loggers that support SetLevel.
+func (m *MultiLogger) GetLevel() Level {
+	var current Level
 	for _, logger := range m.loggers {
-		logger.Panicf(format, args...)
+		controller, ok := logger.(LevelController)
+		if !ok {
+			continue
+		}
+		if level := controller.GetLevel(); current == "" || levelRank[level] < levelRank[current] {
+			current = level
+		}
 	}
+	return current
+}
+
+// Close closes every sub-logger that implements io.Closer, flushing buffered entries.
+func (m *MultiLogger) Close() error {
+	var errs []error
+	for _, logger := range m.loggers {
+		if closer, ok := logger.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
 }
 
 // WithFields creates a new multi-logger with additional context fields.
@@ -105,13 +160,19 @@ func (m *MultiLogger) WithFields(fields ...Field) Logger {
 		newLoggers[i] = logger.WithFields(fields...)
 	}
 
+	contextData := m.copyContextData()
+	for _, field := range fields {
+		contextData[field.Key] = field.Value
+	}
+
 	return &MultiLogger{
 		loggers:     newLoggers,
-		contextData: m.contextData,
+		contextData: contextData,
 	}
 }
 
 // WithContext creates a new multi-logger with context.
+// Each sub-logger derives its own context logger, keeping fields added with WithFields.
 func (m *MultiLogger) WithContext(ctx context.Context) Logger {
 	newLoggers := make([]Logger, len(m.loggers))
 	for i, logger := range m.loggers {
@@ -120,6 +181,28 @@ func (m *MultiLogger) WithContext(ctx context.Context) Logger {
 
 	return &MultiLogger{
 		loggers:     newLoggers,
-		contextData: m.contextData,
+		contextData: m.copyContextData(),
+	}
+}
+
+// WithRedactedKeys creates a new multi-logger whose sub-loggers redact the given field keys.
+func (m *MultiLogger) WithRedactedKeys(keys ...string) Logger {
+	newLoggers := make([]Logger, len(m.loggers))
+	for i, logger := range m.loggers {
+		newLoggers[i] = logger.WithRedactedKeys(keys...)
+	}
+
+	return &MultiLogger{
+		loggers:     newLoggers,
+		contextData: m.copyContextData(),
+	}
+}
+
+// copyContextData returns a copy of the context data so derived loggers do not share the map.
+func (m *MultiLogger) copyContextData() map[string]any {
+	contextData := make(map[string]any, len(m.contextData))
+	for k, v := range m.contextData {
+		contextData[k] = v
 	}
+	return contextData
 }
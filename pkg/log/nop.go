@@ -0,0 +1,65 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// NopLogger discards every message. Fatal and Panic still exit and panic like the other loggers,
+// so failures are not silently ignored.
+type NopLogger struct{}
+
+// NewNopLogger creates a logger that discards every message, for packages logging nothing until given a logger.
+func NewNopLogger() Logger {
+	return NopLogger{}
+}
+
+// Debug, Info, Warn and Error discard the message
+func (NopLogger) Debug(msg string, fields ...Field) {}
+
+func (NopLogger) Info(msg string, fields ...Field) {}
+
+func (NopLogger) Warn(msg string, fields ...Field) {}
+
+func (NopLogger) Error(msg string, fields ...Field) {}
+
+// Fatal exits the process without logging.
+func (NopLogger) Fatal(msg string, fields ...Field) {
+	os.Exit(1)
+}
+
+// Panic panics with msg without logging.
+func (NopLogger) Panic(msg string, fields ...Field) {
+	panic(msg)
+}
+
+// Formatted logging methods, formatted like the structured ones
+func (NopLogger) Debugf(format string, args ...interface{}) {}
+
+func (NopLogger) Infof(format string, args ...interface{}) {}
+
+func (NopLogger) Warnf(format string, args ...interface{}) {}
+
+func (NopLogger) Errorf(format string, args ...interface{}) {}
+
+func (l NopLogger) Fatalf(format string, args ...interface{}) {
+	l.Fatal(fmt.Sprintf(format, args...))
+}
+
+func (l NopLogger) Panicf(format string, args ...interface{}) {
+	l.Panic(fmt.Sprintf(format, args...))
+}
+
+// WithFields, WithContext and WithRedactedKeys return the logger itself
+func (l NopLogger) WithFields(fields ...Field) Logger {
+	return l
+}
+
+func (l NopLogger) WithContext(ctx context.Context) Logger {
+	return l
+}
+
+func (l NopLogger) WithRedactedKeys(keys ...string) Logger {
+	return l
+}
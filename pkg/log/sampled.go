@@ -0,0 +1,131 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync/atomic"
+)
+
+// SampledLogger forwards a fraction of Debug and Info messages to the wrapped logger.
+// Warn, Error, Fatal and Panic messages are always forwarded.
+type SampledLogger struct {
+	base  Logger
+	state *samplingState // shared by loggers derived with WithFields/WithContext
+}
+
+// samplingState holds the sampling rate and message counter without locks.
+type samplingState struct {
+	rate    atomic.Uint64 // math.Float64bits of the rate
+	counter atomic.Uint64
+}
+
+// NewSampledLogger wraps base so that roughly rate (0..1) of Debug and Info messages are emitted.
+func NewSampledLogger(base Logger, rate float64) Logger {
+	state := &samplingState{}
+	state.setRate(rate)
+	return &SampledLogger{base: base, state: state}
+}
+
+// setRate stores rate clamped to [0, 1].
+func (s *samplingState) setRate(rate float64) {
+	s.rate.Store(math.Float64bits(math.Max(0, math.Min(1, rate))))
+}
+
+// sample reports whether the next message is emitted. Counting messages instead of drawing random
+// numbers spreads emitted messages evenly, e.g. every 10th message at rate 0.1.
+func (s *samplingState) sample() bool {
+	rate := math.Float64frombits(s.rate.Load())
+	switch {
+	case rate >= 1:
+		return true
+	case rate <= 0:
+		return false
+	}
+
+	n := s.counter.Add(1)
+	return math.Floor(float64(n)*rate) > math.Floor(float64(n-1)*rate)
+}
+
+// SetSamplingRate changes the sampling rate at runtime for this logger and the loggers derived from it.
+func (l *SampledLogger) SetSamplingRate(rate float64) {
+	l.state.setRate(rate)
+}
+
+// Debug logs a sampled debug message.
+func (l *SampledLogger) Debug(msg string, fields ...Field) {
+	if l.state.sample() {
+		l.base.Debug(msg, fields...)
+	}
+}
+
+// Info logs a sampled info message.
+func (l *SampledLogger) Info(msg string, fields ...Field) {
+	if l.state.sample() {
+		l.base.Info(msg, fields...)
+	}
+}
+
+// Warn logs a warning message.
+func (l *SampledLogger) Warn(msg string, fields ...Field) {
+	l.base.Warn(msg, fields...)
+}
+
+// Error logs an error message.
+func (l *SampledLogger) Error(msg string, fields ...Field) {
+	l.base.Error(msg, fields...)
+}
+
+// Fatal logs a fatal message.
+func (l *SampledLogger) Fatal(msg string, fields ...Field) {
+	l.base.Fatal(msg, fields...)
+}
+
+// Panic logs a panic message.
+func (l *SampledLogger) Panic(msg string, fields ...Field) {
+	l.base.Panic(msg, fields...)
+}
+
+// Formatted logging methods, sampled messages are not formatted when dropped
+func (l *SampledLogger) Debugf(format string, args ...interface{}) {
+	if l.state.sample() {
+		l.base.Debug(fmt.Sprintf(format, args...))
+	}
+}
+
+func (l *SampledLogger) Infof(format string, args ...interface{}) {
+	if l.state.sample() {
+		l.base.Info(fmt.Sprintf(format, args...))
+	}
+}
+
+func (l *SampledLogger) Warnf(format string, args ...interface{}) {
+	l.base.Warnf(format, args...)
+}
+
+func (l *SampledLogger) Errorf(format string, args ...interface{}) {
+	l.base.Errorf(format, args...)
+}
+
+func (l *SampledLogger) Fatalf(format string, args ...interface{}) {
+	l.base.Fatalf(format, args...)
+}
+
+func (l *SampledLogger) Panicf(format string, args ...interface{}) {
+	l.base.Panicf(format, args...)
+}
+
+// WithFields creates a new sampled logger wrapping the base logger with additional context fields.
+func (l *SampledLogger) WithFields(fields ...Field) Logger {
+	return &SampledLogger{base: l.base.WithFields(fields...), state: l.state}
+}
+
+// WithContext creates a new sampled logger wrapping the base logger with context.
+func (l *SampledLogger) WithContext(ctx context.Context) Logger {
+	return &SampledLogger{base: l.base.WithContext(ctx), state: l.state}
+}
+
+// WithRedactedKeys creates a new sampled logger whose base logger redacts the given field keys.
+func (l *SampledLogger) WithRedactedKeys(keys ...string) Logger {
+	return &SampledLogger{base: l.base.WithRedactedKeys(keys...), state: l.state}
+}
@@ -0,0 +1,141 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// lineCount returns the number of log lines containing msg
+func lineCount(output, msg string) int {
+	count := 0
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, msg) {
+			count++
+		}
+	}
+	return count
+}
+
+func TestSampledLoggerRates(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rate     float64
+		expected int
+	}{
+		{"ten percent", 0.1, 100},
+		{"half", 0.5, 500},
+		{"all", 1, 1000},
+		{"none", 0, 0},
+		{"clamped above one", 2, 1000},
+		{"clamped below zero", -1, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := NewSampledLogger(NewConsoleLoggerWithWriter(DebugLevel, &buf, false), tc.rate)
+
+			for i := 0; i < 500; i++ {
+				logger.Info("sampled info")
+				logger.Debugf("sampled %s", "debug")
+			}
+			for i := 0; i < 20; i++ {
+				logger.Warn("always warn")
+				logger.Errorf("always %s", "error")
+			}
+
+			output := buf.String()
+			if got := lineCount(output, `"message":"sampled`); got != tc.expected {
+				t.Errorf("Expected %d sampled messages, got %d", tc.expected, got)
+			}
+			if got := lineCount(output, `"message":"always`); got != 40 {
+				t.Errorf("Expected all 40 warn and error messages, got %d", got)
+			}
+		})
+	}
+}
+
+func TestSampledLoggerSetSamplingRate(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewSampledLogger(NewConsoleLoggerWithWriter(InfoLevel, &buf, false), 0).(*SampledLogger)
+	child := logger.WithFields(String("request_id", "123")).WithContext(context.Background())
+
+	child.Info("dropped")
+	logger.SetSamplingRate(1)
+	child.Info("emitted")
+
+	output := buf.String()
+	if contains(output, "dropped") {
+		t.Errorf("Expected message to be dropped at rate 0, got: %s", output)
+	}
+	if !contains(output, "emitted") || !contains(output, `"request_id":"123"`) {
+		t.Errorf("Expected child to follow the new rate and keep its fields, got: %s", output)
+	}
+	if _, ok := child.(*SampledLogger); !ok {
+		t.Errorf("Expected derived logger to be a *SampledLogger, got %T", child)
+	}
+}
+
+func TestSampledLoggerConcurrent(t *testing.T) {
+	var buf safeBuffer
+	logger := NewSampledLogger(NewConsoleLoggerWithWriter(InfoLevel, &buf, false), 0.25)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				logger.Info("concurrent")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := lineCount(buf.String(), "concurrent"); got != 200 {
+		t.Errorf("Expected 200 sampled messages, got %d", got)
+	}
+}
+
+// safeBuffer is a bytes.Buffer safe for concurrent writes
+type safeBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *safeBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *safeBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// BenchmarkSampledLogger compares the unwrapped console logger against sampling rates below 0.5
+func BenchmarkSampledLogger(b *testing.B) {
+	base := NewConsoleLoggerWithWriter(InfoLevel, io.Discard, false)
+	cases := []struct {
+		name   string
+		logger Logger
+	}{
+		{"base", base},
+		{"rate_0.4", NewSampledLogger(base, 0.4)},
+		{"rate_0.1", NewSampledLogger(base, 0.1)},
+	}
+
+	for _, bc := range cases {
+		b.Run(bc.name, func(b *testing.B) {
+			runParallel(b, func() {
+				bc.logger.Info("Sampled benchmark message", String("key", "value"))
+			})
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/spf13/viper"
+)
+
+// SentryLoggerConfig contains configuration for Sentry error reporting.
+type SentryLoggerConfig struct {
+	DSN          string `mapstructure:"dsn"`
+	Environment  string `mapstructure:"environment"`
+	Release      string `mapstructure:"release"`
+	FlushTimeout int    `mapstructure:"flush_timeout"` // seconds to wait for pending events on Fatal, Panic and Close
+}
+
+// SentryLogger implements Logger interface by capturing Warn, Error, Fatal and Panic messages as Sentry events.
+// Debug and Info messages are ignored.
+type SentryLogger struct {
+	config      *SentryLoggerConfig
+	level       Level
+	contextData map[string]any
+	redact      redactSet
+	hub         *sentry.Hub
+}
+
+func init() {
+	RegisterFactory("sentry", NewSentryLoggerFromConfig)
+}
+
+// NewSentryLoggerFromConfig creates a new Sentry logger from a Viper configuration.
+func NewSentryLoggerFromConfig(level Level, v *viper.Viper) (Logger, error) {
+	var config SentryLoggerConfig
+	if err := v.Unmarshal(&config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sentry logger config: %w", err)
+	}
+	if config.DSN == "" {
+		return nil, fmt.Errorf("sentry logger requires a dsn")
+	}
+
+	return NewSentryLogger(level, &config)
+}
+
+// NewSentryLogger creates a new Sentry logger with its own client and hub.
+func NewSentryLogger(level Level, config *SentryLoggerConfig) (Logger, error) {
+	return newSentryLogger(level, config, sentry.ClientOptions{})
+}
+
+// newSentryLogger creates a Sentry logger on top of options, e.g. with a BeforeSend hook in tests.
+func newSentryLogger(level Level, config *SentryLoggerConfig, options sentry.ClientOptions) (Logger, error) {
+	// Set defaults
+	if config.Environment == "" {
+		config.Environment = "development"
+	}
+	if config.FlushTimeout == 0 {
+		config.FlushTimeout = 2
+	}
+
+	options.Dsn = config.DSN
+	options.Environment = config.Environment
+	options.Release = config.Release
+
+	client, err := sentry.NewClient(options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sentry client: %w", err)
+	}
+
+	return &SentryLogger{
+		config:      config,
+		level:       level,
+		contextData: make(map[string]any),
+		hub:         sentry.NewHub(client, sentry.NewScope()),
+	}, nil
+}
+
+// sentryLevels maps log levels to Sentry event levels.
+var sentryLevels = map[Level]sentry.Level{
+	WarnLevel:  sentry.LevelWarning,
+	ErrorLevel: sentry.LevelError,
+	FatalLevel: sentry.LevelFatal,
+	PanicLevel: sentry.LevelFatal,
+}
+
+// capture sends a Sentry event with the context data and fields as extras if level is enabled.
+func (l *SentryLogger) capture(level Level, message string, fields []Field) {
+	if !levelEnabled(l.level, level) {
+		return
+	}
+
+	extra := make(map[string]interface{}, len(l.contextData)+len(fields))
+	for k, v := range l.contextData {
+		extra[k] = resolveValue(l.redact.value(k, v))
+	}
+	for _, field := range fields {
+		extra[field.Key] = resolveValue(l.redact.value(field.Key, field.Value))
+	}
+	for k, v := range extra {
+		if err, ok := v.(error); ok {
+			if msg, ok := errorMessage(err); ok {
+				extra[k] = msg
+			} else {
+				extra[k] = nil
+			}
+		}
+	}
+
+	event := sentry.NewEvent()
+	event.Level = sentryLevels[level]
+	event.Message = message
+	event.Extra = extra
+	l.hub.CaptureEvent(event)
+}
+
+// flush waits up to the configured flush timeout for pending events.
+func (l *SentryLogger) flush() bool {
+	return l.hub.Flush(time.Duration(l.config.FlushTimeout) * time.Second)
+}
+
+// Debug is a no-op, debug messages are not sent to Sentry.
+func (l *SentryLogger) Debug(msg string, fields ...Field) {}
+
+// Info is a no-op, info messages are not sent to Sentry.
+func (l *SentryLogger) Info(msg string, fields ...Field) {}
+
+// Warn captures a warning event.
+func (l *SentryLogger) Warn(msg string, fields ...Field) {
+	l.capture(WarnLevel, msg, fields)
+}
+
+// Error captures an error event.
+func (l *SentryLogger) Error(msg string, fields ...Field) {
+	l.capture(ErrorLevel, msg, fields)
+}
+
+// Fatal captures a fatal event and flushes, since the process is usually about to exit.
+func (l *SentryLogger) Fatal(msg string, fields ...Field) {
+	l.capture(FatalLevel, msg, fields)
+	l.flush()
+}
+
+// Panic captures a fatal event and flushes.
+func (l *SentryLogger) Panic(msg string, fields ...Field) {
+	l.capture(PanicLevel, msg, fields)
+	l.flush()
+}
+
+// Formatted logging methods
+func (l *SentryLogger) Debugf(format string, args ...interface{}) {}
+
+func (l *SentryLogger) Infof(format string, args ...interface{}) {}
+
+func (l *SentryLogger) Warnf(format string, args ...interface{}) {
+	l.Warn(fmt.Sprintf(format, args...))
+}
+
+func (l *SentryLogger) Errorf(format string, args ...interface{}) {
+	l.Error(fmt.Sprintf(format, args...))
+}
+
+func (l *SentryLogger) Fatalf(format string, args ...interface{}) {
+	l.Fatal(fmt.Sprintf(format, args...))
+}
+
+func (l *SentryLogger) Panicf(format string, args ...interface{}) {
+	l.Panic(fmt.Sprintf(format, args...))
+}
+
+// WithFields creates a new logger with additional context fields, sent as event extras.
+func (l *SentryLogger) WithFields(fields ...Field) Logger {
+	newContextData := make(map[string]any, len(l.contextData)+len(fields))
+
+	// Copy existing context data
+	for k, v := range l.contextData {
+		newContextData[k] = v
+	}
+
+	// Add new fields
+	for _, field := range fields {
+		newContextData[field.Key] = field.Value
+	}
+
+	return &SentryLogger{
+		config:      l.config,
+		level:       l.level,
+		contextData: newContextData,
+		redact:      l.redact,
+		hub:         l.hub,
+	}
+}
+
+// WithRedactedKeys creates a new logger that redacts the values of fields with the given keys.
+func (l *SentryLogger) WithRedactedKeys(keys ...string) Logger {
+	return &SentryLogger{
+		config:      l.config,
+		level:       l.level,
+		contextData: l.contextData,
+		redact:      l.redact.with(keys...),
+		hub:         l.hub,
+	}
+}
+
+// WithContext creates a new logger that uses the Sentry hub stored in ctx, e.g. by the Sentry HTTP middleware,
+// so events carry the request scope. Without one the current hub is kept.
+func (l *SentryLogger) WithContext(ctx context.Context) Logger {
+	hub := l.hub
+	if ctxHub := sentry.GetHubFromContext(ctx); ctxHub != nil {
+		hub = ctxHub
+	}
+
+	return &SentryLogger{
+		config:      l.config,
+		level:       l.level,
+		contextData: l.contextData,
+		redact:      l.redact,
+		hub:         hub,
+	}
+}
+
+// Close waits for pending events to be sent.
+func (l *SentryLogger) Close() error {
+	if !l.flush() {
+		return fmt.Errorf("timed out flushing sentry events after %ds", l.config.FlushTimeout)
+	}
+	return nil
+}
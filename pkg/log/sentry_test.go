@@ -0,0 +1,150 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/spf13/viper"
+)
+
+// capturedEvents collects the events passed to BeforeSend and drops them
+type capturedEvents struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+func (c *capturedEvents) beforeSend(event *sentry.Event, _ *sentry.EventHint) *sentry.Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events = append(c.events, event)
+	return nil
+}
+
+func (c *capturedEvents) all() []*sentry.Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*sentry.Event(nil), c.events...)
+}
+
+// newTestSentryLogger creates a Sentry logger that records events instead of sending them
+func newTestSentryLogger(t *testing.T, level Level) (*SentryLogger, *capturedEvents) {
+	t.Helper()
+	captured := &capturedEvents{}
+	logger, err := newSentryLogger(level, &SentryLoggerConfig{Environment: "test", Release: "1.0.0"}, sentry.ClientOptions{
+		BeforeSend: captured.beforeSend,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create sentry logger: %v", err)
+	}
+	return logger.(*SentryLogger), captured
+}
+
+func TestSentryLoggerCapturesOneEventPerError(t *testing.T) {
+	logger, captured := newTestSentryLogger(t, DebugLevel)
+
+	logger.Debug("ignored")
+	logger.Info("ignored")
+	logger.Infof("ignored %d", 1)
+	logger.Error("first failure")
+	logger.Errorf("second %s", "failure")
+
+	events := captured.all()
+	if len(events) != 2 {
+		t.Fatalf("Expected exactly 2 events, got %d", len(events))
+	}
+	for i, want := range []string{"first failure", "second failure"} {
+		if events[i].Message != want {
+			t.Errorf("Expected message %q, got %q", want, events[i].Message)
+		}
+		if events[i].Level != sentry.LevelError {
+			t.Errorf("Expected level error, got %s", events[i].Level)
+		}
+		if events[i].Environment != "test" || events[i].Release != "1.0.0" {
+			t.Errorf("Expected environment and release from config, got %q %q", events[i].Environment, events[i].Release)
+		}
+	}
+}
+
+func TestSentryLoggerLevels(t *testing.T) {
+	logger, captured := newTestSentryLogger(t, ErrorLevel)
+
+	logger.Warn("below configured level")
+	logger.Error("captured")
+
+	events := captured.all()
+	if len(events) != 1 || events[0].Message != "captured" {
+		t.Fatalf("Expected only the error event, got %d events", len(events))
+	}
+
+	logger, captured = newTestSentryLogger(t, InfoLevel)
+	logger.Warn("warning")
+	if events := captured.all(); len(events) != 1 || events[0].Level != sentry.LevelWarning {
+		t.Errorf("Expected one warning event, got %+v", events)
+	}
+}
+
+func TestSentryLoggerFieldsAsExtra(t *testing.T) {
+	logger, captured := newTestSentryLogger(t, InfoLevel)
+
+	logger.WithFields(String("request_id", "123")).
+		WithRedactedKeys("password").
+		Error("Login failed", Err(errors.New("bad credentials")), String("password", "password123"))
+
+	events := captured.all()
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+	expected := map[string]interface{}{"request_id": "123", "err": "bad credentials", "password": RedactedValue}
+	for k, v := range expected {
+		if events[0].Extra[k] != v {
+			t.Errorf("Expected extra %s=%v, got %v", k, v, events[0].Extra[k])
+		}
+	}
+}
+
+func TestSentryLoggerWithContextHub(t *testing.T) {
+	logger, loggerEvents := newTestSentryLogger(t, InfoLevel)
+
+	// A hub stored in the context, e.g. by the Sentry HTTP middleware, takes precedence
+	requestEvents := &capturedEvents{}
+	client, err := sentry.NewClient(sentry.ClientOptions{BeforeSend: requestEvents.beforeSend})
+	if err != nil {
+		t.Fatalf("Failed to create sentry client: %v", err)
+	}
+	ctx := sentry.SetHubOnContext(context.Background(), sentry.NewHub(client, sentry.NewScope()))
+
+	logger.WithContext(ctx).Error("from request")
+	logger.WithContext(context.Background()).Error("without hub")
+
+	if got := len(requestEvents.all()); got != 1 {
+		t.Errorf("Expected 1 event on the context hub, got %d", got)
+	}
+	if got := len(loggerEvents.all()); got != 1 {
+		t.Errorf("Expected 1 event on the logger hub, got %d", got)
+	}
+}
+
+func TestSentryLoggerRegistration(t *testing.T) {
+	v := viper.New()
+	v.Set("log.loggers.sentry.driver", "sentry")
+	v.Set("log.loggers.sentry.enabled", true)
+	v.Set("log.loggers.sentry.dsn", "https://public@sentry.example.com/1")
+	v.Set("log.loggers.sentry.flush_timeout", 1)
+
+	logger, err := CreateLoggerFromConfig(v)
+	if err != nil {
+		t.Fatalf("Failed to create logger from config: %v", err)
+	}
+	if _, ok := logger.(*SentryLogger); !ok {
+		t.Fatalf("Expected *SentryLogger, got %T", logger)
+	}
+
+	// The dsn is required
+	v.Set("log.loggers.sentry.dsn", "")
+	if _, err := CreateLoggerFromConfig(v); err == nil {
+		t.Error("Expected an error without a dsn")
+	}
+}
@@ -0,0 +1,105 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+)
+
+// slogHandler is a slog.Handler logging records through a Logger.
+type slogHandler struct {
+	logger Logger
+	group  string // prefix of the keys of attributes added after WithGroup, eg: "request."
+}
+
+// NewSlogHandler returns a slog.Handler that logs records through l, so code using log/slog shares its backends.
+// Attributes become fields, with the keys of grouped attributes joined by dots, eg: request.method.
+func NewSlogHandler(l Logger) slog.Handler {
+	return &slogHandler{logger: l}
+}
+
+// Enabled reports whether the logger emits records at level, always true for loggers without a level.
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	controller, ok := h.logger.(LevelController)
+	if !ok {
+		return true
+	}
+	return levelEnabled(controller.GetLevel(), fromSlogLevel(level))
+}
+
+// Handle logs the record with the logger method of its level.
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	fields := make([]Field, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = appendSlogAttr(fields, h.group, attr)
+		return true
+	})
+
+	logger := h.logger
+	if ctx != nil {
+		logger = logger.WithContext(ctx)
+	}
+
+	switch fromSlogLevel(record.Level) {
+	case DebugLevel:
+		logger.Debug(record.Message, fields...)
+	case InfoLevel:
+		logger.Info(record.Message, fields...)
+	case WarnLevel:
+		logger.Warn(record.Message, fields...)
+	default:
+		logger.Error(record.Message, fields...)
+	}
+	return nil
+}
+
+// WithAttrs returns a handler whose logger carries attrs as fields.
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make([]Field, 0, len(attrs))
+	for _, attr := range attrs {
+		fields = appendSlogAttr(fields, h.group, attr)
+	}
+	return &slogHandler{logger: h.logger.WithFields(fields...), group: h.group}
+}
+
+// WithGroup returns a handler prefixing the keys of later attributes with name.
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &slogHandler{logger: h.logger, group: h.group + name + "."}
+}
+
+// fromSlogLevel maps a slog level to the closest Level at or below it.
+func fromSlogLevel(level slog.Level) Level {
+	switch {
+	case level < slog.LevelInfo:
+		return DebugLevel
+	case level < slog.LevelWarn:
+		return InfoLevel
+	case level < slog.LevelError:
+		return WarnLevel
+	default:
+		return ErrorLevel
+	}
+}
+
+// appendSlogAttr appends attr as a field keyed by prefix and the attribute key, flattening groups.
+// Empty attributes are skipped, as slog.Handler requires.
+func appendSlogAttr(fields []Field, prefix string, attr slog.Attr) []Field {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		// Attributes of a group without a key are inlined
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+		}
+		for _, groupAttr := range attr.Value.Group() {
+			fields = appendSlogAttr(fields, prefix, groupAttr)
+		}
+		return fields
+	}
+	return append(fields, Any(prefix+attr.Key, attr.Value.Any()))
+}
@@ -0,0 +1,98 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestSlogHandler(t *testing.T) {
+	tests := []struct {
+		name          string
+		log           func(l *slog.Logger)
+		expectLevel   string
+		expectMessage string
+		expectFields  map[string]any
+	}{
+		{
+			name:          "info with key value",
+			log:           func(l *slog.Logger) { l.Info("msg", "key", "val") },
+			expectLevel:   "info",
+			expectMessage: "msg",
+			expectFields:  map[string]any{"key": "val"},
+		},
+		{
+			name:          "debug",
+			log:           func(l *slog.Logger) { l.Debug("cache miss", slog.Int("attempt", 2)) },
+			expectLevel:   "debug",
+			expectMessage: "cache miss",
+			expectFields:  map[string]any{"attempt": float64(2)},
+		},
+		{
+			name:          "warn",
+			log:           func(l *slog.Logger) { l.Warn("slow query") },
+			expectLevel:   "warn",
+			expectMessage: "slow query",
+		},
+		{
+			name:          "error",
+			log:           func(l *slog.Logger) { l.Error("request failed", "status", 500) },
+			expectLevel:   "error",
+			expectMessage: "request failed",
+			expectFields:  map[string]any{"status": float64(500)},
+		},
+		{
+			name:          "with attrs and group",
+			log:           func(l *slog.Logger) { l.With("service", "users").WithGroup("request").Info("handled", "method", "GET") },
+			expectLevel:   "info",
+			expectMessage: "handled",
+			expectFields:  map[string]any{"service": "users", "request.method": "GET"},
+		},
+		{
+			name:          "group attribute",
+			log:           func(l *slog.Logger) { l.Info("handled", slog.Group("http", "status", 200, "path", "/users")) },
+			expectLevel:   "info",
+			expectMessage: "handled",
+			expectFields:  map[string]any{"http.status": float64(200), "http.path": "/users"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tt.log(slog.New(NewSlogHandler(NewConsoleLoggerWithWriter(DebugLevel, &buf, false))))
+
+			var entry map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("Failed to parse log output %q: %v", buf.String(), err)
+			}
+			if entry["level"] != tt.expectLevel {
+				t.Errorf("Expected level %q, got %v", tt.expectLevel, entry["level"])
+			}
+			if entry["message"] != tt.expectMessage {
+				t.Errorf("Expected message %q, got %v", tt.expectMessage, entry["message"])
+			}
+			for key, expected := range tt.expectFields {
+				if entry[key] != expected {
+					t.Errorf("Expected field %s=%v, got %v", key, expected, entry[key])
+				}
+			}
+		})
+	}
+}
+
+func TestSlogHandlerEnabled(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewSlogHandler(NewConsoleLoggerWithWriter(WarnLevel, &buf, false)))
+
+	logger.Info("filtered")
+	if buf.Len() != 0 {
+		t.Errorf("Expected info to be filtered at warn level, got %q", buf.String())
+	}
+
+	logger.Warn("kept")
+	if buf.Len() == 0 {
+		t.Error("Expected the warning to be logged")
+	}
+}
@@ -0,0 +1,65 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/rs/zerolog"
+)
+
+// ToZerolog returns a zerolog.Logger whose events are logged through l, for libraries that only accept
+// a zerolog.Logger. Event fields are passed on as fields, except zerolog's own time field.
+func ToZerolog(l Logger) zerolog.Logger {
+	return zerolog.New(zerologWriter{logger: l})
+}
+
+// zerologWriter decodes the JSON events written by zerolog and forwards them to the wrapped logger.
+type zerologWriter struct {
+	logger Logger
+}
+
+// Write forwards an event without a known level at info level.
+func (w zerologWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel forwards the event to the logger method of its level. Fatal and panic events are logged
+// as errors since zerolog exits or panics itself once the event is written.
+func (w zerologWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	decoder := json.NewDecoder(bytes.NewReader(p))
+	decoder.UseNumber()
+
+	var event map[string]any
+	if err := decoder.Decode(&event); err != nil {
+		return 0, fmt.Errorf("decode zerolog event: %w", err)
+	}
+
+	msg, _ := event[zerolog.MessageFieldName].(string)
+	delete(event, zerolog.MessageFieldName)
+	delete(event, zerolog.LevelFieldName)
+	delete(event, zerolog.TimestampFieldName)
+
+	keys := make([]string, 0, len(event))
+	for key := range event {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fields := make([]Field, 0, len(keys))
+	for _, key := range keys {
+		fields = append(fields, Any(key, event[key]))
+	}
+
+	switch level {
+	case zerolog.TraceLevel, zerolog.DebugLevel:
+		w.logger.Debug(msg, fields...)
+	case zerolog.WarnLevel:
+		w.logger.Warn(msg, fields...)
+	case zerolog.ErrorLevel, zerolog.FatalLevel, zerolog.PanicLevel:
+		w.logger.Error(msg, fields...)
+	default:
+		w.logger.Info(msg, fields...)
+	}
+	return len(p), nil
+}
@@ -0,0 +1,92 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestToZerolog(t *testing.T) {
+	tests := []struct {
+		name          string
+		log           func(z zerolog.Logger)
+		expectLevel   string
+		expectMessage string
+		expectFields  map[string]any
+	}{
+		{
+			name: "info with fields",
+			log: func(z zerolog.Logger) {
+				z.Info().Str("migration", "0001_init").Int("version", 1).Msg("applied migration")
+			},
+			expectLevel:   "info",
+			expectMessage: "applied migration",
+			expectFields:  map[string]any{"migration": "0001_init", "version": float64(1)},
+		},
+		{
+			name:          "debug",
+			log:           func(z zerolog.Logger) { z.Debug().Msg("checking lock") },
+			expectLevel:   "debug",
+			expectMessage: "checking lock",
+		},
+		{
+			name:          "warn",
+			log:           func(z zerolog.Logger) { z.Warn().Msg("dirty database") },
+			expectLevel:   "warn",
+			expectMessage: "dirty database",
+		},
+		{
+			name:          "error",
+			log:           func(z zerolog.Logger) { z.Error().Str("error", "boom").Msg("migration failed") },
+			expectLevel:   "error",
+			expectMessage: "migration failed",
+			expectFields:  map[string]any{"error": "boom"},
+		},
+		{
+			name:          "without level",
+			log:           func(z zerolog.Logger) { z.Log().Msg("plain message") },
+			expectLevel:   "info",
+			expectMessage: "plain message",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tt.log(ToZerolog(NewConsoleLoggerWithWriter(DebugLevel, &buf, false)))
+
+			var entry map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("Failed to parse log output %q: %v", buf.String(), err)
+			}
+			if entry["level"] != tt.expectLevel {
+				t.Errorf("Expected level %q, got %v", tt.expectLevel, entry["level"])
+			}
+			if entry["message"] != tt.expectMessage {
+				t.Errorf("Expected message %q, got %v", tt.expectMessage, entry["message"])
+			}
+			for key, expected := range tt.expectFields {
+				if entry[key] != expected {
+					t.Errorf("Expected field %s=%v, got %v", key, expected, entry[key])
+				}
+			}
+		})
+	}
+}
+
+func TestToZerologRespectsLevel(t *testing.T) {
+	var buf bytes.Buffer
+	z := ToZerolog(NewConsoleLoggerWithWriter(WarnLevel, &buf, false))
+
+	z.Info().Msg("filtered by the wrapped logger")
+	if buf.Len() != 0 {
+		t.Errorf("Expected info to be filtered by the wrapped logger, got %q", buf.String())
+	}
+
+	z.Warn().Msg("kept")
+	if buf.Len() == 0 {
+		t.Error("Expected the warning to be logged")
+	}
+}
@@ -0,0 +1,158 @@
+package messaging
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+	"github.com/spf13/viper"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// KafkaConfig holds the Kafka producer configuration
+type KafkaConfig struct {
+	Brokers      []string        `mapstructure:"brokers"`
+	TopicPrefix  string          `mapstructure:"topic_prefix"`
+	WriteTimeout time.Duration   `mapstructure:"write_timeout"`
+	TLS          bool            `mapstructure:"tls"`
+	SASL         KafkaSASLConfig `mapstructure:"sasl"`
+}
+
+// KafkaSASLConfig holds the SASL credentials for the Kafka brokers
+type KafkaSASLConfig struct {
+	Mechanism string `mapstructure:"mechanism"` // plain, scram-sha-256 or scram-sha-512
+	Username  string `mapstructure:"username"`
+	Password  string `mapstructure:"password"`
+}
+
+// messageWriter is the subset of *kafka.Writer used by the producer
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// KafkaProducer publishes messages to Kafka topics
+type KafkaProducer struct {
+	writer      messageWriter
+	topicPrefix string
+	logger      log.Logger
+}
+
+// NewKafkaProducer creates a producer from the messaging.kafka section of the config.
+// Publish waits until all in-sync replicas have acknowledged the message.
+func NewKafkaProducer(conf *viper.Viper, logger log.Logger) (*KafkaProducer, error) {
+	config, err := parseKafkaConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	mechanism, err := saslMechanism(config.SASL)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &kafka.Transport{SASL: mechanism}
+	if config.TLS {
+		transport.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	writer := &kafka.Writer{
+		Addr:                   kafka.TCP(config.Brokers...),
+		Balancer:               &kafka.Hash{},
+		RequiredAcks:           kafka.RequireAll,
+		WriteTimeout:           config.WriteTimeout,
+		AllowAutoTopicCreation: false,
+		Transport:              transport,
+	}
+
+	logger.Info("Kafka producer created", log.String("brokers", strings.Join(config.Brokers, ",")))
+	return newKafkaProducer(writer, config.TopicPrefix, logger), nil
+}
+
+// newKafkaProducer creates a producer around an existing writer
+func newKafkaProducer(writer messageWriter, topicPrefix string, logger log.Logger) *KafkaProducer {
+	return &KafkaProducer{
+		writer:      writer,
+		topicPrefix: topicPrefix,
+		logger:      logger,
+	}
+}
+
+// parseKafkaConfig extracts the Kafka configuration from Viper
+func parseKafkaConfig(conf *viper.Viper) (*KafkaConfig, error) {
+	config := &KafkaConfig{
+		WriteTimeout: 10 * time.Second,
+	}
+
+	if err := conf.UnmarshalKey("messaging.kafka", config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal messaging.kafka config: %w", err)
+	}
+
+	// Allow a comma separated broker list, e.g. from an environment variable
+	if len(config.Brokers) == 1 && strings.Contains(config.Brokers[0], ",") {
+		config.Brokers = strings.Split(config.Brokers[0], ",")
+	}
+	if len(config.Brokers) == 0 {
+		return nil, errors.New("messaging.kafka.brokers is required")
+	}
+
+	return config, nil
+}
+
+// saslMechanism builds the SASL mechanism for the configured credentials
+func saslMechanism(config KafkaSASLConfig) (sasl.Mechanism, error) {
+	switch strings.ToLower(config.Mechanism) {
+	case "":
+		return nil, nil
+	case "plain":
+		return plain.Mechanism{Username: config.Username, Password: config.Password}, nil
+	case "scram-sha-256":
+		return scram.Mechanism(scram.SHA256, config.Username, config.Password)
+	case "scram-sha-512":
+		return scram.Mechanism(scram.SHA512, config.Username, config.Password)
+	default:
+		return nil, fmt.Errorf("unsupported messaging.kafka.sasl.mechanism %q", config.Mechanism)
+	}
+}
+
+// Publish writes a message to the topic (with the configured prefix) and waits for the broker acknowledgement
+func (p *KafkaProducer) Publish(ctx context.Context, topic string, key []byte, value []byte) error {
+	topic = p.topicPrefix + topic
+
+	err := p.writer.WriteMessages(ctx, kafka.Message{
+		Topic: topic,
+		Key:   key,
+		Value: value,
+		Time:  time.Now(),
+	})
+	if err != nil {
+		p.logger.Error("Failed to publish message", log.Error(err), log.String("topic", topic))
+		return fmt.Errorf("failed to publish to %s: %w", topic, err)
+	}
+
+	p.logger.Debug("Published message", log.String("topic", topic), log.Int("bytes", len(value)))
+	return nil
+}
+
+// PublishJSON encodes payload as JSON and publishes it without a key
+func (p *KafkaProducer) PublishJSON(ctx context.Context, topic string, payload interface{}) error {
+	value, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode message for %s: %w", topic, err)
+	}
+	return p.Publish(ctx, topic, nil, value)
+}
+
+// Close flushes pending messages and closes the broker connections
+func (p *KafkaProducer) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,154 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/spf13/viper"
+
+	"github.com/MayukhSobo/scaffold/pkg/log"
+)
+
+// fakeWriter records written messages and acknowledges them with ackErr
+type fakeWriter struct {
+	messages []kafka.Message
+	ackErr   error
+	closed   bool
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	if w.ackErr != nil {
+		return w.ackErr
+	}
+	w.messages = append(w.messages, msgs...)
+	return nil
+}
+
+func (w *fakeWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+func createTestLogger() log.Logger {
+	var buf bytes.Buffer
+	return log.NewConsoleLoggerWithWriter(log.InfoLevel, &buf, false)
+}
+
+func TestKafkaProducerPublish(t *testing.T) {
+	writer := &fakeWriter{}
+	producer := newKafkaProducer(writer, "scaffold.", createTestLogger())
+
+	if err := producer.Publish(context.Background(), "users", []byte("42"), []byte("created")); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(writer.messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(writer.messages))
+	}
+	msg := writer.messages[0]
+	if msg.Topic != "scaffold.users" {
+		t.Errorf("Expected topic scaffold.users, got %s", msg.Topic)
+	}
+	if string(msg.Key) != "42" || string(msg.Value) != "created" {
+		t.Errorf("Unexpected key/value: %s/%s", msg.Key, msg.Value)
+	}
+}
+
+func TestKafkaProducerPublishJSON(t *testing.T) {
+	writer := &fakeWriter{}
+	producer := newKafkaProducer(writer, "", createTestLogger())
+
+	type userCreated struct {
+		ID       uint64 `json:"id"`
+		Username string `json:"username"`
+	}
+
+	if err := producer.PublishJSON(context.Background(), "user.created", userCreated{ID: 7, Username: "janedoe"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var decoded userCreated
+	if err := json.Unmarshal(writer.messages[0].Value, &decoded); err != nil {
+		t.Fatalf("Message value should be JSON: %v", err)
+	}
+	if decoded.ID != 7 || decoded.Username != "janedoe" {
+		t.Errorf("Unexpected payload: %+v", decoded)
+	}
+
+	if err := producer.PublishJSON(context.Background(), "user.created", func() {}); err == nil {
+		t.Error("Expected error for payload that can't be encoded, got nil")
+	}
+}
+
+func TestKafkaProducerDeliveryFailure(t *testing.T) {
+	ackErr := errors.New("not enough replicas")
+	writer := &fakeWriter{ackErr: ackErr}
+	producer := newKafkaProducer(writer, "", createTestLogger())
+
+	err := producer.Publish(context.Background(), "users", nil, []byte("created"))
+	if !errors.Is(err, ackErr) {
+		t.Errorf("Expected the unacknowledged write to surface, got %v", err)
+	}
+
+	if err := producer.Close(); err != nil || !writer.closed {
+		t.Error("Expected Close to close the writer")
+	}
+}
+
+func TestNewKafkaProducer(t *testing.T) {
+	conf := viper.New()
+	conf.Set("messaging.kafka.brokers", "kafka-1:9092,kafka-2:9092")
+	conf.Set("messaging.kafka.topic_prefix", "scaffold.")
+	conf.Set("messaging.kafka.sasl.mechanism", "scram-sha-512")
+	conf.Set("messaging.kafka.sasl.username", "app")
+	conf.Set("messaging.kafka.sasl.password", "secret")
+
+	producer, err := NewKafkaProducer(conf, createTestLogger())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer func() { _ = producer.Close() }()
+
+	writer, ok := producer.writer.(*kafka.Writer)
+	if !ok {
+		t.Fatalf("Expected a *kafka.Writer, got %T", producer.writer)
+	}
+	if writer.RequiredAcks != kafka.RequireAll {
+		t.Errorf("Expected RequireAll acks, got %v", writer.RequiredAcks)
+	}
+	if writer.Addr.String() != "kafka-1:9092,kafka-2:9092" {
+		t.Errorf("Unexpected broker address %s", writer.Addr.String())
+	}
+	if producer.topicPrefix != "scaffold." {
+		t.Errorf("Expected topic prefix scaffold., got %s", producer.topicPrefix)
+	}
+}
+
+func TestNewKafkaProducerInvalidConfig(t *testing.T) {
+	testCases := []struct {
+		name     string
+		settings map[string]interface{}
+	}{
+		{"missing brokers", map[string]interface{}{}},
+		{"unsupported sasl mechanism", map[string]interface{}{
+			"messaging.kafka.brokers":        []string{"localhost:9092"},
+			"messaging.kafka.sasl.mechanism": "gssapi",
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conf := viper.New()
+			for key, value := range tc.settings {
+				conf.Set(key, value)
+			}
+			if _, err := NewKafkaProducer(conf, createTestLogger()); err == nil {
+				t.Error("Expected an error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// byteUnits maps size suffixes to their multiplier (binary, 1KB = 1024B).
+var byteUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// ParseByteSize parses a human-readable size such as "4MB", "512KB" or "1GB" into bytes.
+// Units are case-insensitive and a bare number is treated as bytes.
+func ParseByteSize(s string) (int, error) {
+	value := strings.ToUpper(strings.TrimSpace(s))
+	if value == "" {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+
+	multiplier := int64(1)
+	for _, unit := range byteUnits {
+		if strings.HasSuffix(value, unit.suffix) {
+			multiplier = unit.multiplier
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+	if n > math.MaxInt/multiplier {
+		return 0, fmt.Errorf("byte size %q overflows", s)
+	}
+
+	return int(n * multiplier), nil
+}
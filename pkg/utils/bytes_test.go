@@ -0,0 +1,43 @@
+package utils
+
+import "testing"
+
+func TestParseByteSize(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected int
+	}{
+		{"0", 0},
+		{"100", 100},
+		{"100B", 100},
+		{"512KB", 512 * 1024},
+		{"4MB", 4 * 1024 * 1024},
+		{"1GB", 1024 * 1024 * 1024},
+		{"4mb", 4 * 1024 * 1024},
+		{" 2 KB ", 2 * 1024},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			n, err := ParseByteSize(tc.input)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if n != tc.expected {
+				t.Errorf("Expected %d, got %d", tc.expected, n)
+			}
+		})
+	}
+}
+
+func TestParseByteSizeInvalid(t *testing.T) {
+	testCases := []string{"", "MB", "abc", "-1MB", "1.5MB", "4TB", "99999999999999999GB"}
+
+	for _, input := range testCases {
+		t.Run(input, func(t *testing.T) {
+			if _, err := ParseByteSize(input); err == nil {
+				t.Errorf("Expected error for %q, got nil", input)
+			}
+		})
+	}
+}
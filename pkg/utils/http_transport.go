@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+)
+
+// HeaderCorrelationID carries the correlation ID between services
+const HeaderCorrelationID = "X-Correlation-ID"
+
+// correlationIDKey is the context key for the correlation ID.
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a copy of ctx carrying the correlation ID.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// GetCorrelationID returns the correlation ID stored in ctx, if any.
+func GetCorrelationID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(correlationIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// CorrelationIDTransport is an http.RoundTripper that forwards the correlation ID from the
+// request context as the X-Correlation-ID header. Base defaults to http.DefaultTransport.
+type CorrelationIDTransport struct {
+	Base http.RoundTripper
+}
+
+// RoundTrip sets X-Correlation-ID unless the request already has one.
+func (t *CorrelationIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	id, ok := GetCorrelationID(req.Context())
+	if !ok || req.Header.Get(HeaderCorrelationID) != "" {
+		return base.RoundTrip(req)
+	}
+
+	// RoundTrippers must not modify the caller's request
+	req = req.Clone(req.Context())
+	req.Header.Set(HeaderCorrelationID, id)
+	return base.RoundTrip(req)
+}
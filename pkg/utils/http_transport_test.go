@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCorrelationIDTransport(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(HeaderCorrelationID)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &CorrelationIDTransport{}}
+
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		header   string
+		expected string
+	}{
+		{name: "forwards context ID", ctx: WithCorrelationID(context.Background(), "corr-abc"), expected: "corr-abc"},
+		{name: "keeps explicit header", ctx: WithCorrelationID(context.Background(), "corr-abc"), header: "corr-explicit", expected: "corr-explicit"},
+		{name: "no ID in context", ctx: context.Background(), expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			received = ""
+			req, err := http.NewRequestWithContext(tc.ctx, "GET", server.URL, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			if tc.header != "" {
+				req.Header.Set(HeaderCorrelationID, tc.header)
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			resp.Body.Close()
+
+			if received != tc.expected {
+				t.Errorf("Expected forwarded correlation ID %q, got %q", tc.expected, received)
+			}
+			if tc.header == "" && req.Header.Get(HeaderCorrelationID) != "" {
+				t.Error("Expected the caller's request headers to be left unchanged")
+			}
+		})
+	}
+}
+
+func TestGetCorrelationIDMissing(t *testing.T) {
+	if id, ok := GetCorrelationID(context.Background()); ok || id != "" {
+		t.Errorf("Expected no correlation ID, got %q", id)
+	}
+}
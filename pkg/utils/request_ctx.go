@@ -0,0 +1,26 @@
+package utils
+
+import "context"
+
+// RequestContext carries request metadata from the HTTP layer to service calls.
+type RequestContext struct {
+	UserID    uint64
+	Role      string
+	Locale    string
+	RequestID string
+	TraceID   string
+}
+
+// requestContextKey is the context key for RequestContext values.
+type requestContextKey struct{}
+
+// WithRequestContext returns a copy of ctx carrying rc.
+func WithRequestContext(ctx context.Context, rc RequestContext) context.Context {
+	return context.WithValue(ctx, requestContextKey{}, rc)
+}
+
+// GetRequestContext returns the RequestContext stored in ctx, if any.
+func GetRequestContext(ctx context.Context) (RequestContext, bool) {
+	rc, ok := ctx.Value(requestContextKey{}).(RequestContext)
+	return rc, ok
+}
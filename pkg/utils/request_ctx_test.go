@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRequestContextRoundTrip(t *testing.T) {
+	rc := RequestContext{
+		UserID:    42,
+		Role:      "admin",
+		Locale:    "en-GB",
+		RequestID: "req-123",
+		TraceID:   "4bf92f3577b34da6a3ce929d0e0e4736",
+	}
+
+	ctx := WithRequestContext(context.Background(), rc)
+
+	got, ok := GetRequestContext(ctx)
+	if !ok {
+		t.Fatal("Expected request context to be present")
+	}
+	if got != rc {
+		t.Errorf("Expected %+v, got %+v", rc, got)
+	}
+}
+
+func TestGetRequestContextMissing(t *testing.T) {
+	rc, ok := GetRequestContext(context.Background())
+	if ok {
+		t.Error("Expected no request context on a plain context")
+	}
+	if rc != (RequestContext{}) {
+		t.Errorf("Expected zero value, got %+v", rc)
+	}
+}
+
+func TestWithRequestContextOverrides(t *testing.T) {
+	ctx := WithRequestContext(context.Background(), RequestContext{UserID: 1})
+	ctx = WithRequestContext(ctx, RequestContext{UserID: 2})
+
+	if rc, _ := GetRequestContext(ctx); rc.UserID != 2 {
+		t.Errorf("Expected the innermost request context, got user %d", rc.UserID)
+	}
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// ErrInvalidSSEEvent is returned by SSEWriter.Send for event names containing a line break
+var ErrInvalidSSEEvent = errors.New("sse: event name contains a line break")
+
+// SSEWriter writes Server-Sent Events to a streamed response, following the EventSource format
+type SSEWriter struct {
+	ctx *fiber.Ctx
+	w   *bufio.Writer
+}
+
+// NewSSEWriter returns an SSEWriter writing the events of the request c to w
+func NewSSEWriter(c *fiber.Ctx, w *bufio.Writer) *SSEWriter {
+	return &SSEWriter{ctx: c, w: w}
+}
+
+// Context returns the request the events are sent for
+func (s *SSEWriter) Context() *fiber.Ctx {
+	return s.ctx
+}
+
+// Send writes an event and flushes it to the client. An empty event is sent without an event field,
+// which the client receives as a "message" event. Each line of data becomes a data field.
+// It returns the write error once the client has disconnected.
+func (s *SSEWriter) Send(event, data string) error {
+	if strings.ContainsAny(event, "\r\n") {
+		return ErrInvalidSSEEvent
+	}
+
+	var b strings.Builder
+	if event != "" {
+		b.WriteString("event: " + event + "\n")
+	}
+	for _, line := range splitSSELines(data) {
+		b.WriteString("data: " + line + "\n")
+	}
+	b.WriteString("\n")
+	return s.write(b.String())
+}
+
+// Comment writes a comment the client ignores, eg: to keep an idle connection open
+func (s *SSEWriter) Comment(text string) error {
+	var b strings.Builder
+	for _, line := range splitSSELines(text) {
+		b.WriteString(": " + line + "\n")
+	}
+	b.WriteString("\n")
+	return s.write(b.String())
+}
+
+// write writes the frame and flushes it, so the client receives it immediately
+func (s *SSEWriter) write(frame string) error {
+	if _, err := s.w.WriteString(frame); err != nil {
+		return err
+	}
+	return s.w.Flush()
+}
+
+// splitSSELines splits text on CRLF, LF or CR, the line breaks of the EventSource format
+func splitSSELines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	return strings.Split(text, "\n")
+}
+
+// StreamSSE sets the event stream headers on c and streams the events written by handler once the
+// route handler returns. handler receives a context bound to the same request, as c is released by then;
+// locals and the query are available from it, route params are not.
+func StreamSSE(c *fiber.Ctx, handler func(*SSEWriter, *fiber.Ctx)) error {
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no") // Disables response buffering in nginx
+
+	app := c.App()
+	requestCtx := c.Context()
+	requestCtx.SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+		streamCtx := app.AcquireCtx(requestCtx)
+		defer app.ReleaseCtx(streamCtx)
+		handler(NewSSEWriter(streamCtx, w), streamCtx)
+	}))
+	return nil
+}
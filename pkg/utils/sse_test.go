@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"bufio"
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// readSSEFrames reads the stream and returns its frames, the lines written before each blank line
+func readSSEFrames(t *testing.T, r io.Reader) [][]string {
+	t.Helper()
+	var frames [][]string
+	var frame []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			frames = append(frames, frame)
+			frame = nil
+			continue
+		}
+		frame = append(frame, line)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Failed to read stream: %v", err)
+	}
+	if frame != nil {
+		t.Errorf("Expected the stream to end with a blank line, got trailing %q", frame)
+	}
+	return frames
+}
+
+func TestStreamSSE(t *testing.T) {
+	tests := []struct {
+		name     string
+		write    func(w *SSEWriter) error
+		expected [][]string
+	}{
+		{
+			name:     "named event",
+			write:    func(w *SSEWriter) error { return w.Send("user_created", `{"id":1}`) },
+			expected: [][]string{{"event: user_created", `data: {"id":1}`}},
+		},
+		{
+			name:     "unnamed event",
+			write:    func(w *SSEWriter) error { return w.Send("", "hello") },
+			expected: [][]string{{"data: hello"}},
+		},
+		{
+			name:     "multiline data",
+			write:    func(w *SSEWriter) error { return w.Send("log", "first\nsecond\r\nthird") },
+			expected: [][]string{{"event: log", "data: first", "data: second", "data: third"}},
+		},
+		{
+			name:     "comment",
+			write:    func(w *SSEWriter) error { return w.Comment("keep-alive") },
+			expected: [][]string{{": keep-alive"}},
+		},
+		{
+			name: "several events",
+			write: func(w *SSEWriter) error {
+				if err := w.Send("tick", "1"); err != nil {
+					return err
+				}
+				return w.Send("tick", "2")
+			},
+			expected: [][]string{{"event: tick", "data: 1"}, {"event: tick", "data: 2"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/events", func(c *fiber.Ctx) error {
+				return StreamSSE(c, func(w *SSEWriter, c *fiber.Ctx) {
+					if err := tt.write(w); err != nil {
+						t.Errorf("Failed to write event: %v", err)
+					}
+				})
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/events", nil))
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if contentType := resp.Header.Get(fiber.HeaderContentType); contentType != "text/event-stream" {
+				t.Errorf("Expected content type %q, got %q", "text/event-stream", contentType)
+			}
+			if cacheControl := resp.Header.Get(fiber.HeaderCacheControl); cacheControl != "no-cache" {
+				t.Errorf("Expected cache control %q, got %q", "no-cache", cacheControl)
+			}
+
+			if frames := readSSEFrames(t, resp.Body); !reflect.DeepEqual(frames, tt.expected) {
+				t.Errorf("Expected frames %q, got %q", tt.expected, frames)
+			}
+		})
+	}
+}
+
+func TestStreamSSEContext(t *testing.T) {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", "42")
+		return c.Next()
+	})
+	app.Get("/events", func(c *fiber.Ctx) error {
+		return StreamSSE(c, func(w *SSEWriter, c *fiber.Ctx) {
+			if w.Context() != c {
+				t.Error("Expected the writer context to be the handler context")
+			}
+			_ = w.Send("whoami", c.Locals("user_id").(string)+" "+c.Query("topic"))
+		})
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/events?topic=users", nil))
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "data: 42 users\n") {
+		t.Errorf("Expected locals and query in the stream, got %q", body)
+	}
+}
+
+func TestSSEWriterInvalidEvent(t *testing.T) {
+	w := NewSSEWriter(nil, bufio.NewWriter(io.Discard))
+	if err := w.Send("bad\nevent", "data"); err != ErrInvalidSSEEvent {
+		t.Errorf("Expected %v, got %v", ErrInvalidSSEEvent, err)
+	}
+}
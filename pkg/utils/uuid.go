@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"github.com/google/uuid"
+)
+
+// NewUUID generates a random (version 4) UUID string.
+func NewUUID() string {
+	return uuid.NewString()
+}
+
+// ParseUUID parses s into a UUID.
+func ParseUUID(s string) (uuid.UUID, error) {
+	return uuid.Parse(s)
+}
+
+// MustParseUUID parses s into a UUID and panics if it is invalid.
+func MustParseUUID(s string) uuid.UUID {
+	return uuid.MustParse(s)
+}
+
+// IsValidUUID reports whether s is a well-formed, non-nil UUID.
+func IsValidUUID(s string) bool {
+	id, err := uuid.Parse(s)
+	return err == nil && id != uuid.Nil
+}
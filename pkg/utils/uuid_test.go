@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewUUID(t *testing.T) {
+	id := NewUUID()
+
+	parsed, err := ParseUUID(id)
+	if err != nil {
+		t.Fatalf("NewUUID() returned unparsable UUID %s: %v", id, err)
+	}
+	if parsed.Version() != 4 {
+		t.Errorf("Expected version 4 UUID, got version %d", parsed.Version())
+	}
+	if NewUUID() == id {
+		t.Error("NewUUID() should return unique values")
+	}
+}
+
+func TestUUIDValidation(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		valid   bool
+		parses  bool
+		version uuid.Version
+	}{
+		{"valid v4", "f47ac10b-58cc-4372-a567-0e02b2c3d479", true, true, 4},
+		{"valid v1", "6ba7b810-9dad-11d1-80b4-00c04fd430c8", true, true, 1},
+		{"invalid string", "not-a-uuid", false, false, 0},
+		{"empty string", "", false, false, 0},
+		{"nil UUID", "00000000-0000-0000-0000-000000000000", false, true, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsValidUUID(tc.input); got != tc.valid {
+				t.Errorf("IsValidUUID(%q) = %v, expected %v", tc.input, got, tc.valid)
+			}
+
+			parsed, err := ParseUUID(tc.input)
+			if (err == nil) != tc.parses {
+				t.Errorf("ParseUUID(%q) error = %v, expected parse success %v", tc.input, err, tc.parses)
+			}
+			if tc.parses && parsed.Version() != tc.version {
+				t.Errorf("Expected version %d, got %d", tc.version, parsed.Version())
+			}
+		})
+	}
+}
+
+func TestMustParseUUID(t *testing.T) {
+	id := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	if MustParseUUID(id).String() != id {
+		t.Errorf("MustParseUUID(%q) did not round-trip", id)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustParseUUID should panic on invalid input")
+		}
+	}()
+	MustParseUUID("not-a-uuid")
+}
@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	validate     *validator.Validate
+	validateOnce sync.Once
+)
+
+// Validator returns the shared validator instance, reporting fields by their json name
+func Validator() *validator.Validate {
+	validateOnce.Do(func() {
+		validate = validator.New(validator.WithRequiredStructEnabled())
+		validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return field.Name
+			}
+			return name
+		})
+	})
+	return validate
+}
+
+// Validatable is implemented by request DTOs with checks that struct tags cannot express
+type Validatable interface {
+	Valid() error
+}
+
+// FieldError describes a single failed check, Field is empty for errors returned by Valid
+type FieldError struct {
+	Field   string `json:"field,omitempty"`
+	Rule    string `json:"rule,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists the failed checks of a value
+type ValidationError struct {
+	Fields []FieldError `json:"fields"`
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		if field.Field == "" {
+			messages[i] = field.Message
+			continue
+		}
+		messages[i] = field.Field + " " + field.Message
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Validate runs the `validate` struct tags of v, then its Valid method when it implements Validatable.
+// Failed checks are returned as a *ValidationError.
+func Validate(v interface{}) error {
+	if err := Validator().Struct(v); err != nil {
+		var fieldErrs validator.ValidationErrors
+		if !errors.As(err, &fieldErrs) {
+			return err
+		}
+
+		verr := &ValidationError{Fields: make([]FieldError, len(fieldErrs))}
+		for i, fe := range fieldErrs {
+			verr.Fields[i] = FieldError{
+				Field:   fieldPath(fe),
+				Rule:    fe.Tag(),
+				Message: ruleMessage(fe),
+			}
+		}
+		return verr
+	}
+
+	if validatable, ok := v.(Validatable); ok {
+		if err := validatable.Valid(); err != nil {
+			var verr *ValidationError
+			if errors.As(err, &verr) {
+				return verr
+			}
+			return &ValidationError{Fields: []FieldError{{Message: err.Error()}}}
+		}
+	}
+	return nil
+}
+
+// fieldPath returns the json path of the field without the top-level struct name, eg: address.city
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
+	}
+	return path
+}
+
+// ruleMessage describes the failed rule for API clients
+func ruleMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "min":
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "max":
+		return fmt.Sprintf("must be at most %s", fe.Param())
+	case "len":
+		return fmt.Sprintf("must have length %s", fe.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", fe.Param())
+	default:
+		return fmt.Sprintf("failed the %s rule", fe.Tag())
+	}
+}
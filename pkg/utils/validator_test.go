@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+)
+
+type signupRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=8"`
+	Confirm  string `json:"confirm"`
+}
+
+func (r signupRequest) Valid() error {
+	if r.Password != r.Confirm {
+		return errors.New("passwords do not match")
+	}
+	return nil
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		req      signupRequest
+		expected []FieldError
+	}{
+		{
+			name: "valid",
+			req:  signupRequest{Email: "jane@example.com", Password: "password1", Confirm: "password1"},
+		},
+		{
+			name: "tag failures use json names",
+			req:  signupRequest{Email: "not-an-email", Password: "short", Confirm: "short"},
+			expected: []FieldError{
+				{Field: "email", Rule: "email", Message: "must be a valid email address"},
+				{Field: "password", Rule: "min", Message: "must be at least 8"},
+			},
+		},
+		{
+			name:     "missing field",
+			req:      signupRequest{Password: "password1", Confirm: "password1"},
+			expected: []FieldError{{Field: "email", Rule: "required", Message: "is required"}},
+		},
+		{
+			name:     "Valid runs after the tags pass",
+			req:      signupRequest{Email: "jane@example.com", Password: "password1", Confirm: "password2"},
+			expected: []FieldError{{Message: "passwords do not match"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(&tc.req)
+			if tc.expected == nil {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Expected *ValidationError, got %v", err)
+			}
+			if len(verr.Fields) != len(tc.expected) {
+				t.Fatalf("Expected %d field errors, got %v", len(tc.expected), verr.Fields)
+			}
+			for i, expected := range tc.expected {
+				if verr.Fields[i] != expected {
+					t.Errorf("Expected %+v, got %+v", expected, verr.Fields[i])
+				}
+			}
+		})
+	}
+}